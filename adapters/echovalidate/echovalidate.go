@@ -0,0 +1,50 @@
+// Package echovalidate plugs protego validation into Echo.
+//
+// Install the validator once at startup; c.Validate then calls the Validate()
+// method of bound values:
+//
+//	e := echo.New()
+//	e.Validator = echovalidate.New()
+//
+//	func createUser(c echo.Context) error {
+//	    var input CreateUserInput
+//	    if err := c.Bind(&input); err != nil {
+//	        return err
+//	    }
+//	    if err := c.Validate(&input); err != nil {
+//	        return err // 400 Bad Request
+//	    }
+//	    ...
+//	}
+package echovalidate
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/quantumcycle/protego/validation"
+)
+
+// Validator implements echo.Validator using protego.
+type Validator struct{}
+
+var _ echo.Validator = (*Validator)(nil)
+
+// New creates a Validator to assign to echo.Echo.Validator.
+func New() *Validator {
+	return &Validator{}
+}
+
+// Validate validates i if it implements validation.Validatable or validation.ValidatableCtx,
+// the latter with context.Background() as Echo does not pass the request to validators.
+// Validation errors are returned as a 400 *echo.HTTPError carrying the original
+// error as Internal, so Echo's error handler answers with Bad Request. System
+// errors (see validation.IsSystemError) are returned unchanged.
+func (v *Validator) Validate(i any) error {
+	err := validation.ValidateNested(i)
+	if err == nil || validation.IsSystemError(err) {
+		return err
+	}
+	return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+}
@@ -0,0 +1,79 @@
+package echovalidate_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	. "github.com/onsi/gomega"
+
+	"github.com/quantumcycle/protego/adapters/echovalidate"
+	"github.com/quantumcycle/protego/validation"
+)
+
+type createUser struct {
+	Name string `json:"name"`
+}
+
+func (c createUser) Validate() error {
+	return validation.NewFieldError("name", validation.Validate(c.Name, validation.Required[string]()))
+}
+
+type failingInput struct{}
+
+func (failingInput) Validate() error {
+	return errors.New("database unavailable")
+}
+
+func TestValidator(t *testing.T) {
+	e := echo.New()
+	e.Validator = echovalidate.New()
+	e.POST("/users", func(c echo.Context) error {
+		var input createUser
+		if err := c.Bind(&input); err != nil {
+			return err
+		}
+		if err := c.Validate(&input); err != nil {
+			return err
+		}
+		return c.String(http.StatusCreated, input.Name)
+	})
+
+	post := func(body string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest("POST", "/users", strings.NewReader(body))
+		r.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		w := httptest.NewRecorder()
+		e.ServeHTTP(w, r)
+		return w
+	}
+
+	t.Run("accepts valid input", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(post(`{"name":"John"}`).Code).To(Equal(http.StatusCreated))
+	})
+
+	t.Run("rejects invalid input with 400", func(t *testing.T) {
+		g := NewWithT(t)
+		w := post(`{"name":""}`)
+		g.Expect(w.Code).To(Equal(http.StatusBadRequest))
+		g.Expect(w.Body.String()).To(ContainSubstring("name: required"))
+	})
+
+	t.Run("keeps the validation error as internal error", func(t *testing.T) {
+		g := NewWithT(t)
+		err := echovalidate.New().Validate(createUser{})
+		var httpErr *echo.HTTPError
+		g.Expect(errors.As(err, &httpErr)).To(BeTrue())
+		g.Expect(httpErr.Code).To(Equal(http.StatusBadRequest))
+		g.Expect(validation.IsValidationError(httpErr.Internal)).To(BeTrue())
+	})
+
+	t.Run("returns other errors unchanged", func(t *testing.T) {
+		g := NewWithT(t)
+		err := echovalidate.New().Validate(failingInput{})
+		g.Expect(err).To(MatchError("database unavailable"))
+	})
+}
@@ -0,0 +1,55 @@
+// Package fibervalidate plugs protego validation into Fiber.
+//
+// Fiber v2 has no validator hook, so Bind parses the request body and validates it
+// in one call:
+//
+//	app.Post("/users", func(c *fiber.Ctx) error {
+//	    var input CreateUserInput
+//	    if err := fibervalidate.Bind(c, &input); err != nil {
+//	        return err // 400 Bad Request
+//	    }
+//	    ...
+//	})
+//
+// Validator also satisfies Fiber v3's StructValidator interface, so it can be set
+// as fiber.Config.StructValidator when upgrading.
+package fibervalidate
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/quantumcycle/protego/validation"
+)
+
+// Validator validates values implementing validation.Validatable or validation.ValidatableCtx.
+type Validator struct{}
+
+// New creates a Validator.
+func New() *Validator {
+	return &Validator{}
+}
+
+// Validate validates out if it implements validation.Validatable or validation.ValidatableCtx,
+// the latter with context.Background().
+// Validation errors are returned as a 400 *fiber.Error. Other errors are returned unchanged.
+func (v *Validator) Validate(out any) error {
+	return badRequest(validation.ValidateNested(out))
+}
+
+// Bind parses the request body into out with c.BodyParser and validates it, passing
+// c.UserContext() to values implementing validation.ValidatableCtx.
+// Body parsing failures are also reported as 400 *fiber.Error.
+func Bind(c *fiber.Ctx, out any) error {
+	if err := c.BodyParser(out); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	return badRequest(validation.ValidateNestedCtx(c.UserContext(), out))
+}
+
+// badRequest converts validation errors to a 400 *fiber.Error.
+func badRequest(err error) error {
+	if err == nil || validation.IsSystemError(err) {
+		return err
+	}
+	return fiber.NewError(fiber.StatusBadRequest, err.Error())
+}
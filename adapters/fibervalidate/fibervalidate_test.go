@@ -0,0 +1,77 @@
+package fibervalidate_test
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/quantumcycle/protego/adapters/fibervalidate"
+	"github.com/quantumcycle/protego/validation"
+)
+
+type createUser struct {
+	Name string `json:"name"`
+}
+
+func (c createUser) Validate() error {
+	return validation.NewFieldError("name", validation.Validate(c.Name, validation.Required[string]()))
+}
+
+func TestBind(t *testing.T) {
+	app := fiber.New()
+	app.Post("/users", func(c *fiber.Ctx) error {
+		var input createUser
+		if err := fibervalidate.Bind(c, &input); err != nil {
+			return err
+		}
+		return c.Status(http.StatusCreated).SendString(input.Name)
+	})
+
+	post := func(t *testing.T, body string) (int, string) {
+		r := httptest.NewRequest("POST", "/users", strings.NewReader(body))
+		r.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		b, _ := io.ReadAll(resp.Body)
+		return resp.StatusCode, string(b)
+	}
+
+	t.Run("accepts valid input", func(t *testing.T) {
+		g := NewWithT(t)
+		code, body := post(t, `{"name":"John"}`)
+		g.Expect(code).To(Equal(http.StatusCreated))
+		g.Expect(body).To(Equal("John"))
+	})
+
+	t.Run("rejects invalid input with 400", func(t *testing.T) {
+		g := NewWithT(t)
+		code, body := post(t, `{"name":""}`)
+		g.Expect(code).To(Equal(http.StatusBadRequest))
+		g.Expect(body).To(Equal("name: required"))
+	})
+
+	t.Run("rejects malformed body with 400", func(t *testing.T) {
+		g := NewWithT(t)
+		code, _ := post(t, `{"name":`)
+		g.Expect(code).To(Equal(http.StatusBadRequest))
+	})
+}
+
+func TestValidator(t *testing.T) {
+	t.Run("returns fiber errors for validation failures", func(t *testing.T) {
+		g := NewWithT(t)
+		err := fibervalidate.New().Validate(&createUser{})
+		var fiberErr *fiber.Error
+		g.Expect(errors.As(err, &fiberErr)).To(BeTrue())
+		g.Expect(fiberErr.Code).To(Equal(fiber.StatusBadRequest))
+	})
+}
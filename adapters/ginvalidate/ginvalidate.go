@@ -0,0 +1,72 @@
+// Package ginvalidate plugs protego validation into gin's binding flow.
+//
+// Install the validator once at startup; c.Bind and c.ShouldBind then call the
+// Validate() method of bound values instead of evaluating go-playground struct tags:
+//
+//	import (
+//	    "github.com/gin-gonic/gin/binding"
+//	    "github.com/quantumcycle/protego/adapters/ginvalidate"
+//	)
+//
+//	binding.Validator = ginvalidate.New()
+//
+// The package does not import gin: Validator satisfies binding.StructValidator
+// structurally, so it adds no dependency beyond protego itself.
+package ginvalidate
+
+import (
+	"reflect"
+
+	"github.com/quantumcycle/protego/validation"
+)
+
+// Validator implements gin's binding.StructValidator using protego.
+type Validator struct{}
+
+// New creates a Validator to assign to binding.Validator.
+func New() *Validator {
+	return &Validator{}
+}
+
+// ValidateStruct validates obj if it implements validation.Validatable or
+// validation.ValidatableCtx, the latter with context.Background() as gin does not pass
+// the request to validators. Slices and arrays are validated element by element.
+// Other values are accepted as-is.
+func (v *Validator) ValidateStruct(obj any) error {
+	return validate(reflect.ValueOf(obj))
+}
+
+// Engine returns nil, as there is no underlying validation engine to configure.
+func (v *Validator) Engine() any {
+	return nil
+}
+
+func validate(value reflect.Value) error {
+	for value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return nil
+		}
+		switch value.Interface().(type) {
+		case validation.Validatable, validation.ValidatableCtx:
+			return validation.ValidateNested(value.Interface())
+		}
+		value = value.Elem()
+	}
+	if !value.IsValid() {
+		return nil
+	}
+	if value.Kind() == reflect.Slice || value.Kind() == reflect.Array {
+		return validation.Each(func(elem reflect.Value) error {
+			return validate(elem)
+		})(elements(value))
+	}
+	return validation.ValidateNested(value.Interface())
+}
+
+func elements(value reflect.Value) []reflect.Value {
+	out := make([]reflect.Value, value.Len())
+	for i := range out {
+		out[i] = value.Index(i)
+	}
+	return out
+}
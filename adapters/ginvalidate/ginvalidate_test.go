@@ -0,0 +1,57 @@
+package ginvalidate_test
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/quantumcycle/protego/adapters/ginvalidate"
+	"github.com/quantumcycle/protego/validation"
+)
+
+// structValidator mirrors gin's binding.StructValidator interface.
+type structValidator interface {
+	ValidateStruct(any) error
+	Engine() any
+}
+
+var _ structValidator = ginvalidate.New()
+
+type createUser struct {
+	Name string `json:"name"`
+}
+
+func (c createUser) Validate() error {
+	return validation.NewFieldError("name", validation.Validate(c.Name, validation.Required[string]()))
+}
+
+func TestValidator(t *testing.T) {
+
+	t.Run("accepts valid input", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(ginvalidate.New().ValidateStruct(&createUser{Name: "John"})).To(Succeed())
+	})
+
+	t.Run("rejects invalid input with protego errors", func(t *testing.T) {
+		g := NewWithT(t)
+		err := ginvalidate.New().ValidateStruct(&createUser{})
+		g.Expect(err).To(MatchError("name: required"))
+		var fieldErr *validation.FieldError
+		g.Expect(errors.As(err, &fieldErr)).To(BeTrue())
+	})
+
+	t.Run("validates slice elements", func(t *testing.T) {
+		g := NewWithT(t)
+		err := ginvalidate.New().ValidateStruct(&[]createUser{{Name: "John"}, {}})
+		g.Expect(err).To(MatchError("index 1: name: required"))
+		g.Expect(validation.IsValidationError(err)).To(BeTrue())
+	})
+
+	t.Run("accepts values that are not validatable", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(ginvalidate.New().ValidateStruct(&map[string]any{})).To(Succeed())
+		var nilInput *createUser
+		g.Expect(ginvalidate.New().ValidateStruct(nilInput)).To(Succeed())
+	})
+}
@@ -0,0 +1,138 @@
+// Package coerce converts string inputs, such as query parameters, CSV cells or environment
+// variables, to typed values and validates them with typed validators.
+//
+// Conversion failures are validation errors, so they are reported like any other rule:
+//
+//	port, err := coerce.Int(os.Getenv("PORT"), validation.Range(1, 65535))
+//
+//	err := validation.ValidateStringMap(row, false,
+//	    validation.MapKey("quantity", true, coerce.ToInt(validation.Positive[int]())),
+//	    validation.MapKey("shipped_at", false, coerce.ToTime(time.DateOnly, validation.IsPastTime())),
+//	)
+package coerce
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/quantumcycle/protego/validation"
+)
+
+// Int converts s to an int and applies the validators to it.
+// Surrounding whitespace is ignored.
+//
+// Example:
+//
+//	workers, err := coerce.Int(os.Getenv("WORKERS"), validation.Range(1, 64))
+func Int(s string, validators ...validation.Validator[int]) (int, error) {
+	v, err := strconv.Atoi(strings.TrimSpace(s))
+	if err != nil {
+		return 0, validation.NewValidationError("must be a valid integer")
+	}
+	return v, validation.Validate(v, validators...)
+}
+
+// Bool converts s to a bool and applies the validators to it.
+// Accepted values are those understood by strconv.ParseBool (1, t, true, 0, f, false...).
+//
+// Example:
+//
+//	debug, err := coerce.Bool(os.Getenv("DEBUG"))
+func Bool(s string, validators ...validation.Validator[bool]) (bool, error) {
+	v, err := strconv.ParseBool(strings.TrimSpace(s))
+	if err != nil {
+		return false, validation.NewValidationError("must be a valid boolean")
+	}
+	return v, validation.Validate(v, validators...)
+}
+
+// LocalizedFloat converts s, written in the number format of a BCP 47 locale such as
+// "1.234,56" in "de-DE", to a float64 and applies the validators to it
+// (see validation.ParseLocalizedNumber). Surrounding whitespace is ignored.
+//
+// Example:
+//
+//	amount, err := coerce.LocalizedFloat(row["amount"], "de-DE", validation.Positive[float64]())
+func LocalizedFloat(s, locale string, validators ...validation.Validator[float64]) (float64, error) {
+	v, err := validation.ParseLocalizedNumber(strings.TrimSpace(s), locale)
+	if err != nil {
+		return 0, err
+	}
+	return v, validation.Validate(v, validators...)
+}
+
+// Time parses s with the given layout and applies the validators to the result.
+//
+// Example:
+//
+//	since, err := coerce.Time(r.URL.Query().Get("since"), time.RFC3339, validation.IsPastTime())
+func Time(s, layout string, validators ...validation.Validator[time.Time]) (time.Time, error) {
+	v, err := time.Parse(layout, strings.TrimSpace(s))
+	if err != nil {
+		return time.Time{}, validation.NewValidationError(fmt.Sprintf("must match date format %q", layout))
+	}
+	return v, validation.Validate(v, validators...)
+}
+
+// UUID parses s as a UUID, in any form accepted by uuid.Parse, and applies the validators
+// to the result.
+//
+// Example:
+//
+//	id, err := coerce.UUID(chi.URLParam(r, "id"))
+func UUID(s string, validators ...validation.Validator[uuid.UUID]) (uuid.UUID, error) {
+	v, err := uuid.Parse(strings.TrimSpace(s))
+	if err != nil {
+		return uuid.Nil, validation.NewValidationError("must be a valid UUID")
+	}
+	return v, validation.Validate(v, validators...)
+}
+
+// ToInt returns a string validator converting values with Int.
+//
+// Example:
+//
+//	validation.MapKey("quantity", true, coerce.ToInt(validation.Positive[int]()))
+func ToInt(validators ...validation.Validator[int]) validation.Validator[string] {
+	return discard(Int, validators)
+}
+
+// ToBool returns a string validator converting values with Bool.
+func ToBool(validators ...validation.Validator[bool]) validation.Validator[string] {
+	return discard(Bool, validators)
+}
+
+// ToTime returns a string validator converting values with Time.
+//
+// Example:
+//
+//	validation.Validate(row["shipped_at"], coerce.ToTime(time.DateOnly, validation.IsPastTime()))
+func ToTime(layout string, validators ...validation.Validator[time.Time]) validation.Validator[string] {
+	return discard(func(s string, validators ...validation.Validator[time.Time]) (time.Time, error) {
+		return Time(s, layout, validators...)
+	}, validators)
+}
+
+// ToLocalizedFloat returns a string validator converting values with LocalizedFloat.
+func ToLocalizedFloat(locale string, validators ...validation.Validator[float64]) validation.Validator[string] {
+	return discard(func(s string, validators ...validation.Validator[float64]) (float64, error) {
+		return LocalizedFloat(s, locale, validators...)
+	}, validators)
+}
+
+// ToUUID returns a string validator converting values with UUID.
+func ToUUID(validators ...validation.Validator[uuid.UUID]) validation.Validator[string] {
+	return discard(UUID, validators)
+}
+
+// discard turns a converter into a string validator, dropping the converted value.
+func discard[T any](convert func(string, ...validation.Validator[T]) (T, error), validators []validation.Validator[T]) validation.Validator[string] {
+	return func(s string) error {
+		_, err := convert(s, validators...)
+		return err
+	}
+}
@@ -0,0 +1,101 @@
+package coerce_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/gomega"
+
+	"github.com/quantumcycle/protego/coerce"
+	"github.com/quantumcycle/protego/validation"
+)
+
+func TestInt(t *testing.T) {
+	t.Run("converts and validates", func(t *testing.T) {
+		g := NewWithT(t)
+		v, err := coerce.Int(" 42 ", validation.Range(1, 100))
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(v).To(Equal(42))
+	})
+
+	t.Run("reports conversion errors as validation errors", func(t *testing.T) {
+		g := NewWithT(t)
+		_, err := coerce.Int("forty-two")
+		g.Expect(err).To(MatchError("must be a valid integer"))
+		g.Expect(validation.IsValidationError(err)).To(BeTrue())
+	})
+
+	t.Run("reports validator errors", func(t *testing.T) {
+		g := NewWithT(t)
+		_, err := coerce.Int("420", validation.Range(1, 100))
+		g.Expect(err).To(MatchError("must be between 1 and 100"))
+	})
+}
+
+func TestBool(t *testing.T) {
+	g := NewWithT(t)
+	v, err := coerce.Bool("true")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(v).To(BeTrue())
+	_, err = coerce.Bool("yes")
+	g.Expect(err).To(MatchError("must be a valid boolean"))
+}
+
+func TestLocalizedFloat(t *testing.T) {
+	t.Run("converts per locale", func(t *testing.T) {
+		g := NewWithT(t)
+		v, err := coerce.LocalizedFloat(" 1.234,56 ", "de-DE", validation.Positive[float64]())
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(v).To(Equal(1234.56))
+		v, err = coerce.LocalizedFloat("1,234.56", "en-US")
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(v).To(Equal(1234.56))
+	})
+
+	t.Run("reports conversion errors as validation errors", func(t *testing.T) {
+		g := NewWithT(t)
+		_, err := coerce.LocalizedFloat("1,234.56", "de-DE")
+		g.Expect(err).To(MatchError(`must be a valid number for locale "de-DE"`))
+		g.Expect(validation.IsValidationError(err)).To(BeTrue())
+	})
+}
+
+func TestTime(t *testing.T) {
+	g := NewWithT(t)
+	v, err := coerce.Time("2024-03-01", time.DateOnly)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(v).To(Equal(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)))
+	_, err = coerce.Time("01/03/2024", time.DateOnly)
+	g.Expect(err).To(MatchError(`must match date format "2006-01-02"`))
+}
+
+func TestUUID(t *testing.T) {
+	g := NewWithT(t)
+	id := uuid.New()
+	v, err := coerce.UUID(id.String())
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(v).To(Equal(id))
+	_, err = coerce.UUID("not-a-uuid")
+	g.Expect(err).To(MatchError("must be a valid UUID"))
+}
+
+func TestStringValidators(t *testing.T) {
+	row := map[string]string{"quantity": "0", "shipped_at": "2024-03-01", "id": "x", "gift": "maybe"}
+
+	t.Run("plug into map validation", func(t *testing.T) {
+		g := NewWithT(t)
+		err := validation.ValidateStringMap(row, true,
+			validation.MapKey("shipped_at", true, coerce.ToTime(time.DateOnly)),
+			validation.MapKey("quantity", true, coerce.ToInt(validation.Positive[int]())),
+		)
+		g.Expect(err).To(MatchError(`key "quantity": must be positive`))
+	})
+
+	t.Run("convert every type", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(validation.Validate(row["id"], coerce.ToUUID())).To(MatchError("must be a valid UUID"))
+		g.Expect(validation.Validate(row["gift"], coerce.ToBool())).To(MatchError("must be a valid boolean"))
+		g.Expect(validation.Validate(row["quantity"], coerce.ToInt())).To(Succeed())
+	})
+}
@@ -0,0 +1,87 @@
+// Package domains validates domain names entered by customers, such as the custom domain of
+// a site, against the Public Suffix List. It depends on golang.org/x/net, which the core
+// validation package does not.
+//
+//	err := validation.Validate(input.CustomDomain,
+//	    domains.IsRegistrableDomain(),
+//	    domains.DomainNotIn([]string{"example.com", "ourapp.io"}),
+//	)
+package domains
+
+import (
+	"net"
+	"strings"
+
+	"golang.org/x/net/publicsuffix"
+
+	"github.com/quantumcycle/protego/validation"
+)
+
+// IsRegistrableDomain validates that a string is a domain name that can be registered, or a
+// subdomain of one, such as "example.com" or "shop.example.co.uk", according to the Public
+// Suffix List. Public suffixes themselves, such as "com", "co.uk" or "github.io", are
+// rejected, as are IP addresses, single-label names such as "localhost" and trailing dots.
+// Internationalized domain names must be in their ASCII form ("xn--").
+//
+// Example:
+//
+//	validation.Validate(input.CustomDomain, domains.IsRegistrableDomain())
+func IsRegistrableDomain() validation.Validator[string] {
+	return func(v string) error {
+		if net.ParseIP(v) != nil {
+			return validation.NewValidationError("must be a domain name, not an IP address")
+		}
+		if !isDomainName(v) {
+			return validation.NewValidationError("must be a valid domain name")
+		}
+		if _, err := publicsuffix.EffectiveTLDPlusOne(strings.ToLower(v)); err != nil {
+			return validation.NewValidationError("must be a registrable domain, not a public suffix")
+		}
+		return nil
+	}
+}
+
+// isDomainName reports whether v is a syntactically valid ASCII domain name.
+func isDomainName(v string) bool {
+	if v == "" || len(v) > 253 {
+		return false
+	}
+	for _, label := range strings.Split(v, ".") {
+		if label == "" || len(label) > 63 || label[0] == '-' || label[len(label)-1] == '-' {
+			return false
+		}
+		for i := 0; i < len(label); i++ {
+			if c := label[i]; !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-') {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// DomainNotIn validates that a domain name is neither one of blocklist nor a subdomain of
+// one, ignoring case and trailing dots: "example.com" blocks "mail.example.com" but not
+// "myexample.com". The error does not repeat the blocked domain.
+//
+// Example:
+//
+//	validation.Validate(input.CustomDomain, domains.DomainNotIn([]string{"example.com", "ourapp.io"}))
+func DomainNotIn(blocklist []string) validation.Validator[string] {
+	blocked := make(map[string]struct{}, len(blocklist))
+	for _, domain := range blocklist {
+		blocked[normalizeDomain(domain)] = struct{}{}
+	}
+	return func(v string) error {
+		for domain := normalizeDomain(v); domain != ""; {
+			if _, ok := blocked[domain]; ok {
+				return validation.NewValidationError("must not be a blocked domain")
+			}
+			_, domain, _ = strings.Cut(domain, ".")
+		}
+		return nil
+	}
+}
+
+func normalizeDomain(domain string) string {
+	return strings.ToLower(strings.TrimSuffix(domain, "."))
+}
@@ -0,0 +1,58 @@
+package domains_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/quantumcycle/protego/domains"
+)
+
+func TestIsRegistrableDomain(t *testing.T) {
+	validator := domains.IsRegistrableDomain()
+
+	t.Run("accepts registrable domains and their subdomains", func(t *testing.T) {
+		g := NewWithT(t)
+		for _, domain := range []string{"example.com", "shop.example.co.uk", "Example.COM", "jane.github.io", "xn--bcher-kva.example"} {
+			g.Expect(validator(domain)).To(Succeed(), domain)
+		}
+	})
+
+	t.Run("rejects public suffixes", func(t *testing.T) {
+		g := NewWithT(t)
+		for _, domain := range []string{"com", "co.uk", "github.io", "localhost"} {
+			g.Expect(validator(domain)).To(MatchError("must be a registrable domain, not a public suffix"), domain)
+		}
+	})
+
+	t.Run("rejects IP addresses", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(validator("192.168.1.1")).To(MatchError("must be a domain name, not an IP address"))
+		g.Expect(validator("::1")).To(MatchError("must be a domain name, not an IP address"))
+	})
+
+	t.Run("rejects malformed names", func(t *testing.T) {
+		g := NewWithT(t)
+		for _, domain := range []string{"", "example.com.", "-example.com", "exa mple.com", "bücher.example", "a..example.com"} {
+			g.Expect(validator(domain)).To(MatchError("must be a valid domain name"), domain)
+		}
+	})
+}
+
+func TestDomainNotIn(t *testing.T) {
+	validator := domains.DomainNotIn([]string{"example.com", "OurApp.io."})
+
+	t.Run("rejects blocked domains and their subdomains", func(t *testing.T) {
+		g := NewWithT(t)
+		for _, domain := range []string{"example.com", "mail.example.com", "EXAMPLE.com.", "ourapp.io"} {
+			g.Expect(validator(domain)).To(MatchError("must not be a blocked domain"), domain)
+		}
+	})
+
+	t.Run("accepts other domains", func(t *testing.T) {
+		g := NewWithT(t)
+		for _, domain := range []string{"myexample.com", "example.org", "com"} {
+			g.Expect(validator(domain)).To(Succeed(), domain)
+		}
+	})
+}
@@ -0,0 +1,16 @@
+module github.com/quantumcycle/protego/domains
+
+go 1.24.0
+
+require (
+	github.com/onsi/gomega v1.38.2
+	github.com/quantumcycle/protego/validation v0.9.0
+	golang.org/x/net v0.43.0
+)
+
+require (
+	github.com/google/go-cmp v0.7.0 // indirect
+	go.yaml.in/yaml/v3 v3.0.4 // indirect
+	golang.org/x/exp v0.0.0-20251023183803-a4bb9ffd2546 // indirect
+	golang.org/x/text v0.28.0 // indirect
+)
@@ -0,0 +1,271 @@
+// Package filters validates filter query languages exposed by APIs, such as RSQL/FIQL.
+//
+//	err := validation.Validate(r.URL.Query().Get("filter"),
+//	    filters.IsRSQL([]string{"name", "age", "status"}, nil),
+//	)
+package filters
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/quantumcycle/protego/validation"
+)
+
+// RSQL comparison operators. The FIQL forms <, <=, > and >= are accepted as aliases of
+// =lt=, =le=, =gt= and =ge=.
+const (
+	OpEqual        = "=="
+	OpNotEqual     = "!="
+	OpLess         = "=lt="
+	OpLessEqual    = "=le="
+	OpGreater      = "=gt="
+	OpGreaterEqual = "=ge="
+	OpIn           = "=in="
+	OpOut          = "=out="
+)
+
+// DefaultOps are the operators allowed by IsRSQL when none are given.
+var DefaultOps = []string{OpEqual, OpNotEqual, OpLess, OpLessEqual, OpGreater, OpGreaterEqual, OpIn, OpOut}
+
+// maxRSQLNesting bounds the nesting of parentheses, which the parser follows recursively.
+const maxRSQLNesting = 32
+
+var fiqlAliases = map[string]string{"<": OpLess, "<=": OpLessEqual, ">": OpGreater, ">=": OpGreaterEqual}
+
+// PositionError is a filter error located at a byte offset of the expression.
+// Use errors.As to retrieve it from the errors of IsRSQL.
+type PositionError struct {
+	Pos int
+	Msg string
+}
+
+// Error returns the message prefixed with the position.
+func (e *PositionError) Error() string {
+	return fmt.Sprintf("at position %d: %s", e.Pos, e.Msg)
+}
+
+// IsRSQL validates that a string is an RSQL/FIQL filter, such as
+// `name=="Kill Bill";(year=gt=2003,genre=in=(action,thriller))`, only using the allowed
+// fields and operators. A nil allowedOps means DefaultOps; custom operators such as
+// "=like=" may be listed. Argument lists are only accepted for =in= and =out=, and
+// parentheses may be nested at most 32 levels deep.
+// Errors are validation errors wrapping a *PositionError.
+//
+// Example:
+//
+//	validation.Validate(filter, filters.IsRSQL(
+//	    []string{"name", "year", "genre"},
+//	    []string{filters.OpEqual, filters.OpGreater, filters.OpIn},
+//	))
+func IsRSQL(allowedFields, allowedOps []string) validation.Validator[string] {
+	if allowedOps == nil {
+		allowedOps = DefaultOps
+	}
+	return func(v string) error {
+		p := &rsqlParser{input: v, fields: allowedFields, ops: allowedOps}
+		if err := p.parse(); err != nil {
+			return validation.WrapError(err)
+		}
+		return nil
+	}
+}
+
+// rsqlParser is a recursive descent parser of the RSQL grammar:
+//
+//	or         = and { "," and }
+//	and        = constraint { ";" constraint }
+//	constraint = "(" or ")" | comparison
+//	comparison = selector operator ( value | "(" value { "," value } ")" )
+type rsqlParser struct {
+	input  string
+	pos    int
+	fields []string
+	ops    []string
+	depth  int // of nested parentheses
+}
+
+func (p *rsqlParser) parse() error {
+	if strings.TrimSpace(p.input) == "" {
+		return p.errorf("expected a filter")
+	}
+	if err := p.or(); err != nil {
+		return err
+	}
+	if p.skipSpaces(); p.pos < len(p.input) {
+		return p.errorf("unexpected %q", p.input[p.pos])
+	}
+	return nil
+}
+
+func (p *rsqlParser) or() error {
+	for {
+		if err := p.and(); err != nil {
+			return err
+		}
+		if !p.accept(',') {
+			return nil
+		}
+	}
+}
+
+func (p *rsqlParser) and() error {
+	for {
+		if err := p.constraint(); err != nil {
+			return err
+		}
+		if !p.accept(';') {
+			return nil
+		}
+	}
+}
+
+func (p *rsqlParser) constraint() error {
+	if p.accept('(') {
+		if p.depth == maxRSQLNesting {
+			return &PositionError{Pos: p.pos - 1, Msg: fmt.Sprintf("parentheses must not be nested deeper than %d levels", maxRSQLNesting)}
+		}
+		p.depth++
+		defer func() { p.depth-- }()
+		if err := p.or(); err != nil {
+			return err
+		}
+		if !p.accept(')') {
+			return p.errorf("expected %q", ')')
+		}
+		return nil
+	}
+	return p.comparison()
+}
+
+func (p *rsqlParser) comparison() error {
+	p.skipSpaces()
+	start := p.pos
+	field := p.unreserved()
+	if field == "" {
+		return p.errorf("expected a field name")
+	}
+	if !slices.Contains(p.fields, field) {
+		return &PositionError{Pos: start, Msg: fmt.Sprintf("field %q is not allowed", field)}
+	}
+
+	opStart := p.pos
+	op, err := p.operator()
+	if err != nil {
+		return err
+	}
+	if !slices.Contains(p.ops, op) {
+		return &PositionError{Pos: opStart, Msg: fmt.Sprintf("operator %q is not allowed", op)}
+	}
+
+	argsStart := p.pos
+	count, err := p.arguments()
+	if err != nil {
+		return err
+	}
+	if count > 1 && op != OpIn && op != OpOut {
+		return &PositionError{Pos: argsStart, Msg: fmt.Sprintf("operator %q takes a single value", op)}
+	}
+	return nil
+}
+
+// operator reads ==, !=, =name= or a FIQL alias and returns its canonical form.
+func (p *rsqlParser) operator() (string, error) {
+	rest := p.input[p.pos:]
+	for _, alias := range []string{"<=", ">=", "<", ">"} {
+		if strings.HasPrefix(rest, alias) {
+			p.pos += len(alias)
+			return fiqlAliases[alias], nil
+		}
+	}
+	switch {
+	case strings.HasPrefix(rest, "=="), strings.HasPrefix(rest, "!="):
+		p.pos += 2
+		return rest[:2], nil
+	case strings.HasPrefix(rest, "="):
+		end := 1
+		for end < len(rest) && (rest[end] >= 'a' && rest[end] <= 'z' || rest[end] >= 'A' && rest[end] <= 'Z') {
+			end++
+		}
+		if end > 1 && end < len(rest) && rest[end] == '=' {
+			p.pos += end + 1
+			return rest[:end+1], nil
+		}
+	}
+	return "", p.errorf("expected a comparison operator")
+}
+
+// arguments reads a single value or a parenthesized list and returns the number of values.
+func (p *rsqlParser) arguments() (int, error) {
+	if !p.accept('(') {
+		return 1, p.value()
+	}
+	count := 0
+	for {
+		if err := p.value(); err != nil {
+			return 0, err
+		}
+		count++
+		if p.accept(')') {
+			return count, nil
+		}
+		if !p.accept(',') {
+			return 0, p.errorf("expected %q or %q", ',', ')')
+		}
+	}
+}
+
+// value reads an unreserved string or a single- or double-quoted string with backslash escapes.
+func (p *rsqlParser) value() error {
+	p.skipSpaces()
+	if p.pos < len(p.input) && (p.input[p.pos] == '"' || p.input[p.pos] == '\'') {
+		start, quote := p.pos, p.input[p.pos]
+		for p.pos++; p.pos < len(p.input); p.pos++ {
+			switch p.input[p.pos] {
+			case '\\':
+				p.pos++
+			case quote:
+				p.pos++
+				return nil
+			}
+		}
+		return &PositionError{Pos: start, Msg: "unterminated quoted value"}
+	}
+	if p.unreserved() == "" {
+		return p.errorf("expected a value")
+	}
+	return nil
+}
+
+// unreserved reads a run of characters that are not reserved by the grammar.
+func (p *rsqlParser) unreserved() string {
+	start := p.pos
+	for p.pos < len(p.input) && !strings.ContainsRune("\"'();,=!~<> ", rune(p.input[p.pos])) {
+		p.pos++
+	}
+	return p.input[start:p.pos]
+}
+
+// accept consumes c, after optional spaces, and reports whether it was found.
+func (p *rsqlParser) accept(c byte) bool {
+	p.skipSpaces()
+	if p.pos < len(p.input) && p.input[p.pos] == c {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *rsqlParser) skipSpaces() {
+	for p.pos < len(p.input) && p.input[p.pos] == ' ' {
+		p.pos++
+	}
+}
+
+func (p *rsqlParser) errorf(format string, args ...any) error {
+	if p.pos >= len(p.input) {
+		return &PositionError{Pos: p.pos, Msg: fmt.Sprintf(format, args...) + " at end of filter"}
+	}
+	return &PositionError{Pos: p.pos, Msg: fmt.Sprintf(format, args...)}
+}
@@ -0,0 +1,88 @@
+package filters_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/quantumcycle/protego/filters"
+	"github.com/quantumcycle/protego/validation"
+)
+
+var fields = []string{"name", "year", "genre", "director.lastName"}
+
+func TestIsRSQL(t *testing.T) {
+
+	t.Run("passes with valid filters", func(t *testing.T) {
+		g := NewWithT(t)
+		for _, filter := range []string{
+			`name=="Kill Bill";year=gt=2003`,
+			`genre=in=(sci-fi,action);(director.lastName==Nolan,year=ge=2000)`,
+			`year>=2000;year<2010`,
+			`name=='Kill \'Bill\''`,
+			`name==Kill* , year!=2003`,
+			`genre=out=( drama , 'romance' )`,
+		} {
+			g.Expect(validation.Validate(filter, filters.IsRSQL(fields, nil))).To(Succeed(), filter)
+		}
+	})
+
+	t.Run("reports syntax errors with their position", func(t *testing.T) {
+		g := NewWithT(t)
+		for filter, msg := range map[string]string{
+			``:                      "at position 0: expected a filter at end of filter",
+			`name`:                  "at position 4: expected a comparison operator at end of filter",
+			`name=~Kill`:            "at position 4: expected a comparison operator",
+			`name==`:                "at position 6: expected a value at end of filter",
+			`name=="Kill`:           "at position 6: unterminated quoted value",
+			`(name==Kill`:           `at position 11: expected ')' at end of filter`,
+			`name==Kill;`:           "at position 11: expected a field name at end of filter",
+			`genre=in=(a,b`:         `at position 13: expected ',' or ')' at end of filter`,
+			`name==Kill)`:           `at position 10: unexpected ')'`,
+			`year=gt=2003 year==1`:  `at position 13: unexpected 'y'`,
+			`genre=in=(action;war)`: `at position 16: expected ',' or ')'`,
+		} {
+			err := validation.Validate(filter, filters.IsRSQL(fields, nil))
+			g.Expect(err).To(MatchError(msg), filter)
+			g.Expect(validation.IsValidationError(err)).To(BeTrue())
+		}
+	})
+
+	t.Run("limits the nesting of parentheses", func(t *testing.T) {
+		g := NewWithT(t)
+		nested := func(depth int) string {
+			return strings.Repeat("(", depth) + "year==2000" + strings.Repeat(")", depth)
+		}
+		g.Expect(validation.Validate(nested(32), filters.IsRSQL(fields, nil))).To(Succeed())
+		err := validation.Validate(nested(33), filters.IsRSQL(fields, nil))
+		g.Expect(err).To(MatchError("at position 32: parentheses must not be nested deeper than 32 levels"))
+		g.Expect(validation.IsValidationError(err)).To(BeTrue())
+		g.Expect(validation.Validate(strings.Repeat("(", 1_000_000), filters.IsRSQL(fields, nil))).To(HaveOccurred())
+	})
+
+	t.Run("rejects fields and operators that are not allowed", func(t *testing.T) {
+		g := NewWithT(t)
+		v := filters.IsRSQL(fields, []string{filters.OpEqual, filters.OpGreater, filters.OpIn})
+		g.Expect(validation.Validate("year=gt=2000;budget==1", v)).To(MatchError(`at position 13: field "budget" is not allowed`))
+		g.Expect(validation.Validate("year=lt=2000", v)).To(MatchError(`at position 4: operator "=lt=" is not allowed`))
+		g.Expect(validation.Validate("year<2000", v)).To(MatchError(`at position 4: operator "=lt=" is not allowed`))
+		g.Expect(validation.Validate("year=gt=(1,2)", v)).To(MatchError(`at position 8: operator "=gt=" takes a single value`))
+	})
+
+	t.Run("supports custom operators", func(t *testing.T) {
+		g := NewWithT(t)
+		v := filters.IsRSQL(fields, append([]string{"=like="}, filters.DefaultOps...))
+		g.Expect(validation.Validate("name=like=Kill", v)).To(Succeed())
+		g.Expect(validation.Validate("name=regex=K.*", v)).To(MatchError(`at position 4: operator "=regex=" is not allowed`))
+	})
+
+	t.Run("exposes the position", func(t *testing.T) {
+		g := NewWithT(t)
+		err := validation.Validate("name==Kill;foo==1", filters.IsRSQL(fields, nil))
+		var posErr *filters.PositionError
+		g.Expect(errors.As(err, &posErr)).To(BeTrue())
+		g.Expect(posErr.Pos).To(Equal(11))
+	})
+}
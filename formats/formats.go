@@ -0,0 +1,130 @@
+// Package formats implements the most used string formats of the playground package
+// natively, without depending on go-playground/validator. Validators have the same names
+// as in playground, so switching is a matter of changing the import:
+//
+//	import playground "github.com/quantumcycle/protego/formats"
+//
+//	func (input Input) Validate() error {
+//	    return errors.Join(
+//	        validation.Validate(input.Email, playground.IsEmail),
+//	        validation.Validate(input.Website, playground.IsURL),
+//	    )
+//	}
+//
+// It is a separate module rather than a build tag of playground, so that modules importing
+// it alone do not require go-playground/validator.
+//
+// Formats follow the relevant RFCs and may differ from go-playground in edge cases, which
+// the documentation of each validator mentions.
+package formats
+
+import (
+	"encoding/base64"
+	"net"
+	"net/mail"
+	"net/url"
+	"strings"
+
+	"github.com/quantumcycle/protego/validation"
+)
+
+// String Format Validators
+var (
+	// IsEmail accepts a bare RFC 5322 address, such as "ada@example.com", as parsed by
+	// net/mail. Display names ("Ada <ada@example.com>") are rejected.
+	IsEmail = format("must be a valid email", isEmail)
+	// IsURL accepts an absolute URL with a scheme and a host, such as "https://example.com",
+	// or an opaque one such as "mailto:ada@example.com".
+	IsURL = format("must be a valid URL", isURL)
+)
+
+// Network Validators
+var (
+	IsIP   = format("must be a valid IP address", func(v string) bool { return net.ParseIP(v) != nil })
+	IsIPv4 = format("must be a valid IPv4 address", func(v string) bool { return net.ParseIP(v) != nil && !strings.Contains(v, ":") })
+	IsIPv6 = format("must be a valid IPv6 address", func(v string) bool { return net.ParseIP(v) != nil && strings.Contains(v, ":") })
+	// IsHostname accepts RFC 1123 host names, whose labels may start with a digit, such as
+	// "3com.com"; go-playground's hostname follows the older RFC 952 that forbids it.
+	IsHostname = format("must be a valid hostname", isHostname)
+)
+
+// Identifier Validators
+var (
+	// IsUUID accepts UUIDs of any version in canonical form, in either case.
+	IsUUID = validation.IsUUIDString()
+)
+
+// Encoding Validators
+var (
+	IsBase64    = format("must be valid base64", isEncoded(base64.StdEncoding))
+	IsBase64URL = format("must be valid base64url", isEncoded(base64.URLEncoding))
+	// IsHexColor accepts #RGB, #RGBA, #RRGGBB and #RRGGBBAA colors, in either case.
+	IsHexColor = format("must be a valid hex color", isHexColor)
+)
+
+// format returns a validator failing with msg when valid reports false.
+func format(msg string, valid func(string) bool) validation.Validator[string] {
+	return func(v string) error {
+		if !valid(v) {
+			return validation.NewValidationError(msg)
+		}
+		return nil
+	}
+}
+
+func isEmail(v string) bool {
+	addr, err := mail.ParseAddress(v)
+	return err == nil && addr.Address == v && strings.Contains(v[strings.LastIndexByte(v, '@'):], ".")
+}
+
+func isURL(v string) bool {
+	u, err := url.Parse(v)
+	return err == nil && u.Scheme != "" && (u.Host != "" || u.Opaque != "")
+}
+
+func isHostname(v string) bool {
+	v = strings.TrimSuffix(v, ".") // fully qualified
+	if v == "" || len(v) > 253 {
+		return false
+	}
+	for _, label := range strings.Split(v, ".") {
+		if label == "" || len(label) > 63 || label[0] == '-' || label[len(label)-1] == '-' {
+			return false
+		}
+		for i := 0; i < len(label); i++ {
+			if c := label[i]; !(isAlnum(c) || c == '-') {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func isEncoded(enc *base64.Encoding) func(string) bool {
+	return func(v string) bool {
+		_, err := enc.DecodeString(v)
+		return v != "" && err == nil
+	}
+}
+
+func isHexColor(v string) bool {
+	if !strings.HasPrefix(v, "#") {
+		return false
+	}
+	switch len(v) {
+	case 4, 5, 7, 9:
+	default:
+		return false
+	}
+	for i := 1; i < len(v); i++ {
+		c := v[i] | 0x20 // lower case letters
+		if !('0' <= v[i] && v[i] <= '9' || 'a' <= c && c <= 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+func isAlnum(c byte) bool {
+	return '0' <= c && c <= '9' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+}
@@ -0,0 +1,72 @@
+package formats_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/quantumcycle/protego/formats"
+	"github.com/quantumcycle/protego/validation"
+)
+
+func TestFormats(t *testing.T) {
+	cases := []struct {
+		name      string
+		validator validation.Validator[string]
+		valid     []string
+		invalid   []string
+		message   string
+	}{
+		{"IsEmail", formats.IsEmail,
+			[]string{"ada@example.com", "first.last+tag@sub.example.org"},
+			[]string{"", "ada", "ada@", "@example.com", "Ada <ada@example.com>", "ada@localhost", "a b@example.com"},
+			"must be a valid email"},
+		{"IsURL", formats.IsURL,
+			[]string{"https://example.com", "http://localhost:8080/path?q=1", "mailto:ada@example.com"},
+			[]string{"", "example.com", "/relative/path", "https://", "http//example.com"},
+			"must be a valid URL"},
+		{"IsIP", formats.IsIP,
+			[]string{"192.168.1.1", "::1", "2001:db8::1"},
+			[]string{"", "999.1.1.1", "1.2.3", "fe80::1%eth0"},
+			"must be a valid IP address"},
+		{"IsIPv4", formats.IsIPv4,
+			[]string{"10.0.0.1"},
+			[]string{"::1", "::ffff:10.0.0.1", "10.0.0"},
+			"must be a valid IPv4 address"},
+		{"IsIPv6", formats.IsIPv6,
+			[]string{"::1", "::ffff:10.0.0.1"},
+			[]string{"10.0.0.1", "2001:db8:::1"},
+			"must be a valid IPv6 address"},
+		{"IsHostname", formats.IsHostname,
+			[]string{"example.com", "my-host", "3com.com", "example.com."},
+			[]string{"", "-host", "host-", "a..b", "under_score.com", "ex ample.com"},
+			"must be a valid hostname"},
+		{"IsUUID", formats.IsUUID,
+			[]string{"f47ac10b-58cc-4372-a567-0e02b2c3d479", "F47AC10B-58CC-4372-A567-0E02B2C3D479"},
+			[]string{"", "f47ac10b58cc4372a5670e02b2c3d479", "not-a-uuid"},
+			"must be a valid UUID"},
+		{"IsBase64", formats.IsBase64,
+			[]string{"aGVsbG8=", "aGk="},
+			[]string{"", "aGVsbG8", "aGVs*G8=", "_-8="},
+			"must be valid base64"},
+		{"IsBase64URL", formats.IsBase64URL,
+			[]string{"_-8=", "aGk="},
+			[]string{"", "+/8="},
+			"must be valid base64url"},
+		{"IsHexColor", formats.IsHexColor,
+			[]string{"#fff", "#FFFA", "#a1b2c3", "#A1B2C3D4"},
+			[]string{"", "fff", "#ff", "#fffff", "#ggg", "#a1b2c3d"},
+			"must be a valid hex color"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			g := NewWithT(t)
+			for _, v := range c.valid {
+				g.Expect(validation.Validate(v, c.validator)).To(Succeed(), v)
+			}
+			for _, v := range c.invalid {
+				g.Expect(validation.Validate(v, c.validator)).To(MatchError(c.message), v)
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package gqlgen
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"github.com/quantumcycle/protego/validation"
+)
+
+// CodeValidationFailed is the "code" extension of validation errors.
+const CodeValidationFailed = "VALIDATION_FAILED"
+
+// Error is a validation failure exposing GraphQL error extensions.
+type Error struct {
+	err error
+}
+
+// Error returns the validation error message.
+func (e *Error) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap returns the underlying validation error.
+func (e *Error) Unwrap() error {
+	return e.err
+}
+
+// Extensions returns the GraphQL error extensions: the error code and, when the failure
+// is attributed to a field or argument, its name.
+func (e *Error) Extensions() map[string]any {
+	ext := map[string]any{"code": CodeValidationFailed}
+	var fieldErr *validation.FieldError
+	if errors.As(e.err, &fieldErr) {
+		ext["field"] = fieldErr.Field()
+	}
+	return ext
+}
+
+// wrap converts validation errors to *Error and leaves other errors unchanged,
+// so infrastructure failures keep surfacing as internal errors.
+func wrap(err error) error {
+	if err == nil || validation.IsSystemError(err) {
+		return err
+	}
+	return &Error{err: err}
+}
+
+// ValidateArgs validates resolver arguments implementing validation.Validatable or
+// validation.ValidatableCtx, attributing failures to the argument name. Use it from a field middleware so every resolver receives
+// validated inputs:
+//
+//	srv.AroundFields(func(ctx context.Context, next graphql.Resolver) (any, error) {
+//	    if fc := graphql.GetFieldContext(ctx); fc != nil {
+//	        if err := gqlgen.ValidateArgs(ctx, fc.Args); err != nil {
+//	            return nil, err
+//	        }
+//	    }
+//	    return next(ctx)
+//	})
+func ValidateArgs(ctx context.Context, args map[string]any) error {
+	names := make([]string, 0, len(args))
+	for name := range args {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	errs := make([]error, 0, len(names))
+	for _, name := range names {
+		errs = append(errs, validation.NewFieldError(name, validation.ValidateNestedCtx(ctx, args[name])))
+	}
+	return wrap(errors.Join(errs...))
+}
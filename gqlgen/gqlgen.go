@@ -0,0 +1,191 @@
+// Package gqlgen integrates protego validation with gqlgen GraphQL servers.
+//
+// It implements a @constraint directive for input fields and arguments, and validates
+// resolver arguments implementing validation.Validatable. Failures are returned as *Error,
+// whose extensions gqlgen's default error presenter includes in the response:
+//
+//	{"message": "must be at least 3 characters", "path": ["createUser", "input", "name"],
+//	 "extensions": {"code": "VALIDATION_FAILED"}}
+//
+// The package does not import gqlgen. Resolvers are taken as plain
+// func(context.Context) (any, error) values, which graphql.Resolver is assignable to:
+//
+//	constraints := gqlgen.New()
+//	cfg := generated.Config{Resolvers: &resolver{}}
+//	cfg.Directives.Constraint = func(ctx context.Context, obj any, next graphql.Resolver,
+//	    minLength, maxLength *int, min, max *float64, pattern, format *string) (any, error) {
+//	    return constraints.Directive(ctx, next, gqlgen.Args{
+//	        MinLength: minLength, MaxLength: maxLength, Min: min, Max: max, Pattern: pattern, Format: format,
+//	    })
+//	}
+package gqlgen
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/quantumcycle/protego/validation"
+)
+
+// DirectiveSDL declares the @constraint directive. Add it to your schema files so gqlgen
+// generates the matching DirectiveRoot field.
+const DirectiveSDL = `directive @constraint(
+  minLength: Int
+  maxLength: Int
+  min: Float
+  max: Float
+  pattern: String
+  format: String
+) on INPUT_FIELD_DEFINITION | ARGUMENT_DEFINITION`
+
+// Args holds the arguments of a @constraint directive. Nil fields are not checked.
+type Args struct {
+	MinLength *int
+	MaxLength *int
+	Min       *float64
+	Max       *float64
+	Pattern   *string
+	Format    *string
+}
+
+// Constraints implements the @constraint directive.
+// It is safe for concurrent use.
+type Constraints struct {
+	mu       sync.RWMutex
+	formats  map[string]validation.Validator[string]
+	patterns map[string]validation.Validator[string]
+}
+
+// New creates a Constraints with no registered formats.
+func New() *Constraints {
+	return &Constraints{
+		formats:  make(map[string]validation.Validator[string]),
+		patterns: make(map[string]validation.Validator[string]),
+	}
+}
+
+// RegisterFormat makes a string validator available as @constraint(format: name).
+//
+// Example:
+//
+//	constraints.RegisterFormat("email", playground.IsEmail)
+//	constraints.RegisterFormat("slug", validation.MatchesPattern(`^[a-z0-9-]+$`))
+func (c *Constraints) RegisterFormat(name string, validator validation.Validator[string]) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.formats[name] = validator
+}
+
+// Directive resolves the input value with next and checks it against the directive arguments.
+// Strings are checked against minLength, maxLength, pattern and format, numbers against
+// min and max. Null values are accepted; make the field non-null in the schema to require it.
+func (c *Constraints) Directive(ctx context.Context, next func(context.Context) (any, error), args Args) (any, error) {
+	value, err := next(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.check(value, args); err != nil {
+		return nil, wrap(err)
+	}
+	return value, nil
+}
+
+func (c *Constraints) check(value any, args Args) error {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case *string:
+		if v == nil {
+			return nil
+		}
+		return c.check(*v, args)
+	case string:
+		validators, err := c.stringValidators(args)
+		if err != nil {
+			return err
+		}
+		return validation.Validate(v, validators...)
+	default:
+		n, ok := toFloat(value)
+		if !ok {
+			return nil
+		}
+		return validation.Validate(n, numberValidators(args)...)
+	}
+}
+
+func (c *Constraints) stringValidators(args Args) ([]validation.Validator[string], error) {
+	var validators []validation.Validator[string]
+	if args.MinLength != nil {
+		validators = append(validators, validation.MinLength(*args.MinLength))
+	}
+	if args.MaxLength != nil {
+		validators = append(validators, validation.MaxLength(*args.MaxLength))
+	}
+	if args.Pattern != nil {
+		validators = append(validators, c.pattern(*args.Pattern))
+	}
+	if args.Format != nil {
+		c.mu.RLock()
+		format, ok := c.formats[*args.Format]
+		c.mu.RUnlock()
+		if !ok {
+			return nil, fmt.Errorf("constraint format %q is not registered", *args.Format)
+		}
+		validators = append(validators, format)
+	}
+	return validators, nil
+}
+
+// pattern returns a cached MatchesPattern validator, so each pattern is compiled once.
+func (c *Constraints) pattern(pattern string) validation.Validator[string] {
+	c.mu.RLock()
+	validator, ok := c.patterns[pattern]
+	c.mu.RUnlock()
+	if ok {
+		return validator
+	}
+	validator = validation.MatchesPattern(pattern)
+	c.mu.Lock()
+	c.patterns[pattern] = validator
+	c.mu.Unlock()
+	return validator
+}
+
+func numberValidators(args Args) []validation.Validator[float64] {
+	var validators []validation.Validator[float64]
+	if args.Min != nil {
+		validators = append(validators, validation.Min(*args.Min))
+	}
+	if args.Max != nil {
+		validators = append(validators, validation.Max(*args.Max))
+	}
+	return validators
+}
+
+func toFloat(value any) (float64, bool) {
+	switch v := value.(type) {
+	case int:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case float64:
+		return v, true
+	case *int:
+		if v != nil {
+			return float64(*v), true
+		}
+	case *int64:
+		if v != nil {
+			return float64(*v), true
+		}
+	case *float64:
+		if v != nil {
+			return *v, true
+		}
+	}
+	return 0, false
+}
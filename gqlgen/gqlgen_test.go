@@ -0,0 +1,121 @@
+package gqlgen_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/quantumcycle/protego/gqlgen"
+	"github.com/quantumcycle/protego/validation"
+)
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func resolved(value any) func(context.Context) (any, error) {
+	return func(context.Context) (any, error) {
+		return value, nil
+	}
+}
+
+func TestDirective(t *testing.T) {
+	ctx := context.Background()
+	constraints := gqlgen.New()
+	constraints.RegisterFormat("slug", validation.MatchesPattern(`^[a-z0-9-]+$`))
+
+	t.Run("passes valid strings through", func(t *testing.T) {
+		g := NewWithT(t)
+		value, err := constraints.Directive(ctx, resolved("john"), gqlgen.Args{MinLength: ptr(3), MaxLength: ptr(10)})
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(value).To(Equal("john"))
+	})
+
+	t.Run("checks string lengths", func(t *testing.T) {
+		g := NewWithT(t)
+		_, err := constraints.Directive(ctx, resolved("jo"), gqlgen.Args{MinLength: ptr(3)})
+		g.Expect(err).To(MatchError("must be at least 3 characters"))
+		_, err = constraints.Directive(ctx, resolved(ptr("johnathan")), gqlgen.Args{MaxLength: ptr(4)})
+		g.Expect(err).To(HaveOccurred())
+	})
+
+	t.Run("checks patterns and formats", func(t *testing.T) {
+		g := NewWithT(t)
+		_, err := constraints.Directive(ctx, resolved("ABC"), gqlgen.Args{Pattern: ptr(`^[a-z]+$`)})
+		g.Expect(err).To(HaveOccurred())
+		_, err = constraints.Directive(ctx, resolved("my-post"), gqlgen.Args{Format: ptr("slug")})
+		g.Expect(err).ToNot(HaveOccurred())
+		_, err = constraints.Directive(ctx, resolved("My Post"), gqlgen.Args{Format: ptr("slug")})
+		g.Expect(err).To(HaveOccurred())
+	})
+
+	t.Run("checks numeric bounds", func(t *testing.T) {
+		g := NewWithT(t)
+		_, err := constraints.Directive(ctx, resolved(17), gqlgen.Args{Min: ptr(18.0)})
+		g.Expect(err).To(MatchError("must be at least 18"))
+		_, err = constraints.Directive(ctx, resolved(ptr(99.5)), gqlgen.Args{Max: ptr(50.0)})
+		g.Expect(err).To(HaveOccurred())
+		_, err = constraints.Directive(ctx, resolved(int64(20)), gqlgen.Args{Min: ptr(18.0), Max: ptr(50.0)})
+		g.Expect(err).ToNot(HaveOccurred())
+	})
+
+	t.Run("accepts null values", func(t *testing.T) {
+		g := NewWithT(t)
+		_, err := constraints.Directive(ctx, resolved((*string)(nil)), gqlgen.Args{MinLength: ptr(3)})
+		g.Expect(err).ToNot(HaveOccurred())
+		_, err = constraints.Directive(ctx, resolved(nil), gqlgen.Args{Min: ptr(1.0)})
+		g.Expect(err).ToNot(HaveOccurred())
+	})
+
+	t.Run("exposes validation extensions", func(t *testing.T) {
+		g := NewWithT(t)
+		_, err := constraints.Directive(ctx, resolved("jo"), gqlgen.Args{MinLength: ptr(3)})
+		var gqlErr *gqlgen.Error
+		g.Expect(errors.As(err, &gqlErr)).To(BeTrue())
+		g.Expect(gqlErr.Extensions()).To(Equal(map[string]any{"code": gqlgen.CodeValidationFailed}))
+		g.Expect(validation.IsValidationError(err)).To(BeTrue())
+	})
+
+	t.Run("reports unregistered formats as system errors", func(t *testing.T) {
+		g := NewWithT(t)
+		_, err := constraints.Directive(ctx, resolved("x"), gqlgen.Args{Format: ptr("unknown")})
+		g.Expect(err).To(MatchError(`constraint format "unknown" is not registered`))
+		g.Expect(validation.IsValidationError(err)).To(BeFalse())
+	})
+
+	t.Run("returns resolver errors unchanged", func(t *testing.T) {
+		g := NewWithT(t)
+		failure := errors.New("boom")
+		_, err := constraints.Directive(ctx, func(context.Context) (any, error) { return nil, failure }, gqlgen.Args{})
+		g.Expect(err).To(Equal(failure))
+	})
+}
+
+type createUserInput struct {
+	Name string `json:"name"`
+}
+
+func (in createUserInput) Validate() error {
+	return validation.ValidateStruct(
+		validation.Field(&in, &in.Name, validation.Required[string]()),
+	)
+}
+
+func TestValidateArgs(t *testing.T) {
+	t.Run("passes valid arguments", func(t *testing.T) {
+		g := NewWithT(t)
+		err := gqlgen.ValidateArgs(context.Background(), map[string]any{"input": createUserInput{Name: "John"}, "dryRun": true})
+		g.Expect(err).ToNot(HaveOccurred())
+	})
+
+	t.Run("attributes failures to the argument", func(t *testing.T) {
+		g := NewWithT(t)
+		err := gqlgen.ValidateArgs(context.Background(), map[string]any{"input": createUserInput{}})
+		g.Expect(err).To(MatchError("input: name: required"))
+		var gqlErr *gqlgen.Error
+		g.Expect(errors.As(err, &gqlErr)).To(BeTrue())
+		g.Expect(gqlErr.Extensions()).To(HaveKeyWithValue("field", "input"))
+	})
+}
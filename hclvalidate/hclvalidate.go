@@ -0,0 +1,244 @@
+// Package hclvalidate validates HCL configuration with protego validators.
+//
+// Rules describe the attributes and blocks a body may contain, the type of each attribute and
+// the constraints on its value. Failures are returned as protego errors attributed to the
+// attribute, e.g. "region: required" or "service.web: port: must be at most 65535".
+//
+// Usage:
+//
+//	err := hclvalidate.ValidateSource(src, "config.hcl",
+//	    hclvalidate.String("region", validation.In(false, "eu-west-1", "us-east-1")).Required(),
+//	    hclvalidate.Block("service",
+//	        hclvalidate.Int("port", validation.Range(1, 65535)).Required(),
+//	        hclvalidate.Strings("tags", validation.MaxItems[string](10)),
+//	    ).Labels("name"),
+//	)
+package hclvalidate
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+	"github.com/zclconf/go-cty/cty/gocty"
+
+	"github.com/quantumcycle/protego/validation"
+)
+
+// Rule validates an attribute or block of an HCL body.
+type Rule interface {
+	declare(schema *hcl.BodySchema)
+	validate(content *hcl.BodyContent) error
+}
+
+// ValidateSource parses src as native HCL syntax and validates it with ValidateHCL.
+// Syntax errors are reported as validation errors.
+func ValidateSource(src []byte, filename string, rules ...Rule) error {
+	file, diags := hclsyntax.ParseConfig(src, filename, hcl.InitialPos)
+	if diags.HasErrors() {
+		return diagnosticsError(diags)
+	}
+	return ValidateHCL(file.Body, rules...)
+}
+
+// ValidateHCL validates body against the rules. Attributes and blocks not declared by a rule
+// are reported as errors. Attribute values must be constants: expressions referring to
+// variables or functions are rejected.
+//
+// Example:
+//
+//	err := hclvalidate.ValidateHCL(file.Body,
+//	    hclvalidate.String("name", validation.MaxLength(63)).Required(),
+//	    hclvalidate.Bool("enabled"),
+//	)
+func ValidateHCL(body hcl.Body, rules ...Rule) error {
+	schema := &hcl.BodySchema{}
+	for _, rule := range rules {
+		rule.declare(schema)
+	}
+	content, diags := body.Content(schema)
+	errs := []error{diagnosticsError(diags)}
+	for _, rule := range rules {
+		errs = append(errs, rule.validate(content))
+	}
+	return errors.Join(errs...)
+}
+
+// Attribute is a typed attribute rule created by String, Int, Float, Bool, Strings or StringMap.
+// The attribute value is converted to T and then checked with the validators.
+type Attribute[T any] struct {
+	name       string
+	typ        cty.Type
+	typeName   string
+	required   bool
+	validators []validation.Validator[T]
+}
+
+// Required marks the attribute as mandatory.
+func (a Attribute[T]) Required() Attribute[T] {
+	a.required = true
+	return a
+}
+
+func (a Attribute[T]) declare(schema *hcl.BodySchema) {
+	schema.Attributes = append(schema.Attributes, hcl.AttributeSchema{Name: a.name})
+}
+
+func (a Attribute[T]) validate(content *hcl.BodyContent) error {
+	attr, ok := content.Attributes[a.name]
+	if !ok {
+		if a.required {
+			return validation.NewFieldError(a.name, validation.NewValidationError("required"))
+		}
+		return nil
+	}
+	v, err := a.decode(attr)
+	if err != nil {
+		return validation.NewFieldError(a.name, err)
+	}
+	return validation.NewFieldError(a.name, validation.Validate(v, a.validators...))
+}
+
+func (a Attribute[T]) decode(attr *hcl.Attribute) (T, error) {
+	var v T
+	value, diags := attr.Expr.Value(nil)
+	if diags.HasErrors() {
+		return v, validation.NewValidationError("must be a constant value")
+	}
+	converted, err := convert.Convert(value, a.typ)
+	if err != nil || !converted.IsKnown() {
+		return v, validation.NewValidationError("must be " + a.typeName)
+	}
+	if converted.IsNull() {
+		return v, nil
+	}
+	if err := gocty.FromCtyValue(converted, &v); err != nil {
+		return v, validation.NewValidationError("must be " + a.typeName)
+	}
+	return v, nil
+}
+
+// String creates a rule for a string attribute.
+//
+// Example:
+//
+//	hclvalidate.String("region", validation.In(false, "eu-west-1", "us-east-1")).Required()
+func String(name string, validators ...validation.Validator[string]) Attribute[string] {
+	return Attribute[string]{name: name, typ: cty.String, typeName: "a string", validators: validators}
+}
+
+// Int creates a rule for a whole number attribute.
+//
+// Example:
+//
+//	hclvalidate.Int("replicas", validation.Range(1, 10))
+func Int(name string, validators ...validation.Validator[int]) Attribute[int] {
+	return Attribute[int]{name: name, typ: cty.Number, typeName: "an integer", validators: validators}
+}
+
+// Float creates a rule for a number attribute.
+//
+// Example:
+//
+//	hclvalidate.Float("cpu", validation.Positive[float64]())
+func Float(name string, validators ...validation.Validator[float64]) Attribute[float64] {
+	return Attribute[float64]{name: name, typ: cty.Number, typeName: "a number", validators: validators}
+}
+
+// Bool creates a rule for a boolean attribute.
+//
+// Example:
+//
+//	hclvalidate.Bool("enabled")
+func Bool(name string, validators ...validation.Validator[bool]) Attribute[bool] {
+	return Attribute[bool]{name: name, typ: cty.Bool, typeName: "a boolean", validators: validators}
+}
+
+// Strings creates a rule for a list of strings attribute.
+//
+// Example:
+//
+//	hclvalidate.Strings("zones", validation.MinItems[string](1), validation.Each(validation.StartsWith("eu-")))
+func Strings(name string, validators ...validation.Validator[[]string]) Attribute[[]string] {
+	return Attribute[[]string]{name: name, typ: cty.List(cty.String), typeName: "a list of strings", validators: validators}
+}
+
+// StringMap creates a rule for a map of strings attribute, such as resource tags.
+//
+// Example:
+//
+//	hclvalidate.StringMap("tags", validation.Custom(func(tags map[string]string) error {
+//	    return validation.ValidateStringMap(tags, true, validation.MapKey("team", true, validation.Required[string]()))
+//	}))
+func StringMap(name string, validators ...validation.Validator[map[string]string]) Attribute[map[string]string] {
+	return Attribute[map[string]string]{name: name, typ: cty.Map(cty.String), typeName: "a map of strings", validators: validators}
+}
+
+// BlockRule validates the nested blocks of a type, created by Block.
+type BlockRule struct {
+	typeName string
+	labels   []string
+	required bool
+	rules    []Rule
+}
+
+// Block creates a rule for nested blocks of the given type, each validated against rules.
+// Errors are attributed to the block type followed by its labels, e.g. "service.web",
+// or by its index when it has no labels, e.g. "network[1]".
+//
+// Example:
+//
+//	hclvalidate.Block("service",
+//	    hclvalidate.Int("port", validation.Range(1, 65535)).Required(),
+//	).Labels("name").Required()
+func Block(typeName string, rules ...Rule) BlockRule {
+	return BlockRule{typeName: typeName, rules: rules}
+}
+
+// Labels declares the labels expected on each block, such as the name of a resource.
+func (b BlockRule) Labels(names ...string) BlockRule {
+	b.labels = names
+	return b
+}
+
+// Required requires at least one block of the type.
+func (b BlockRule) Required() BlockRule {
+	b.required = true
+	return b
+}
+
+func (b BlockRule) declare(schema *hcl.BodySchema) {
+	schema.Blocks = append(schema.Blocks, hcl.BlockHeaderSchema{Type: b.typeName, LabelNames: b.labels})
+}
+
+func (b BlockRule) validate(content *hcl.BodyContent) error {
+	blocks := content.Blocks.OfType(b.typeName)
+	if len(blocks) == 0 && b.required {
+		return validation.NewFieldError(b.typeName, validation.NewValidationError("required"))
+	}
+	errs := make([]error, 0, len(blocks))
+	for i, block := range blocks {
+		name := fmt.Sprintf("%s[%d]", b.typeName, i)
+		if len(block.Labels) > 0 {
+			name = b.typeName + "." + strings.Join(block.Labels, ".")
+		}
+		errs = append(errs, validation.NewFieldError(name, ValidateHCL(block.Body, b.rules...)))
+	}
+	return errors.Join(errs...)
+}
+
+// diagnosticsError converts HCL error diagnostics, such as syntax errors or unexpected
+// attributes, to validation errors.
+func diagnosticsError(diags hcl.Diagnostics) error {
+	var errs []error
+	for _, diag := range diags {
+		if diag.Severity == hcl.DiagError {
+			errs = append(errs, validation.NewValidationError(diag.Error()))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,142 @@
+package hclvalidate_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/quantumcycle/protego/hclvalidate"
+	"github.com/quantumcycle/protego/validation"
+)
+
+func TestValidateSource(t *testing.T) {
+	rules := []hclvalidate.Rule{
+		hclvalidate.String("region", validation.In(false, "eu-west-1", "us-east-1")).Required(),
+		hclvalidate.Int("replicas", validation.Range(1, 10)),
+		hclvalidate.Float("cpu", validation.Positive[float64]()),
+		hclvalidate.Bool("enabled"),
+		hclvalidate.Strings("zones", validation.MinItems[string](1)),
+		hclvalidate.StringMap("tags"),
+		hclvalidate.Block("service",
+			hclvalidate.Int("port", validation.Range(1, 65535)).Required(),
+		).Labels("name"),
+		hclvalidate.Block("network",
+			hclvalidate.String("cidr").Required(),
+		),
+	}
+	validate := func(src string) error {
+		return hclvalidate.ValidateSource([]byte(src), "config.hcl", rules...)
+	}
+
+	t.Run("accepts valid configuration", func(t *testing.T) {
+		g := NewWithT(t)
+		err := validate(`
+region   = "eu-west-1"
+replicas = 3
+cpu      = 0.5
+enabled  = true
+zones    = ["eu-west-1a", "eu-west-1b"]
+tags     = { team = "platform" }
+
+service "web" {
+  port = 8080
+}
+
+network {
+  cidr = "10.0.0.0/16"
+}
+`)
+		g.Expect(err).ToNot(HaveOccurred())
+	})
+
+	t.Run("checks required attributes", func(t *testing.T) {
+		g := NewWithT(t)
+		err := validate(`replicas = 3`)
+		g.Expect(err).To(MatchError("region: required"))
+		g.Expect(validation.IsValidationError(err)).To(BeTrue())
+	})
+
+	t.Run("checks attribute types", func(t *testing.T) {
+		g := NewWithT(t)
+		err := validate(`
+region   = "eu-west-1"
+replicas = 1.5
+enabled  = "maybe"
+zones    = "eu-west-1a"
+`)
+		g.Expect(err).To(HaveOccurred())
+		g.Expect(err.Error()).To(ContainSubstring("replicas: must be an integer"))
+		g.Expect(err.Error()).To(ContainSubstring("enabled: must be a boolean"))
+		g.Expect(err.Error()).To(ContainSubstring("zones: must be a list of strings"))
+	})
+
+	t.Run("converts compatible values", func(t *testing.T) {
+		g := NewWithT(t)
+		err := validate(`
+region   = "eu-west-1"
+replicas = "3"
+`)
+		g.Expect(err).ToNot(HaveOccurred())
+	})
+
+	t.Run("checks value constraints", func(t *testing.T) {
+		g := NewWithT(t)
+		err := validate(`
+region   = "mars-1"
+replicas = 20
+zones    = []
+`)
+		g.Expect(err).To(HaveOccurred())
+		g.Expect(err.Error()).To(ContainSubstring("region: must be one of"))
+		g.Expect(err.Error()).To(ContainSubstring("replicas: must be between 1 and 10"))
+		g.Expect(err.Error()).To(ContainSubstring("zones: must have at least 1 items"))
+	})
+
+	t.Run("rejects non-constant expressions", func(t *testing.T) {
+		g := NewWithT(t)
+		err := validate(`region = var.region`)
+		g.Expect(err).To(MatchError("region: must be a constant value"))
+	})
+
+	t.Run("validates nested blocks", func(t *testing.T) {
+		g := NewWithT(t)
+		err := validate(`
+region = "eu-west-1"
+
+service "web" {
+  port = 70000
+}
+
+network {
+}
+`)
+		g.Expect(err).To(HaveOccurred())
+		g.Expect(err.Error()).To(ContainSubstring("service.web: port: must be between 1 and 65535"))
+		g.Expect(err.Error()).To(ContainSubstring("network[0]: cidr: required"))
+	})
+
+	t.Run("rejects undeclared attributes", func(t *testing.T) {
+		g := NewWithT(t)
+		err := validate(`
+region  = "eu-west-1"
+unknown = 1
+`)
+		g.Expect(err).To(MatchError(ContainSubstring("Unsupported argument")))
+		g.Expect(validation.IsValidationError(err)).To(BeTrue())
+	})
+
+	t.Run("rejects invalid syntax", func(t *testing.T) {
+		g := NewWithT(t)
+		err := validate(`region = "eu-west-1`)
+		g.Expect(err).To(HaveOccurred())
+		g.Expect(validation.IsValidationError(err)).To(BeTrue())
+	})
+}
+
+func TestBlockRequired(t *testing.T) {
+	g := NewWithT(t)
+	err := hclvalidate.ValidateSource([]byte(``), "config.hcl",
+		hclvalidate.Block("backend", hclvalidate.String("bucket")).Required(),
+	)
+	g.Expect(err).To(MatchError("backend: required"))
+}
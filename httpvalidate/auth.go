@@ -0,0 +1,83 @@
+package httpvalidate
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/quantumcycle/protego/validation"
+)
+
+// The validators of this file cheaply reject malformed credentials before they reach an
+// authentication service. Their errors never include the credentials (see validation.Sensitive).
+
+// IsBearerToken validates an Authorization header using the Bearer scheme of RFC 6750,
+// such as "Bearer mF_9.B5f-4.1JqM". The scheme is case-insensitive.
+//
+// Example:
+//
+//	httpvalidate.Header("Authorization", httpvalidate.IsBearerToken()).Required()
+func IsBearerToken() validation.Validator[string] {
+	return validation.Sensitive(func(v string) error {
+		scheme, token, ok := strings.Cut(v, " ")
+		if !ok || !strings.EqualFold(scheme, "Bearer") || !isToken68(token) {
+			return validation.NewValidationError("must be a valid Bearer token")
+		}
+		return nil
+	})
+}
+
+// IsBasicAuthHeader validates an Authorization header using the Basic scheme of RFC 7617:
+// "Basic " followed by the base64 encoding of "user:password".
+//
+// Example:
+//
+//	httpvalidate.Header("Authorization", httpvalidate.IsBasicAuthHeader()).Required()
+func IsBasicAuthHeader() validation.Validator[string] {
+	return validation.Sensitive(func(v string) error {
+		scheme, encoded, ok := strings.Cut(v, " ")
+		if !ok || !strings.EqualFold(scheme, "Basic") {
+			return validation.NewValidationError("must be a valid Basic authorization")
+		}
+		decoded, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil || !strings.Contains(string(decoded), ":") {
+			return validation.NewValidationError("must be a valid Basic authorization")
+		}
+		return nil
+	})
+}
+
+// IsAPIKeyFormat validates an API key made of prefix followed by exactly length characters
+// from charset, such as "sk_live_" and 24 alphanumeric characters. An empty charset means
+// ASCII letters and digits.
+//
+// Example:
+//
+//	httpvalidate.Header("X-API-Key", httpvalidate.IsAPIKeyFormat("sk_live_", 24, "")).Required()
+func IsAPIKeyFormat(prefix string, length int, charset string) validation.Validator[string] {
+	if charset == "" {
+		charset = validation.ShortCodeAlphabet
+	}
+	return validation.Sensitive(func(v string) error {
+		key, ok := strings.CutPrefix(v, prefix)
+		if !ok || len(key) != length || strings.Trim(key, charset) != "" {
+			return validation.NewValidationError(fmt.Sprintf("must be an API key starting with %q followed by %d characters", prefix, length))
+		}
+		return nil
+	})
+}
+
+// isToken68 reports whether s matches 1*( ALPHA / DIGIT / "-" / "." / "_" / "~" / "+" / "/" ) *"=".
+func isToken68(s string) bool {
+	body := strings.TrimRight(s, "=")
+	if body == "" {
+		return false
+	}
+	for _, c := range body {
+		alnum := c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+		if !alnum && !strings.ContainsRune("-._~+/", c) {
+			return false
+		}
+	}
+	return true
+}
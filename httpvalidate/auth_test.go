@@ -0,0 +1,56 @@
+package httpvalidate_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/quantumcycle/protego/httpvalidate"
+	"github.com/quantumcycle/protego/validation"
+)
+
+func TestIsBearerToken(t *testing.T) {
+	g := NewWithT(t)
+	for _, v := range []string{"Bearer mF_9.B5f-4.1JqM", "bearer abc==", "Bearer eyJhbGciOi.eyJzdWIiOi.SflKxwRJ"} {
+		g.Expect(validation.Validate(v, httpvalidate.IsBearerToken())).To(Succeed(), v)
+	}
+	for _, v := range []string{"", "Bearer", "Bearer ", "Basic abc", "Bearer a b", "Bearer =abc", "Bearer ab=c"} {
+		g.Expect(validation.Validate(v, httpvalidate.IsBearerToken())).To(MatchError("must be a valid Bearer token"), v)
+	}
+}
+
+func TestIsBasicAuthHeader(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(validation.Validate("Basic QWxhZGRpbjpvcGVuIHNlc2FtZQ==", httpvalidate.IsBasicAuthHeader())).To(Succeed())
+	for _, v := range []string{"Basic", "Bearer QWxhZGRpbjpvcGVuIHNlc2FtZQ==", "Basic not-base64!", "Basic QWxhZGRpbg=="} {
+		g.Expect(validation.Validate(v, httpvalidate.IsBasicAuthHeader())).To(MatchError("must be a valid Basic authorization"), v)
+	}
+}
+
+func TestIsAPIKeyFormat(t *testing.T) {
+
+	t.Run("checks prefix, length and charset", func(t *testing.T) {
+		g := NewWithT(t)
+		v := httpvalidate.IsAPIKeyFormat("sk_live_", 8, "")
+		g.Expect(validation.Validate("sk_live_aB3dE6gH", v)).To(Succeed())
+		for _, key := range []string{"sk_test_aB3dE6gH", "sk_live_aB3dE6g", "sk_live_aB3dE6g!"} {
+			g.Expect(validation.Validate(key, v)).To(MatchError(`must be an API key starting with "sk_live_" followed by 8 characters`), key)
+		}
+	})
+
+	t.Run("supports custom charsets", func(t *testing.T) {
+		g := NewWithT(t)
+		v := httpvalidate.IsAPIKeyFormat("", 4, "0123456789abcdef")
+		g.Expect(validation.Validate("9f3a", v)).To(Succeed())
+		g.Expect(validation.Validate("9F3A", v)).To(HaveOccurred())
+	})
+
+	t.Run("never includes the credentials in errors", func(t *testing.T) {
+		g := NewWithT(t)
+		validation.SetIncludeValues(true)
+		defer validation.SetIncludeValues(false)
+		err := validation.Validate("sk_live_secret", httpvalidate.IsAPIKeyFormat("sk_live_", 8, ""))
+		g.Expect(err).To(HaveOccurred())
+		g.Expect(err.Error()).ToNot(ContainSubstring("secret"))
+	})
+}
@@ -0,0 +1,136 @@
+package httpvalidate
+
+import (
+	"strings"
+
+	"github.com/quantumcycle/protego/validation"
+)
+
+// IsETag validates a strong or weak entity tag, such as "\"v42\"" or "W/\"v42\"".
+//
+// Example:
+//
+//	validation.Validate(w.Header().Get("ETag"), httpvalidate.IsETag())
+func IsETag() validation.Validator[string] {
+	return func(v string) error {
+		if _, _, ok := parseETag(v); !ok {
+			return validation.NewValidationError("must be a valid ETag")
+		}
+		return nil
+	}
+}
+
+// IsWeakETag validates a weak entity tag, such as "W/\"v42\"".
+func IsWeakETag() validation.Validator[string] {
+	return func(v string) error {
+		if _, weak, ok := parseETag(v); !ok || !weak {
+			return validation.NewValidationError("must be a valid weak ETag")
+		}
+		return nil
+	}
+}
+
+// MatchesETagList validates an If-Match or If-None-Match header: "*" or a comma-separated
+// list of entity tags.
+//
+// Example:
+//
+//	httpvalidate.Header("If-None-Match", httpvalidate.MatchesETagList())
+func MatchesETagList() validation.Validator[string] {
+	return func(v string) error {
+		if _, ok := parseETagList(v); !ok {
+			return validation.NewValidationError(`must be "*" or a list of ETags`)
+		}
+		return nil
+	}
+}
+
+// IfMatch validates an If-Match header against the current entity tag of the resource,
+// using the strong comparison of RFC 9110: "*" matches any resource, weak tags match nothing.
+// Use it to reject updates based on a stale representation.
+//
+// Example:
+//
+//	err := httpvalidate.ValidateHeaders(r.Header,
+//	    httpvalidate.Header("If-Match", httpvalidate.IfMatch(order.ETag())).Required(),
+//	)
+func IfMatch(current string) validation.Validator[string] {
+	return func(v string) error {
+		tags, ok := parseETagList(v)
+		if !ok {
+			return validation.NewValidationError(`must be "*" or a list of ETags`)
+		}
+		currentTag, currentWeak, _ := parseETag(current)
+		for _, tag := range tags {
+			if tag.any || (!tag.weak && !currentWeak && tag.opaque == currentTag) {
+				return nil
+			}
+		}
+		return validation.NewValidationError("must match the current ETag")
+	}
+}
+
+// IfNoneMatch validates an If-None-Match header against the current entity tag of the
+// resource, using the weak comparison of RFC 9110: it fails when "*" or any listed tag
+// matches. Use it to prevent creating a resource that already exists.
+//
+// Example:
+//
+//	httpvalidate.Header("If-None-Match", httpvalidate.IfNoneMatch(existing.ETag()))
+func IfNoneMatch(current string) validation.Validator[string] {
+	return func(v string) error {
+		tags, ok := parseETagList(v)
+		if !ok {
+			return validation.NewValidationError(`must be "*" or a list of ETags`)
+		}
+		currentTag, _, exists := parseETag(current)
+		for _, tag := range tags {
+			if exists && (tag.any || tag.opaque == currentTag) {
+				return validation.NewValidationError("must not match the current ETag")
+			}
+		}
+		return nil
+	}
+}
+
+type etag struct {
+	opaque string
+	weak   bool
+	any    bool
+}
+
+// parseETag parses an entity tag, returning its opaque part with quotes and whether it is weak.
+func parseETag(s string) (string, bool, bool) {
+	weak := strings.HasPrefix(s, "W/")
+	opaque := strings.TrimPrefix(s, "W/")
+	if len(opaque) < 2 || opaque[0] != '"' || opaque[len(opaque)-1] != '"' {
+		return "", false, false
+	}
+	for i := 1; i < len(opaque)-1; i++ {
+		// etagc = %x21 / %x23-7E / obs-text
+		if c := opaque[i]; c < 0x21 || c == '"' || c == 0x7f {
+			return "", false, false
+		}
+	}
+	return opaque, weak, true
+}
+
+// parseETagList parses "*" or a comma-separated list of entity tags.
+func parseETagList(s string) ([]etag, bool) {
+	if strings.TrimSpace(s) == "*" {
+		return []etag{{any: true}}, true
+	}
+	items := splitList(s)
+	if len(items) == 0 {
+		return nil, false
+	}
+	tags := make([]etag, 0, len(items))
+	for _, item := range items {
+		opaque, weak, ok := parseETag(item)
+		if !ok {
+			return nil, false
+		}
+		tags = append(tags, etag{opaque: opaque, weak: weak})
+	}
+	return tags, true
+}
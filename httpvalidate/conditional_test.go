@@ -0,0 +1,61 @@
+package httpvalidate_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/quantumcycle/protego/httpvalidate"
+	"github.com/quantumcycle/protego/validation"
+)
+
+func TestIsETag(t *testing.T) {
+	g := NewWithT(t)
+	for _, v := range []string{`"v42"`, `W/"v42"`, `""`, `"a-b/c"`} {
+		g.Expect(validation.Validate(v, httpvalidate.IsETag())).To(Succeed(), v)
+	}
+	for _, v := range []string{`v42`, `"v42`, `w/"v42"`, `"v 42"`, `"v"42"`, ``} {
+		g.Expect(validation.Validate(v, httpvalidate.IsETag())).To(MatchError("must be a valid ETag"), v)
+	}
+}
+
+func TestIsWeakETag(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(validation.Validate(`W/"v42"`, httpvalidate.IsWeakETag())).To(Succeed())
+	g.Expect(validation.Validate(`"v42"`, httpvalidate.IsWeakETag())).To(MatchError("must be a valid weak ETag"))
+}
+
+func TestMatchesETagList(t *testing.T) {
+	g := NewWithT(t)
+	for _, v := range []string{`*`, `"a"`, `"a", W/"b" ,"c"`} {
+		g.Expect(validation.Validate(v, httpvalidate.MatchesETagList())).To(Succeed(), v)
+	}
+	for _, v := range []string{``, `*, "a"`, `"a", b`} {
+		g.Expect(validation.Validate(v, httpvalidate.MatchesETagList())).To(MatchError(`must be "*" or a list of ETags`), v)
+	}
+}
+
+func TestIfMatch(t *testing.T) {
+	v := httpvalidate.IfMatch(`"v2"`)
+
+	t.Run("passes when a strong tag matches", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(validation.Validate(`"v1", "v2"`, v)).To(Succeed())
+		g.Expect(validation.Validate(`*`, v)).To(Succeed())
+	})
+
+	t.Run("fails with stale or weak tags", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(validation.Validate(`"v1"`, v)).To(MatchError("must match the current ETag"))
+		g.Expect(validation.Validate(`W/"v2"`, v)).To(MatchError("must match the current ETag"))
+		g.Expect(validation.Validate(`"v2"`, httpvalidate.IfMatch(`W/"v2"`))).To(MatchError("must match the current ETag"))
+	})
+}
+
+func TestIfNoneMatch(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(validation.Validate(`"v1"`, httpvalidate.IfNoneMatch(`"v2"`))).To(Succeed())
+	g.Expect(validation.Validate(`W/"v2"`, httpvalidate.IfNoneMatch(`"v2"`))).To(MatchError("must not match the current ETag"))
+	g.Expect(validation.Validate(`*`, httpvalidate.IfNoneMatch(`"v2"`))).To(MatchError("must not match the current ETag"))
+	g.Expect(validation.Validate(`*`, httpvalidate.IfNoneMatch(""))).To(Succeed())
+}
@@ -0,0 +1,201 @@
+package httpvalidate
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"github.com/quantumcycle/protego/validation"
+)
+
+// ErrorEncoder writes the body of error responses in one media type. The encoders set with
+// SetErrorEncoders are selected by the Accept header of the request.
+type ErrorEncoder struct {
+	// MediaType is the Content-Type of the responses, such as "application/problem+json",
+	// matched against the media ranges of the Accept header.
+	MediaType string
+	// Encode writes the body of the response for err, answered with status. For a 500
+	// status, err is a generic error with the status text as message: the details of
+	// system errors are never exposed.
+	Encode func(w io.Writer, status int, err error) error
+}
+
+var errorEncoders atomic.Pointer[[]ErrorEncoder]
+
+// SetErrorEncoders sets the encoders of the error responses written by WriteErrorFor, and
+// thus by Handler and Headers. The encoder whose media type the Accept header of the
+// request prefers is used; the first one is used when there is no Accept header or it
+// accepts none of them. Without encoders, errors are written by JSONErrors.
+//
+// Example:
+//
+//	func main() {
+//	    httpvalidate.SetErrorEncoders(httpvalidate.ProblemJSON(), httpvalidate.JSONAPIErrors(), httpvalidate.PlainTextErrors())
+//	    ...
+//	}
+func SetErrorEncoders(encoders ...ErrorEncoder) {
+	if len(encoders) == 0 {
+		errorEncoders.Store(nil)
+		return
+	}
+	encoders = append([]ErrorEncoder(nil), encoders...)
+	errorEncoders.Store(&encoders)
+}
+
+// negotiateErrorEncoder returns the encoder preferred by the Accept header, the first one
+// if it accepts none.
+func negotiateErrorEncoder(accept string) ErrorEncoder {
+	encoders := errorEncoders.Load()
+	if encoders == nil {
+		return JSONErrors()
+	}
+	best, bestQuality := (*encoders)[0], 0.0
+	for _, encoder := range *encoders {
+		if q := acceptQuality(accept, encoder.MediaType); q > bestQuality {
+			best, bestQuality = encoder, q
+		}
+	}
+	return best
+}
+
+// acceptQuality returns the quality given to mediaType by the most specific matching range
+// of the Accept header, 0 if none matches.
+func acceptQuality(accept, mediaType string) float64 {
+	mediaType, _, err := mime.ParseMediaType(mediaType)
+	if err != nil {
+		return 0
+	}
+	q, specificity := 0.0, -1
+	for _, item := range splitList(accept) {
+		mediaRange, params, err := mime.ParseMediaType(item)
+		if err != nil || !mediaRangeMatches(mediaRange, mediaType) {
+			continue
+		}
+		s := 2 - strings.Count(mediaRange, "*")
+		if s <= specificity {
+			continue
+		}
+		q, specificity = 1, s
+		if params["q"] != "" {
+			q = quality(params["q"])
+		}
+	}
+	return q
+}
+
+// JSONErrors returns the default encoder, writing an ErrorResponse as application/json:
+//
+//	{"errors":[{"field":"address.city","message":"required"}]}
+func JSONErrors() ErrorEncoder {
+	return ErrorEncoder{
+		MediaType: "application/json",
+		Encode: func(w io.Writer, status int, err error) error {
+			return json.NewEncoder(w).Encode(ErrorResponse{Errors: details(err, "")})
+		},
+	}
+}
+
+// Problem is the body written by ProblemJSON: an RFC 9457 problem detail, listing the
+// validation failures in the errors extension member.
+type Problem struct {
+	Type   string                   `json:"type"`
+	Title  string                   `json:"title"`
+	Status int                      `json:"status"`
+	Detail string                   `json:"detail,omitempty"`
+	Errors []validation.ErrorObject `json:"errors,omitempty"`
+}
+
+// ProblemJSON returns an encoder writing a Problem as application/problem+json:
+//
+//	{"type":"about:blank","title":"Bad Request","status":400,"detail":"The request is invalid.",
+//	 "errors":[{"code":"invalid","message":"required","field":"address.city"}]}
+func ProblemJSON() ErrorEncoder {
+	return ErrorEncoder{
+		MediaType: "application/problem+json",
+		Encode: func(w io.Writer, status int, err error) error {
+			problem := Problem{Type: "about:blank", Title: http.StatusText(status), Status: status}
+			if status < http.StatusInternalServerError {
+				problem.Detail = "The request is invalid."
+				problem.Errors = validation.ErrorObjects(err)
+			}
+			return json.NewEncoder(w).Encode(problem)
+		},
+	}
+}
+
+// JSONAPIErrors returns an encoder writing the JSON:API error objects of
+// validation.ToJSONAPIErrors as application/vnd.api+json, with the status of the response:
+//
+//	{"errors":[{"status":"400","code":"invalid","title":"Bad Request","detail":"required",
+//	 "source":{"pointer":"/data/attributes/address/city"}}]}
+func JSONAPIErrors() ErrorEncoder {
+	return ErrorEncoder{
+		MediaType: "application/vnd.api+json",
+		Encode: func(w io.Writer, status int, err error) error {
+			errs := validation.ToJSONAPIErrors(err)
+			for i := range errs {
+				errs[i].Status, errs[i].Title = strconv.Itoa(status), http.StatusText(status)
+			}
+			return json.NewEncoder(w).Encode(map[string][]validation.JSONAPIError{"errors": errs})
+		},
+	}
+}
+
+// GraphQLError is an error of a GraphQL response, as written by GraphQLErrors.
+type GraphQLError struct {
+	Message    string         `json:"message"`
+	Path       []any          `json:"path,omitempty"`
+	Extensions map[string]any `json:"extensions"`
+}
+
+// GraphQLErrors returns an encoder writing a GraphQL response without data as
+// application/graphql-response+json. Field paths become GraphQL paths, and codes and rule
+// parameters are written under extensions:
+//
+//	{"data":null,"errors":[{"message":"required","path":["items",2,"sku"],"extensions":{"code":"invalid"}}]}
+func GraphQLErrors() ErrorEncoder {
+	return ErrorEncoder{
+		MediaType: "application/graphql-response+json",
+		Encode: func(w io.Writer, status int, err error) error {
+			var errs []GraphQLError
+			for _, obj := range validation.ErrorObjects(err) {
+				e := GraphQLError{Message: obj.Message, Path: validation.PathSegments(obj.Field), Extensions: map[string]any{"code": obj.Code}}
+				if obj.Params != nil {
+					e.Extensions["params"] = obj.Params
+				}
+				errs = append(errs, e)
+			}
+			return json.NewEncoder(w).Encode(struct {
+				Data   any            `json:"data"`
+				Errors []GraphQLError `json:"errors"`
+			}{Errors: errs})
+		},
+	}
+}
+
+// PlainTextErrors returns an encoder writing one line per error as text/plain, prefixed
+// with the field path when there is one:
+//
+//	address.city: required
+func PlainTextErrors() ErrorEncoder {
+	return ErrorEncoder{
+		MediaType: "text/plain; charset=utf-8",
+		Encode: func(w io.Writer, status int, err error) error {
+			for _, detail := range details(err, "") {
+				line := detail.Message
+				if detail.Field != "" {
+					line = detail.Field + ": " + line
+				}
+				if _, err := fmt.Fprintln(w, line); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+}
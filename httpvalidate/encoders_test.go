@@ -0,0 +1,121 @@
+package httpvalidate_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/quantumcycle/protego/httpvalidate"
+	"github.com/quantumcycle/protego/validation"
+)
+
+func TestErrorEncoders(t *testing.T) {
+	httpvalidate.SetErrorEncoders(
+		httpvalidate.JSONErrors(),
+		httpvalidate.ProblemJSON(),
+		httpvalidate.JSONAPIErrors(),
+		httpvalidate.GraphQLErrors(),
+		httpvalidate.PlainTextErrors(),
+	)
+	defer httpvalidate.SetErrorEncoders()
+
+	err := errors.Join(
+		validation.NewFieldError("items", validation.NewFieldError("[2]", validation.NewFieldError("sku",
+			validation.NewCodedError("too_short", "must be at least 8 characters", map[string]any{"min": 8})))),
+		validation.NewValidationError("invalid payload"),
+	)
+	write := func(accept string, err error) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodPost, "/orders", nil)
+		if accept != "" {
+			r.Header.Set("Accept", accept)
+		}
+		w := httptest.NewRecorder()
+		httpvalidate.WriteErrorFor(w, r, err)
+		return w
+	}
+
+	t.Run("uses the first encoder without Accept header", func(t *testing.T) {
+		g := NewWithT(t)
+		w := write("", err)
+		g.Expect(w.Code).To(Equal(http.StatusBadRequest))
+		g.Expect(w.Header().Get("Content-Type")).To(Equal("application/json"))
+		g.Expect(w.Body.String()).To(MatchJSON(`{"errors":[
+			{"field":"items[2].sku","message":"must be at least 8 characters"},
+			{"message":"invalid payload"}
+		]}`))
+	})
+
+	t.Run("writes problem details", func(t *testing.T) {
+		g := NewWithT(t)
+		w := write("application/problem+json", err)
+		g.Expect(w.Header().Get("Content-Type")).To(Equal("application/problem+json"))
+		g.Expect(w.Body.String()).To(MatchJSON(`{
+			"type":"about:blank","title":"Bad Request","status":400,"detail":"The request is invalid.",
+			"errors":[
+				{"code":"too_short","message":"must be at least 8 characters","field":"items[2].sku","params":{"min":8}},
+				{"code":"invalid","message":"invalid payload"}
+			]
+		}`))
+	})
+
+	t.Run("writes JSON:API errors", func(t *testing.T) {
+		g := NewWithT(t)
+		w := write("application/vnd.api+json", err)
+		g.Expect(w.Header().Get("Content-Type")).To(Equal("application/vnd.api+json"))
+		g.Expect(w.Body.String()).To(MatchJSON(`{"errors":[
+			{"status":"400","code":"too_short","title":"Bad Request","detail":"must be at least 8 characters",
+			 "source":{"pointer":"/data/attributes/items/2/sku"},"meta":{"params":{"min":8}}},
+			{"status":"400","code":"invalid","title":"Bad Request","detail":"invalid payload"}
+		]}`))
+	})
+
+	t.Run("writes GraphQL errors", func(t *testing.T) {
+		g := NewWithT(t)
+		w := write("application/graphql-response+json", err)
+		g.Expect(w.Body.String()).To(MatchJSON(`{"data":null,"errors":[
+			{"message":"must be at least 8 characters","path":["items",2,"sku"],"extensions":{"code":"too_short","params":{"min":8}}},
+			{"message":"invalid payload","extensions":{"code":"invalid"}}
+		]}`))
+	})
+
+	t.Run("writes plain text", func(t *testing.T) {
+		g := NewWithT(t)
+		w := write("text/plain", err)
+		g.Expect(w.Header().Get("Content-Type")).To(Equal("text/plain; charset=utf-8"))
+		g.Expect(w.Body.String()).To(Equal("items[2].sku: must be at least 8 characters\ninvalid payload\n"))
+	})
+
+	t.Run("selects the encoder by quality and specificity", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(write("text/plain;q=0.5, application/problem+json", err).Header().Get("Content-Type")).To(Equal("application/problem+json"))
+		g.Expect(write("text/*, */*;q=0.1", err).Header().Get("Content-Type")).To(Equal("text/plain; charset=utf-8"))
+		g.Expect(write("*/*, application/json;q=0", err).Header().Get("Content-Type")).To(Equal("application/problem+json"))
+		g.Expect(write("text/html", err).Header().Get("Content-Type")).To(Equal("application/json"))
+	})
+
+	t.Run("does not expose system errors", func(t *testing.T) {
+		g := NewWithT(t)
+		w := write("application/problem+json", errors.New("connection refused"))
+		g.Expect(w.Code).To(Equal(http.StatusInternalServerError))
+		g.Expect(w.Body.String()).To(MatchJSON(`{"type":"about:blank","title":"Internal Server Error","status":500}`))
+
+		w = write("application/vnd.api+json", errors.New("connection refused"))
+		g.Expect(w.Body.String()).NotTo(ContainSubstring("connection refused"))
+	})
+
+	t.Run("is used by the middleware", func(t *testing.T) {
+		g := NewWithT(t)
+		handler := httpvalidate.Headers(
+			httpvalidate.Header("X-Request-ID", validation.Required[string]()).Required(),
+		)(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.Header.Set("Accept", "application/problem+json")
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		g.Expect(w.Code).To(Equal(http.StatusBadRequest))
+		g.Expect(w.Header().Get("Content-Type")).To(Equal("application/problem+json"))
+	})
+}
@@ -0,0 +1,221 @@
+package httpvalidate
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/quantumcycle/protego/validation"
+)
+
+// Filter operators. Values are written after the operator and a colon, e.g. age=gte:18.
+// The in operator takes a comma-separated list of values, e.g. status=in:open,pending.
+const (
+	OpEq   = "eq"
+	OpNe   = "ne"
+	OpGt   = "gt"
+	OpGte  = "gte"
+	OpLt   = "lt"
+	OpLte  = "lte"
+	OpIn   = "in"
+	OpLike = "like"
+)
+
+var (
+	orderedOps  = []string{OpEq, OpNe, OpGt, OpGte, OpLt, OpLte, OpIn}
+	equalityOps = []string{OpEq, OpNe}
+	stringOps   = []string{OpEq, OpNe, OpIn, OpLike}
+	enumOps     = []string{OpEq, OpNe, OpIn}
+)
+
+// Condition is a single parsed filter condition, such as age=gte:18.
+// Values holds one value, or several for the in operator.
+type Condition struct {
+	Field  string
+	Op     string
+	Values []string
+}
+
+// FilterField declares the type and the allowed operators of a filterable field.
+// It is created by FilterInt, FilterBool, FilterTime, FilterString or FilterEnum.
+type FilterField struct {
+	ops   []string
+	check func(string) error
+}
+
+// Ops restricts the operators allowed on the field.
+func (f FilterField) Ops(ops ...string) FilterField {
+	f.ops = ops
+	return f
+}
+
+// FilterInt declares an integer field, allowing comparison operators and in by default.
+func FilterInt(validators ...validation.Validator[int]) FilterField {
+	return FilterField{ops: orderedOps, check: checkParsed(parseInt, validators)}
+}
+
+// FilterBool declares a boolean field, allowing eq and ne by default.
+func FilterBool() FilterField {
+	return FilterField{ops: equalityOps, check: checkParsed(parseBool, nil)}
+}
+
+// FilterTime declares a time field parsed with layout, allowing comparison operators and in
+// by default.
+func FilterTime(layout string, validators ...validation.Validator[time.Time]) FilterField {
+	parse := func(s string) (time.Time, error) {
+		t, err := time.Parse(layout, s)
+		if err != nil {
+			return time.Time{}, validation.NewValidationError(fmt.Sprintf("must match date format %q", layout))
+		}
+		return t, nil
+	}
+	return FilterField{ops: orderedOps, check: checkParsed(parse, validators)}
+}
+
+// FilterString declares a free-form string field, allowing eq, ne, in and like by default.
+func FilterString(validators ...validation.Validator[string]) FilterField {
+	return FilterField{ops: stringOps, check: checkParsed(parseString, validators)}
+}
+
+// FilterEnum declares a string field restricted to the allowed values, allowing eq, ne and
+// in by default.
+func FilterEnum(allowed ...string) FilterField {
+	return FilterField{ops: enumOps, check: checkParsed(parseString, []validation.Validator[string]{validation.In(false, allowed...)})}
+}
+
+func checkParsed[T any](parse func(string) (T, error), validators []validation.Validator[T]) func(string) error {
+	return func(s string) error {
+		v, err := parse(s)
+		if err != nil {
+			return err
+		}
+		return validation.Validate(v, validators...)
+	}
+}
+
+// ParseFilter parses and validates a filter expression such as
+// "status=eq:ACTIVE&age=gte:18" against the declared fields. A value without operator, as
+// in "status=ACTIVE", means eq. Fields may be repeated ("age=gte:18&age=lt:65").
+// Every condition is checked and all failures are returned joined, each attributed to
+// its field (see validation.FieldError).
+//
+// Example:
+//
+//	conditions, err := httpvalidate.ParseFilter(r.URL.Query().Get("filter"), map[string]httpvalidate.FilterField{
+//	    "status": httpvalidate.FilterEnum("ACTIVE", "SUSPENDED"),
+//	    "age":    httpvalidate.FilterInt(validation.Min(0)),
+//	})
+func ParseFilter(expr string, fields map[string]FilterField) ([]Condition, error) {
+	var conditions []Condition
+	var errs []error
+	for _, part := range strings.Split(expr, "&") {
+		if part == "" {
+			continue
+		}
+		key, value, _ := strings.Cut(part, "=")
+		name, err := url.QueryUnescape(key)
+		if err != nil {
+			errs = append(errs, validation.NewValidationError(fmt.Sprintf("invalid filter %q", part)))
+			continue
+		}
+		if value, err = url.QueryUnescape(value); err != nil {
+			errs = append(errs, validation.NewFieldError(name, validation.NewValidationError("must be a valid filter value")))
+			continue
+		}
+		field, ok := fields[name]
+		if !ok {
+			errs = append(errs, validation.NewFieldError(name, validation.NewValidationError("is not filterable")))
+			continue
+		}
+		c, err := field.parse(name, value)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		conditions = append(conditions, c)
+	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	return conditions, nil
+}
+
+// Filters creates a rule binding the query parameters named after the declared fields as
+// filter conditions, e.g. ?status=eq:ACTIVE&age=gte:18. Other parameters are ignored,
+// so that filters can be combined with pagination or sorting parameters.
+// Conditions are stored in target ordered by field name.
+//
+// Example:
+//
+//	var conditions []httpvalidate.Condition
+//	err := httpvalidate.QueryParams(r,
+//	    httpvalidate.Int("limit", &limit, validation.Range(1, 100)).Default(20),
+//	    httpvalidate.Filters(&conditions, map[string]httpvalidate.FilterField{
+//	        "status": httpvalidate.FilterEnum("ACTIVE", "SUSPENDED"),
+//	        "age":    httpvalidate.FilterInt().Ops(httpvalidate.OpGte, httpvalidate.OpLt),
+//	    }),
+//	)
+func Filters(target *[]Condition, fields map[string]FilterField) Rule {
+	return filterRule{target: target, fields: fields}
+}
+
+type filterRule struct {
+	target *[]Condition
+	fields map[string]FilterField
+}
+
+func (r filterRule) bind(src source) error {
+	names := make([]string, 0, len(r.fields))
+	for name := range r.fields {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	var conditions []Condition
+	var errs []error
+	for _, name := range names {
+		for _, value := range src.values[name] {
+			c, err := r.fields[name].parse(name, value)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			conditions = append(conditions, c)
+		}
+	}
+	*r.target = conditions
+	return errors.Join(errs...)
+}
+
+// parse parses a single "op:value" filter value of the named field.
+func (f FilterField) parse(name, raw string) (Condition, error) {
+	op, value := splitOp(raw)
+	if !slices.Contains(f.ops, op) {
+		msg := fmt.Sprintf("operator %q is not allowed, use one of: %s", op, strings.Join(f.ops, ", "))
+		return Condition{}, validation.NewFieldError(name, validation.NewValidationError(msg))
+	}
+	values := []string{value}
+	if op == OpIn {
+		values = strings.Split(value, ",")
+	}
+	for _, v := range values {
+		if err := f.check(v); err != nil {
+			return Condition{}, validation.NewFieldError(name, err)
+		}
+	}
+	return Condition{Field: name, Op: op, Values: values}, nil
+}
+
+// splitOp splits "op:value" into its operator and value. A value without an operator
+// prefix made of lower-case letters, such as "ACTIVE" or "2024-01-02T10:00:00Z", means eq;
+// values such as "a:b" must be written with an explicit operator ("eq:a:b").
+func splitOp(raw string) (string, string) {
+	prefix, value, ok := strings.Cut(raw, ":")
+	if !ok || prefix == "" || strings.Trim(prefix, "abcdefghijklmnopqrstuvwxyz") != "" {
+		return OpEq, raw
+	}
+	return prefix, value
+}
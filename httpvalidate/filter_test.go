@@ -0,0 +1,98 @@
+package httpvalidate_test
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/quantumcycle/protego/httpvalidate"
+	"github.com/quantumcycle/protego/validation"
+)
+
+var userFilters = map[string]httpvalidate.FilterField{
+	"status":  httpvalidate.FilterEnum("ACTIVE", "SUSPENDED"),
+	"age":     httpvalidate.FilterInt(validation.Min(0)),
+	"name":    httpvalidate.FilterString(validation.MaxLength(20)),
+	"admin":   httpvalidate.FilterBool(),
+	"created": httpvalidate.FilterTime(time.RFC3339).Ops(httpvalidate.OpGte, httpvalidate.OpLt),
+}
+
+func TestParseFilter(t *testing.T) {
+
+	t.Run("parses conditions in order", func(t *testing.T) {
+		g := NewWithT(t)
+		conditions, err := httpvalidate.ParseFilter("status=eq:ACTIVE&age=gte:18&age=lt:65&status=in:ACTIVE,SUSPENDED", userFilters)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(conditions).To(Equal([]httpvalidate.Condition{
+			{Field: "status", Op: "eq", Values: []string{"ACTIVE"}},
+			{Field: "age", Op: "gte", Values: []string{"18"}},
+			{Field: "age", Op: "lt", Values: []string{"65"}},
+			{Field: "status", Op: "in", Values: []string{"ACTIVE", "SUSPENDED"}},
+		}))
+	})
+
+	t.Run("defaults to eq without operator", func(t *testing.T) {
+		g := NewWithT(t)
+		conditions, err := httpvalidate.ParseFilter("status=ACTIVE&created=gte:2024-01-02T10:00:00Z&name=eq:a%3Ab", userFilters)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(conditions).To(Equal([]httpvalidate.Condition{
+			{Field: "status", Op: "eq", Values: []string{"ACTIVE"}},
+			{Field: "created", Op: "gte", Values: []string{"2024-01-02T10:00:00Z"}},
+			{Field: "name", Op: "eq", Values: []string{"a:b"}},
+		}))
+	})
+
+	t.Run("reports every invalid condition with its field", func(t *testing.T) {
+		g := NewWithT(t)
+		conditions, err := httpvalidate.ParseFilter("status=eq:DELETED&age=gte:adult&admin=gt:true&role=eq:x&created=eq:2024-01-02T10:00:00Z", userFilters)
+		g.Expect(conditions).To(BeNil())
+		g.Expect(validation.IsValidationError(err)).To(BeTrue())
+		g.Expect(err).To(MatchError("status: must be one of: [ACTIVE SUSPENDED]\n" +
+			"age: must be a valid integer\n" +
+			`admin: operator "gt" is not allowed, use one of: eq, ne` + "\n" +
+			"role: is not filterable\n" +
+			`created: operator "eq" is not allowed, use one of: gte, lt`))
+	})
+
+	t.Run("rejects unknown operators", func(t *testing.T) {
+		g := NewWithT(t)
+		_, err := httpvalidate.ParseFilter("age=between:1", userFilters)
+		g.Expect(err).To(MatchError(`age: operator "between" is not allowed, use one of: eq, ne, gt, gte, lt, lte, in`))
+	})
+
+	t.Run("checks every value of in", func(t *testing.T) {
+		g := NewWithT(t)
+		_, err := httpvalidate.ParseFilter("age=in:1,-2", userFilters)
+		g.Expect(err).To(MatchError("age: must be at least 0"))
+	})
+}
+
+func TestFilters(t *testing.T) {
+
+	t.Run("binds filter conditions from query parameters", func(t *testing.T) {
+		g := NewWithT(t)
+		r := httptest.NewRequest("GET", "/users?status=eq:ACTIVE&age=gte:18&limit=10", nil)
+		var conditions []httpvalidate.Condition
+		var limit int
+		err := httpvalidate.QueryParams(r,
+			httpvalidate.Int("limit", &limit),
+			httpvalidate.Filters(&conditions, userFilters),
+		)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(limit).To(Equal(10))
+		g.Expect(conditions).To(Equal([]httpvalidate.Condition{
+			{Field: "age", Op: "gte", Values: []string{"18"}},
+			{Field: "status", Op: "eq", Values: []string{"ACTIVE"}},
+		}))
+	})
+
+	t.Run("reports invalid conditions", func(t *testing.T) {
+		g := NewWithT(t)
+		r := httptest.NewRequest("GET", "/users?age=like:1", nil)
+		var conditions []httpvalidate.Condition
+		err := httpvalidate.QueryParams(r, httpvalidate.Filters(&conditions, userFilters))
+		g.Expect(err).To(MatchError(`age: operator "like" is not allowed, use one of: eq, ne, gt, gte, lt, lte, in`))
+	})
+}
@@ -0,0 +1,65 @@
+package httpvalidate
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/quantumcycle/protego/validation"
+)
+
+// Handler creates an http.Handler around a typed function.
+// The JSON request body is decoded into In, validated, passed to fn, and the result is
+// encoded as JSON with a 200 status. In is validated with its Validate method when it
+// implements validation.Validatable or validation.ValidatableCtx, receiving the request
+// context in the latter case, then with the given validators.
+//
+// Malformed bodies and validation failures are answered with a 400 response, errors
+// returned by fn are written with WriteErrorFor.
+//
+// Example:
+//
+//	mux.Handle("POST /users", httpvalidate.Handler(func(ctx context.Context, in CreateUserInput) (User, error) {
+//	    return users.Create(ctx, in)
+//	}))
+func Handler[In, Out any](fn func(context.Context, In) (Out, error), validators ...validation.Validator[In]) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var in In
+		if err := decodeJSON(r, &in); err != nil {
+			WriteErrorFor(w, r, err)
+			return
+		}
+		if err := validateInput(r.Context(), in, validators); err != nil {
+			WriteErrorFor(w, r, err)
+			return
+		}
+		out, err := fn(r.Context(), in)
+		if err != nil {
+			WriteErrorFor(w, r, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(out)
+	})
+}
+
+// decodeJSON decodes the request body into target. An empty body leaves target untouched.
+func decodeJSON(r *http.Request, target any) error {
+	if r.Body == nil {
+		return nil
+	}
+	err := json.NewDecoder(r.Body).Decode(target)
+	if err == nil || errors.Is(err, io.EOF) {
+		return nil
+	}
+	return validation.NewValidationError("must be a valid JSON body")
+}
+
+func validateInput[In any](ctx context.Context, in In, validators []validation.Validator[In]) error {
+	if err := validation.ValidateNestedCtx(ctx, in); err != nil {
+		return err
+	}
+	return validation.Validate(in, validators...)
+}
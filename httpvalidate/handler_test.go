@@ -0,0 +1,122 @@
+package httpvalidate_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/quantumcycle/protego/httpvalidate"
+	"github.com/quantumcycle/protego/validation"
+)
+
+type createUserInput struct {
+	Name string `json:"name"`
+	Age  int    `json:"age"`
+}
+
+func (in createUserInput) Validate() error {
+	return validation.ValidateStruct(
+		validation.Field(&in, &in.Name, validation.Required[string]()),
+	)
+}
+
+type user struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+func TestHandler(t *testing.T) {
+	create := func(_ context.Context, in createUserInput) (user, error) {
+		if in.Name == "taken" {
+			return user{}, validation.NewFieldError("name", validation.NewValidationError("already taken"))
+		}
+		if in.Name == "crash" {
+			return user{}, errors.New("database unavailable")
+		}
+		return user{ID: 1, Name: in.Name}, nil
+	}
+	handler := httpvalidate.Handler(create, validation.Custom(func(in createUserInput) error {
+		return validation.NewFieldError("age", validation.Validate(in.Age, validation.Range(18, 120)))
+	}))
+
+	serve := func(body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest("POST", "/users", strings.NewReader(body)))
+		return w
+	}
+
+	t.Run("decodes, invokes and encodes", func(t *testing.T) {
+		g := NewWithT(t)
+		w := serve(`{"name":"John","age":30}`)
+		g.Expect(w.Code).To(Equal(http.StatusOK))
+		var out user
+		g.Expect(json.Unmarshal(w.Body.Bytes(), &out)).To(Succeed())
+		g.Expect(out).To(Equal(user{ID: 1, Name: "John"}))
+	})
+
+	t.Run("rejects input failing Validate", func(t *testing.T) {
+		g := NewWithT(t)
+		w := serve(`{"age":30}`)
+		g.Expect(w.Code).To(Equal(http.StatusBadRequest))
+		g.Expect(w.Body.String()).To(ContainSubstring(`{"field":"name","message":"required"}`))
+	})
+
+	t.Run("rejects input failing extra validators", func(t *testing.T) {
+		g := NewWithT(t)
+		w := serve(`{"name":"John","age":12}`)
+		g.Expect(w.Code).To(Equal(http.StatusBadRequest))
+		g.Expect(w.Body.String()).To(ContainSubstring(`{"field":"age","message":"must be between 18 and 120"}`))
+	})
+
+	t.Run("rejects malformed JSON", func(t *testing.T) {
+		g := NewWithT(t)
+		w := serve(`{"name":`)
+		g.Expect(w.Code).To(Equal(http.StatusBadRequest))
+		g.Expect(w.Body.String()).To(ContainSubstring("must be a valid JSON body"))
+	})
+
+	t.Run("writes validation errors returned by the function as 400", func(t *testing.T) {
+		g := NewWithT(t)
+		w := serve(`{"name":"taken","age":30}`)
+		g.Expect(w.Code).To(Equal(http.StatusBadRequest))
+		g.Expect(w.Body.String()).To(ContainSubstring("already taken"))
+	})
+
+	t.Run("writes other errors returned by the function as 500", func(t *testing.T) {
+		g := NewWithT(t)
+		w := serve(`{"name":"crash","age":30}`)
+		g.Expect(w.Code).To(Equal(http.StatusInternalServerError))
+	})
+}
+
+type requestKey struct{}
+
+type reserveInput struct {
+	Slot string `json:"slot"`
+}
+
+func (in reserveInput) Validate(ctx context.Context) error {
+	if in.Slot == ctx.Value(requestKey{}) {
+		return validation.NewFieldError("slot", validation.NewValidationError("already reserved"))
+	}
+	return nil
+}
+
+func TestHandlerValidatableCtx(t *testing.T) {
+	g := NewWithT(t)
+	handler := httpvalidate.Handler(func(_ context.Context, in reserveInput) (reserveInput, error) {
+		return in, nil
+	})
+	r := httptest.NewRequest("POST", "/reservations", strings.NewReader(`{"slot":"9am"}`))
+	r = r.WithContext(context.WithValue(r.Context(), requestKey{}, "9am"))
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	g.Expect(w.Code).To(Equal(http.StatusBadRequest))
+	g.Expect(w.Body.String()).To(ContainSubstring(`{"field":"slot","message":"already reserved"}`))
+}
@@ -0,0 +1,89 @@
+package httpvalidate
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/quantumcycle/protego/validation"
+)
+
+// HeaderRule validates the values of a single request header.
+type HeaderRule struct {
+	name       string
+	required   bool
+	maxSize    int
+	validators []validation.Validator[string]
+}
+
+// Header creates a rule applying the validators to every value of the named header.
+//
+// Example:
+//
+//	httpvalidate.Header("X-Request-ID", playground.IsUUID).Required()
+func Header(name string, validators ...validation.Validator[string]) HeaderRule {
+	return HeaderRule{name: name, validators: validators}
+}
+
+// Required marks the header as mandatory.
+func (h HeaderRule) Required() HeaderRule {
+	h.required = true
+	return h
+}
+
+// MaxSize limits each header value to the given number of bytes.
+// The limit is checked before the validators run, so oversized values never reach them.
+func (h HeaderRule) MaxSize(bytes int) HeaderRule {
+	h.maxSize = bytes
+	return h
+}
+
+func (h HeaderRule) validate(header http.Header) error {
+	values := header.Values(h.name)
+	if len(values) == 0 {
+		if h.required {
+			return validation.NewFieldError(h.name, validation.NewValidationError("required"))
+		}
+		return nil
+	}
+	for _, v := range values {
+		if h.maxSize > 0 && len(v) > h.maxSize {
+			return validation.NewFieldError(h.name, validation.NewValidationError(fmt.Sprintf("must be at most %d bytes", h.maxSize)))
+		}
+		if err := validation.Validate(v, h.validators...); err != nil {
+			return validation.NewFieldError(h.name, err)
+		}
+	}
+	return nil
+}
+
+// ValidateHeaders applies the rules to the headers and returns all failures joined,
+// each attributed to its header name.
+func ValidateHeaders(header http.Header, rules ...HeaderRule) error {
+	errs := make([]error, 0, len(rules))
+	for _, rule := range rules {
+		errs = append(errs, rule.validate(header))
+	}
+	return errors.Join(errs...)
+}
+
+// Headers returns a middleware that validates request headers before calling the next handler.
+// Requests failing validation are rejected with a 400 response written by WriteErrorFor.
+//
+// Example:
+//
+//	mux.Handle("/orders", httpvalidate.Headers(
+//	    httpvalidate.Header("X-Request-ID", playground.IsUUID).Required(),
+//	    httpvalidate.Header("Authorization", validation.StartsWith("Bearer ")).Required().MaxSize(4096),
+//	)(ordersHandler))
+func Headers(rules ...HeaderRule) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if err := ValidateHeaders(r.Header, rules...); err != nil {
+				WriteErrorFor(w, r, err)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
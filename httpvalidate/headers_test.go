@@ -0,0 +1,70 @@
+package httpvalidate_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/quantumcycle/protego/httpvalidate"
+	"github.com/quantumcycle/protego/validation"
+)
+
+func TestHeaders(t *testing.T) {
+	ok := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+	middleware := httpvalidate.Headers(
+		httpvalidate.Header("X-Request-ID", validation.MatchesPattern(`^[0-9a-f-]{36}$`)).Required(),
+		httpvalidate.Header("Authorization", validation.StartsWith("Bearer ")).MaxSize(32),
+	)
+
+	t.Run("calls next handler when headers are valid", func(t *testing.T) {
+		g := NewWithT(t)
+		r := httptest.NewRequest("GET", "/", nil)
+		r.Header.Set("X-Request-ID", "550e8400-e29b-41d4-a716-446655440000")
+		r.Header.Set("Authorization", "Bearer abc")
+		w := httptest.NewRecorder()
+		middleware(ok).ServeHTTP(w, r)
+		g.Expect(w.Code).To(Equal(http.StatusNoContent))
+	})
+
+	t.Run("rejects missing required header", func(t *testing.T) {
+		g := NewWithT(t)
+		r := httptest.NewRequest("GET", "/", nil)
+		w := httptest.NewRecorder()
+		middleware(ok).ServeHTTP(w, r)
+		g.Expect(w.Code).To(Equal(http.StatusBadRequest))
+		g.Expect(w.Header().Get("Content-Type")).To(Equal("application/json"))
+
+		var body httpvalidate.ErrorResponse
+		g.Expect(json.Unmarshal(w.Body.Bytes(), &body)).To(Succeed())
+		g.Expect(body.Errors).To(ConsistOf(httpvalidate.ErrorDetail{Field: "X-Request-ID", Message: "required"}))
+	})
+
+	t.Run("rejects oversized header before running validators", func(t *testing.T) {
+		g := NewWithT(t)
+		r := httptest.NewRequest("GET", "/", nil)
+		r.Header.Set("X-Request-ID", "550e8400-e29b-41d4-a716-446655440000")
+		r.Header.Set("Authorization", "Bearer "+strings.Repeat("x", 64))
+		w := httptest.NewRecorder()
+		middleware(ok).ServeHTTP(w, r)
+		g.Expect(w.Code).To(Equal(http.StatusBadRequest))
+		g.Expect(w.Body.String()).To(ContainSubstring("must be at most 32 bytes"))
+	})
+
+	t.Run("reports every failing header", func(t *testing.T) {
+		g := NewWithT(t)
+		header := http.Header{}
+		header.Set("X-Request-ID", "nope")
+		header.Set("Authorization", "Basic abc")
+		err := httpvalidate.ValidateHeaders(header,
+			httpvalidate.Header("X-Request-ID", validation.MatchesPattern(`^[0-9a-f-]{36}$`)),
+			httpvalidate.Header("Authorization", validation.StartsWith("Bearer ")),
+		)
+		g.Expect(err).To(MatchError("X-Request-ID: must match pattern \"^[0-9a-f-]{36}$\"\nAuthorization: must start with \"Bearer \""))
+	})
+}
@@ -0,0 +1,23 @@
+// Package httpvalidate integrates protego validation with net/http.
+//
+// It binds and validates request inputs such as query parameters and headers, producing
+// errors that carry the name of the offending parameter.
+//
+// Usage:
+//
+//	import "github.com/quantumcycle/protego/httpvalidate"
+//
+//	func listOrders(w http.ResponseWriter, r *http.Request) {
+//	    var page int
+//	    var status string
+//	    err := httpvalidate.QueryParams(r,
+//	        httpvalidate.Int("page", &page, validation.Min(1)).Default(1),
+//	        httpvalidate.Enum("status", &status, "open", "closed").Required(),
+//	    )
+//	    if err != nil {
+//	        http.Error(w, err.Error(), http.StatusBadRequest)
+//	        return
+//	    }
+//	    ...
+//	}
+package httpvalidate
@@ -0,0 +1,136 @@
+package httpvalidate
+
+import (
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/quantumcycle/protego/validation"
+)
+
+// sniffLen is the number of bytes http.DetectContentType considers.
+const sniffLen = 512
+
+// FileRule validates the file parts uploaded under a single multipart form field.
+type FileRule struct {
+	name      string
+	target    *[]*multipart.FileHeader
+	required  bool
+	maxSize   int64
+	maxCount  int
+	mimeTypes []string
+}
+
+// File creates a rule for the file parts of the named form field.
+// The accepted file headers are stored in the target, which may be nil.
+//
+// Example:
+//
+//	httpvalidate.File("avatar", &avatar).Required().MaxSize(2<<20).MIMETypes("image/png", "image/jpeg")
+func File(name string, target *[]*multipart.FileHeader) FileRule {
+	return FileRule{name: name, target: target}
+}
+
+// Required marks the file field as mandatory.
+func (f FileRule) Required() FileRule {
+	f.required = true
+	return f
+}
+
+// MaxSize limits the size of each file to the given number of bytes.
+func (f FileRule) MaxSize(bytes int64) FileRule {
+	f.maxSize = bytes
+	return f
+}
+
+// MaxCount limits the number of files uploaded under the field.
+func (f FileRule) MaxCount(n int) FileRule {
+	f.maxCount = n
+	return f
+}
+
+// MIMETypes restricts files to the given media types.
+// The type is detected from the file content with http.DetectContentType rather than
+// trusting the Content-Type declared by the client.
+func (f FileRule) MIMETypes(types ...string) FileRule {
+	f.mimeTypes = types
+	return f
+}
+
+func (f FileRule) bind(src source) error {
+	files := src.files[f.name]
+	if len(files) == 0 {
+		if f.required {
+			return validation.NewFieldError(f.name, validation.NewValidationError("required"))
+		}
+		return nil
+	}
+	if f.maxCount > 0 && len(files) > f.maxCount {
+		return validation.NewFieldError(f.name, validation.NewValidationError(fmt.Sprintf("must have at most %d files", f.maxCount)))
+	}
+	for _, file := range files {
+		if err := f.validateFile(file); err != nil {
+			return validation.NewFieldError(f.name, err)
+		}
+	}
+	if f.target != nil {
+		*f.target = files
+	}
+	return nil
+}
+
+func (f FileRule) validateFile(file *multipart.FileHeader) error {
+	if f.maxSize > 0 && file.Size > f.maxSize {
+		return validation.NewValidationError(fmt.Sprintf("%s: must be at most %d bytes", file.Filename, f.maxSize))
+	}
+	if len(f.mimeTypes) == 0 {
+		return nil
+	}
+	mimeType, err := detectContentType(file)
+	if err != nil {
+		return err
+	}
+	if !slices.Contains(f.mimeTypes, mimeType) {
+		return validation.NewValidationError(fmt.Sprintf("%s: must be one of: %v", file.Filename, f.mimeTypes))
+	}
+	return nil
+}
+
+// detectContentType sniffs the media type of the file, without parameters.
+func detectContentType(file *multipart.FileHeader) (string, error) {
+	content, err := file.Open()
+	if err != nil {
+		return "", err
+	}
+	defer content.Close()
+
+	buf := make([]byte, sniffLen)
+	n, err := io.ReadFull(content, buf)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return "", err
+	}
+	mimeType, _, _ := strings.Cut(http.DetectContentType(buf[:n]), ";")
+	return mimeType, nil
+}
+
+// MultipartForm parses a multipart/form-data request and applies the rules.
+// Text fields are bound with the parameter rules (Int, String, ...) and file parts with
+// File rules. maxMemory is passed to http.Request.ParseMultipartForm.
+//
+// Example:
+//
+//	var title string
+//	var attachments []*multipart.FileHeader
+//	err := httpvalidate.MultipartForm(r, 32<<20,
+//	    httpvalidate.String("title", &title, validation.MaxLength(100)).Required(),
+//	    httpvalidate.File("attachments", &attachments).MaxCount(5).MaxSize(10<<20),
+//	)
+func MultipartForm(r *http.Request, maxMemory int64, rules ...Rule) error {
+	if err := r.ParseMultipartForm(maxMemory); err != nil {
+		return validation.NewValidationError("must be a valid multipart/form-data body")
+	}
+	return bindAll(source{values: r.MultipartForm.Value, files: r.MultipartForm.File}, rules)
+}
@@ -0,0 +1,123 @@
+package httpvalidate_test
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/quantumcycle/protego/httpvalidate"
+	"github.com/quantumcycle/protego/validation"
+)
+
+var pngHeader = []byte("\x89PNG\x0D\x0A\x1A\x0A")
+
+type part struct {
+	field    string
+	filename string
+	content  []byte
+}
+
+func newMultipartRequest(t *testing.T, fields map[string]string, files ...part) *http.Request {
+	t.Helper()
+	body := &bytes.Buffer{}
+	mw := multipart.NewWriter(body)
+	for k, v := range fields {
+		if err := mw.WriteField(k, v); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, f := range files {
+		w, err := mw.CreateFormFile(f.field, f.filename)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write(f.content); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := mw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	r := httptest.NewRequest("POST", "/upload", body)
+	r.Header.Set("Content-Type", mw.FormDataContentType())
+	return r
+}
+
+func TestMultipartForm(t *testing.T) {
+
+	t.Run("binds text fields and files", func(t *testing.T) {
+		g := NewWithT(t)
+		r := newMultipartRequest(t, map[string]string{"title": "Holiday", "rating": "4"},
+			part{field: "photo", filename: "beach.png", content: pngHeader},
+		)
+		var title string
+		var rating int
+		var photos []*multipart.FileHeader
+		err := httpvalidate.MultipartForm(r, 1<<20,
+			httpvalidate.String("title", &title).Required(),
+			httpvalidate.Int("rating", &rating, validation.Range(1, 5)),
+			httpvalidate.File("photo", &photos).Required().MIMETypes("image/png"),
+		)
+		g.Expect(err).To(BeNil())
+		g.Expect(title).To(Equal("Holiday"))
+		g.Expect(rating).To(Equal(4))
+		g.Expect(photos).To(HaveLen(1))
+		g.Expect(photos[0].Filename).To(Equal("beach.png"))
+	})
+
+	t.Run("fails when required file is missing", func(t *testing.T) {
+		g := NewWithT(t)
+		r := newMultipartRequest(t, map[string]string{"title": "Holiday"})
+		err := httpvalidate.MultipartForm(r, 1<<20, httpvalidate.File("photo", nil).Required())
+		g.Expect(err).To(MatchError("photo: required"))
+	})
+
+	t.Run("fails when a file is too large", func(t *testing.T) {
+		g := NewWithT(t)
+		r := newMultipartRequest(t, nil, part{field: "doc", filename: "big.txt", content: []byte(strings.Repeat("x", 100))})
+		err := httpvalidate.MultipartForm(r, 1<<20, httpvalidate.File("doc", nil).MaxSize(10))
+		g.Expect(err).To(MatchError("doc: big.txt: must be at most 10 bytes"))
+	})
+
+	t.Run("fails when there are too many files", func(t *testing.T) {
+		g := NewWithT(t)
+		r := newMultipartRequest(t, nil,
+			part{field: "doc", filename: "a.txt", content: []byte("a")},
+			part{field: "doc", filename: "b.txt", content: []byte("b")},
+		)
+		err := httpvalidate.MultipartForm(r, 1<<20, httpvalidate.File("doc", nil).MaxCount(1))
+		g.Expect(err).To(MatchError("doc: must have at most 1 files"))
+	})
+
+	t.Run("detects media type from content", func(t *testing.T) {
+		g := NewWithT(t)
+		r := newMultipartRequest(t, nil, part{field: "photo", filename: "fake.png", content: []byte("plain text")})
+		err := httpvalidate.MultipartForm(r, 1<<20, httpvalidate.File("photo", nil).MIMETypes("image/png"))
+		g.Expect(err).To(MatchError("photo: fake.png: must be one of: [image/png]"))
+	})
+
+	t.Run("reports text field and file errors together", func(t *testing.T) {
+		g := NewWithT(t)
+		r := newMultipartRequest(t, map[string]string{"rating": "9"})
+		var rating int
+		err := httpvalidate.MultipartForm(r, 1<<20,
+			httpvalidate.Int("rating", &rating, validation.Range(1, 5)),
+			httpvalidate.File("photo", nil).Required(),
+		)
+		g.Expect(err).To(MatchError("rating: must be between 1 and 5\nphoto: required"))
+	})
+
+	t.Run("fails when body is not multipart", func(t *testing.T) {
+		g := NewWithT(t)
+		r := httptest.NewRequest("POST", "/upload", strings.NewReader("title=x"))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		err := httpvalidate.MultipartForm(r, 1<<20)
+		g.Expect(err).To(MatchError("must be a valid multipart/form-data body"))
+		g.Expect(validation.IsValidationError(err)).To(BeTrue())
+	})
+}
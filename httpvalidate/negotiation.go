@@ -0,0 +1,199 @@
+package httpvalidate
+
+import (
+	"fmt"
+	"mime"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/quantumcycle/protego/validation"
+)
+
+// The validators of this file check content-negotiation headers. They are meant for Header
+// rules, and can be used standalone on any string.
+//
+//	mux.Handle("POST /orders", httpvalidate.Headers(
+//	    httpvalidate.Header("Content-Type", httpvalidate.IsContentType("application/json")).Required(),
+//	    httpvalidate.Header("Accept", httpvalidate.IsAcceptHeader("application/json")),
+//	)(ordersHandler))
+
+// IsAcceptHeader validates an Accept header: a list of media ranges such as
+// "text/html, application/*;q=0.8, */*;q=0.1". When allowed media types are given, at least
+// one of them must be acceptable, i.e. matched by a range with a non-zero quality.
+//
+// Example:
+//
+//	httpvalidate.Header("Accept", httpvalidate.IsAcceptHeader("application/json", "application/xml"))
+func IsAcceptHeader(allowed ...string) validation.Validator[string] {
+	return func(v string) error {
+		var ranges []string
+		for _, item := range splitList(v) {
+			mediaRange, params, err := mime.ParseMediaType(item)
+			if err != nil || !isMediaRange(mediaRange) || !validQuality(params["q"]) {
+				return validation.NewValidationError(fmt.Sprintf("must be a valid Accept header, %q is not a media range", item))
+			}
+			if params["q"] == "" || quality(params["q"]) > 0 {
+				ranges = append(ranges, mediaRange)
+			}
+		}
+		if len(allowed) == 0 {
+			return nil
+		}
+		for _, a := range allowed {
+			for _, r := range ranges {
+				if mediaRangeMatches(r, strings.ToLower(a)) {
+					return nil
+				}
+			}
+		}
+		return validation.NewValidationError(fmt.Sprintf("must accept one of: %s", strings.Join(allowed, ", ")))
+	}
+}
+
+// IsContentType validates a Content-Type header value such as "application/json; charset=utf-8".
+// When allowed media types are given, the media type, ignoring parameters and case, must be
+// one of them.
+//
+// Example:
+//
+//	httpvalidate.Header("Content-Type", httpvalidate.IsContentType("application/json")).Required()
+func IsContentType(allowed ...string) validation.Validator[string] {
+	return func(v string) error {
+		mediaType, _, err := mime.ParseMediaType(v)
+		if err != nil || strings.Contains(mediaType, "*") || !isMediaRange(mediaType) {
+			return validation.NewValidationError("must be a valid media type")
+		}
+		if len(allowed) > 0 && !slices.ContainsFunc(allowed, func(a string) bool { return strings.EqualFold(a, mediaType) }) {
+			return validation.NewValidationError(fmt.Sprintf("must be one of: %s", strings.Join(allowed, ", ")))
+		}
+		return nil
+	}
+}
+
+// IsCharset validates a charset name such as "utf-8". When allowed charsets are given, the
+// name must be one of them, ignoring case.
+//
+// Example:
+//
+//	validation.Validate(params["charset"], httpvalidate.IsCharset("utf-8", "us-ascii"))
+func IsCharset(allowed ...string) validation.Validator[string] {
+	return func(v string) error {
+		if !isToken(v) {
+			return validation.NewValidationError("must be a valid charset")
+		}
+		if len(allowed) > 0 && !slices.ContainsFunc(allowed, func(a string) bool { return strings.EqualFold(a, v) }) {
+			return validation.NewValidationError(fmt.Sprintf("must be one of: %s", strings.Join(allowed, ", ")))
+		}
+		return nil
+	}
+}
+
+// IsLanguageRange validates an Accept-Language header: a list of language ranges
+// (RFC 4647) with optional qualities, such as "fr-CH, fr;q=0.9, en;q=0.8, *;q=0.5".
+//
+// Example:
+//
+//	httpvalidate.Header("Accept-Language", httpvalidate.IsLanguageRange())
+func IsLanguageRange() validation.Validator[string] {
+	return func(v string) error {
+		items := splitList(v)
+		if len(items) == 0 {
+			return validation.NewValidationError("must be a valid language range")
+		}
+		for _, item := range items {
+			tag, q, _ := strings.Cut(item, ";")
+			tag = strings.TrimSpace(tag)
+			if !isLanguageRange(tag) || (q != "" && !validQualityParam(q)) {
+				return validation.NewValidationError(fmt.Sprintf("must be a valid language range, %q is not", item))
+			}
+		}
+		return nil
+	}
+}
+
+// splitList splits a comma-separated header value, dropping empty elements.
+func splitList(v string) []string {
+	var items []string
+	for _, item := range strings.Split(v, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// isMediaRange reports whether s is type/subtype, where either part may be *, but a
+// type * requires a subtype *.
+func isMediaRange(s string) bool {
+	typ, sub, ok := strings.Cut(s, "/")
+	if !ok || !isToken(typ) || !isToken(sub) {
+		return false
+	}
+	return typ != "*" || sub == "*"
+}
+
+// mediaRangeMatches reports whether the media range r matches the media type t.
+func mediaRangeMatches(r, t string) bool {
+	if r == "*/*" || r == t {
+		return true
+	}
+	typ, sub, _ := strings.Cut(r, "/")
+	return sub == "*" && strings.HasPrefix(t, typ+"/")
+}
+
+// validQualityParam reports whether s is a " q=value" parameter with a valid quality.
+func validQualityParam(s string) bool {
+	name, value, ok := strings.Cut(strings.TrimSpace(s), "=")
+	return ok && strings.TrimSpace(name) == "q" && value != "" && validQuality(strings.TrimSpace(value))
+}
+
+// validQuality reports whether q is empty or a quality value between 0 and 1 with at most
+// three decimals (RFC 9110, section 12.4.2).
+func validQuality(q string) bool {
+	if q == "" {
+		return true
+	}
+	intPart, decimals, _ := strings.Cut(q, ".")
+	if (intPart != "0" && intPart != "1") || len(decimals) > 3 || strings.Trim(decimals, "0123456789") != "" {
+		return false
+	}
+	return intPart == "0" || strings.Trim(decimals, "0") == ""
+}
+
+func quality(q string) float64 {
+	v, _ := strconv.ParseFloat(q, 64)
+	return v
+}
+
+// isLanguageRange reports whether s is * or 1*8ALPHA *("-" 1*8alphanum).
+func isLanguageRange(s string) bool {
+	if s == "*" {
+		return true
+	}
+	for i, part := range strings.Split(s, "-") {
+		if len(part) == 0 || len(part) > 8 {
+			return false
+		}
+		for _, c := range part {
+			alpha := c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+			if !alpha && (i == 0 || c < '0' || c > '9') {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// isToken reports whether s is a non-empty HTTP token (RFC 9110, section 5.6.2).
+func isToken(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, c := range s {
+		if c > 0x7e || c <= ' ' || strings.ContainsRune(`"(),/:;<=>?@[\]{}`, c) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,71 @@
+package httpvalidate_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/quantumcycle/protego/httpvalidate"
+	"github.com/quantumcycle/protego/validation"
+)
+
+func TestIsAcceptHeader(t *testing.T) {
+
+	t.Run("passes with valid media ranges", func(t *testing.T) {
+		g := NewWithT(t)
+		for _, v := range []string{"*/*", "application/json", "text/html, application/*;q=0.8, */*;q=0.1", "text/plain; charset=utf-8"} {
+			g.Expect(validation.Validate(v, httpvalidate.IsAcceptHeader())).To(Succeed(), v)
+		}
+	})
+
+	t.Run("fails with invalid media ranges", func(t *testing.T) {
+		g := NewWithT(t)
+		for _, v := range []string{"json", "*/json", "text/html;q=2", "text/html;q=0.1234", "application/json;q=abc"} {
+			g.Expect(validation.Validate(v, httpvalidate.IsAcceptHeader())).To(MatchError(ContainSubstring("must be a valid Accept header")), v)
+		}
+	})
+
+	t.Run("requires an allowed media type to be acceptable", func(t *testing.T) {
+		g := NewWithT(t)
+		v := httpvalidate.IsAcceptHeader("application/json")
+		g.Expect(validation.Validate("application/*", v)).To(Succeed())
+		g.Expect(validation.Validate("text/html, */*;q=0.1", v)).To(Succeed())
+		g.Expect(validation.Validate("text/html", v)).To(MatchError("must accept one of: application/json"))
+		g.Expect(validation.Validate("text/html, application/json;q=0", v)).To(MatchError("must accept one of: application/json"))
+	})
+}
+
+func TestIsContentType(t *testing.T) {
+	g := NewWithT(t)
+	v := httpvalidate.IsContentType("application/json")
+	g.Expect(validation.Validate("application/json", v)).To(Succeed())
+	g.Expect(validation.Validate("Application/JSON; charset=utf-8", v)).To(Succeed())
+	g.Expect(validation.Validate("text/plain", v)).To(MatchError("must be one of: application/json"))
+	g.Expect(validation.Validate("application/*", v)).To(MatchError("must be a valid media type"))
+	g.Expect(validation.Validate("json", httpvalidate.IsContentType())).To(MatchError("must be a valid media type"))
+}
+
+func TestIsCharset(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(validation.Validate("ISO-8859-1", httpvalidate.IsCharset())).To(Succeed())
+	g.Expect(validation.Validate("UTF-8", httpvalidate.IsCharset("utf-8"))).To(Succeed())
+	g.Expect(validation.Validate("latin1", httpvalidate.IsCharset("utf-8"))).To(MatchError("must be one of: utf-8"))
+	g.Expect(validation.Validate("utf 8", httpvalidate.IsCharset())).To(MatchError("must be a valid charset"))
+}
+
+func TestIsLanguageRange(t *testing.T) {
+
+	t.Run("passes with valid language ranges", func(t *testing.T) {
+		g := NewWithT(t)
+		for _, v := range []string{"en", "fr-CH, fr;q=0.9, en;q=0.8, *;q=0.5", "zh-Hant-TW", "de-1996"} {
+			g.Expect(validation.Validate(v, httpvalidate.IsLanguageRange())).To(Succeed(), v)
+		}
+	})
+
+	t.Run("fails with invalid language ranges", func(t *testing.T) {
+		g := NewWithT(t)
+		for _, v := range []string{"", "en_US", "1996", "en;q=1.5", "en;level=1", "toolongtag"} {
+			g.Expect(validation.Validate(v, httpvalidate.IsLanguageRange())).To(MatchError(ContainSubstring("must be a valid language range")), v)
+		}
+	})
+}
@@ -0,0 +1,169 @@
+package httpvalidate
+
+import (
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/quantumcycle/protego/validation"
+)
+
+// Rule binds and validates a single request parameter.
+type Rule interface {
+	bind(src source) error
+}
+
+// source holds the request inputs rules are bound from.
+type source struct {
+	values url.Values
+	files  map[string][]*multipart.FileHeader
+}
+
+// Param is a typed parameter rule created by Int, Bool, Time, String or Enum.
+// The parsed value is stored in the target and then checked with the validators.
+type Param[T any] struct {
+	name       string
+	target     *T
+	parse      func(string) (T, error)
+	required   bool
+	hasDefault bool
+	def        T
+	validators []validation.Validator[T]
+}
+
+// Required marks the parameter as mandatory.
+func (p Param[T]) Required() Param[T] {
+	p.required = true
+	return p
+}
+
+// Default sets the value stored in the target when the parameter is absent.
+// Validators are not applied to default values.
+func (p Param[T]) Default(value T) Param[T] {
+	p.hasDefault = true
+	p.def = value
+	return p
+}
+
+func (p Param[T]) bind(src source) error {
+	raw, ok := src.values[p.name]
+	if !ok || len(raw) == 0 || raw[0] == "" {
+		if p.required {
+			return validation.NewFieldError(p.name, validation.NewValidationError("required"))
+		}
+		if p.hasDefault {
+			*p.target = p.def
+		}
+		return nil
+	}
+	v, err := p.parse(raw[0])
+	if err != nil {
+		return validation.NewFieldError(p.name, err)
+	}
+	*p.target = v
+	return validation.NewFieldError(p.name, validation.Validate(v, p.validators...))
+}
+
+// Int creates a rule for an integer parameter.
+//
+// Example:
+//
+//	httpvalidate.Int("page", &page, validation.Min(1)).Default(1)
+func Int(name string, target *int, validators ...validation.Validator[int]) Param[int] {
+	return Param[int]{name: name, target: target, parse: parseInt, validators: validators}
+}
+
+// Bool creates a rule for a boolean parameter.
+// Accepted values are those understood by strconv.ParseBool (1, t, true, 0, f, false...).
+//
+// Example:
+//
+//	httpvalidate.Bool("archived", &archived)
+func Bool(name string, target *bool, validators ...validation.Validator[bool]) Param[bool] {
+	return Param[bool]{name: name, target: target, parse: parseBool, validators: validators}
+}
+
+// Time creates a rule for a time parameter parsed with the given layout.
+//
+// Example:
+//
+//	httpvalidate.Time("since", time.RFC3339, &since, validation.IsPastTime())
+func Time(name, layout string, target *time.Time, validators ...validation.Validator[time.Time]) Param[time.Time] {
+	parse := func(s string) (time.Time, error) {
+		t, err := time.Parse(layout, s)
+		if err != nil {
+			return time.Time{}, validation.NewValidationError(fmt.Sprintf("must match date format %q", layout))
+		}
+		return t, nil
+	}
+	return Param[time.Time]{name: name, target: target, parse: parse, validators: validators}
+}
+
+// String creates a rule for a free-form string parameter.
+//
+// Example:
+//
+//	httpvalidate.String("q", &query, validation.MaxLength(100))
+func String(name string, target *string, validators ...validation.Validator[string]) Param[string] {
+	return Param[string]{name: name, target: target, parse: parseString, validators: validators}
+}
+
+// Enum creates a rule for a string parameter restricted to the allowed values.
+//
+// Example:
+//
+//	httpvalidate.Enum("sort", &sort, "asc", "desc").Default("asc")
+func Enum(name string, target *string, allowed ...string) Param[string] {
+	return String(name, target, validation.In(false, allowed...))
+}
+
+// QueryParams binds and validates the query parameters of a request.
+// Every rule is applied and all failures are returned joined, each attributed to its
+// parameter name (see validation.FieldError).
+//
+// Example:
+//
+//	err := httpvalidate.QueryParams(r,
+//	    httpvalidate.Int("limit", &limit, validation.Range(1, 100)).Default(20),
+//	    httpvalidate.Bool("archived", &archived),
+//	)
+func QueryParams(r *http.Request, rules ...Rule) error {
+	return Values(r.URL.Query(), rules...)
+}
+
+// Values binds and validates parameters from url.Values, such as a parsed form body.
+func Values(values url.Values, rules ...Rule) error {
+	return bindAll(source{values: values}, rules)
+}
+
+func bindAll(src source, rules []Rule) error {
+	errs := make([]error, 0, len(rules))
+	for _, rule := range rules {
+		errs = append(errs, rule.bind(src))
+	}
+	return errors.Join(errs...)
+}
+
+func parseInt(s string) (int, error) {
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, validation.NewValidationError("must be a valid integer")
+	}
+	return v, nil
+}
+
+func parseBool(s string) (bool, error) {
+	v, err := strconv.ParseBool(s)
+	if err != nil {
+		return false, validation.NewValidationError("must be a valid boolean")
+	}
+	return v, nil
+}
+
+func parseString(s string) (string, error) {
+	return s, nil
+}
@@ -0,0 +1,94 @@
+package httpvalidate_test
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/quantumcycle/protego/httpvalidate"
+	"github.com/quantumcycle/protego/validation"
+)
+
+func TestQueryParams(t *testing.T) {
+
+	t.Run("binds typed parameters", func(t *testing.T) {
+		g := NewWithT(t)
+		r := httptest.NewRequest("GET", "/orders?page=2&archived=true&since=2024-01-02&status=open", nil)
+		var page int
+		var archived bool
+		var since time.Time
+		var status string
+		err := httpvalidate.QueryParams(r,
+			httpvalidate.Int("page", &page, validation.Min(1)),
+			httpvalidate.Bool("archived", &archived),
+			httpvalidate.Time("since", "2006-01-02", &since),
+			httpvalidate.Enum("status", &status, "open", "closed"),
+		)
+		g.Expect(err).To(BeNil())
+		g.Expect(page).To(Equal(2))
+		g.Expect(archived).To(BeTrue())
+		g.Expect(since).To(Equal(time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)))
+		g.Expect(status).To(Equal("open"))
+	})
+
+	t.Run("applies defaults to absent parameters", func(t *testing.T) {
+		g := NewWithT(t)
+		r := httptest.NewRequest("GET", "/orders", nil)
+		var page int
+		var sort string
+		err := httpvalidate.QueryParams(r,
+			httpvalidate.Int("page", &page, validation.Min(1)).Default(1),
+			httpvalidate.Enum("sort", &sort, "asc", "desc").Default("asc"),
+		)
+		g.Expect(err).To(BeNil())
+		g.Expect(page).To(Equal(1))
+		g.Expect(sort).To(Equal("asc"))
+	})
+
+	t.Run("fails when required parameter is missing", func(t *testing.T) {
+		g := NewWithT(t)
+		r := httptest.NewRequest("GET", "/orders", nil)
+		var status string
+		err := httpvalidate.QueryParams(r, httpvalidate.String("status", &status).Required())
+		g.Expect(err).To(MatchError("status: required"))
+	})
+
+	t.Run("reports coercion errors with the parameter name", func(t *testing.T) {
+		g := NewWithT(t)
+		r := httptest.NewRequest("GET", "/orders?page=abc&archived=maybe", nil)
+		var page int
+		var archived bool
+		err := httpvalidate.QueryParams(r,
+			httpvalidate.Int("page", &page),
+			httpvalidate.Bool("archived", &archived),
+		)
+		g.Expect(err).To(MatchError("page: must be a valid integer\narchived: must be a valid boolean"))
+		g.Expect(validation.IsValidationError(err)).To(BeTrue())
+	})
+
+	t.Run("reports validator errors with the parameter name", func(t *testing.T) {
+		g := NewWithT(t)
+		r := httptest.NewRequest("GET", "/orders?limit=500&status=pending", nil)
+		var limit int
+		var status string
+		err := httpvalidate.QueryParams(r,
+			httpvalidate.Int("limit", &limit, validation.Range(1, 100)),
+			httpvalidate.Enum("status", &status, "open", "closed"),
+		)
+		var fieldErr *validation.FieldError
+		g.Expect(errors.As(err, &fieldErr)).To(BeTrue())
+		g.Expect(fieldErr.Field()).To(Equal("limit"))
+		g.Expect(err).To(MatchError("limit: must be between 1 and 100\nstatus: must be one of: [open closed]"))
+	})
+
+	t.Run("reports invalid time layout", func(t *testing.T) {
+		g := NewWithT(t)
+		r := httptest.NewRequest("GET", "/orders?since=yesterday", nil)
+		var since time.Time
+		err := httpvalidate.QueryParams(r, httpvalidate.Time("since", time.RFC3339, &since))
+		g.Expect(err).To(MatchError(ContainSubstring("since: must match date format")))
+	})
+}
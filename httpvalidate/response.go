@@ -0,0 +1,84 @@
+package httpvalidate
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/quantumcycle/protego/validation"
+)
+
+// ErrorResponse is the JSON body written by WriteError, and by JSONErrors.
+type ErrorResponse struct {
+	Errors []ErrorDetail `json:"errors"`
+}
+
+// ErrorDetail describes a single validation failure.
+// Field is empty for failures that are not attributed to a field.
+type ErrorDetail struct {
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
+
+// WriteError writes err as a JSON response, or with the first encoder set with
+// SetErrorEncoders. Use WriteErrorFor to select the encoder by the Accept header.
+// Validation errors produce a 400 Bad Request listing every failure with its field.
+// Errors containing a system error (see validation.IsSystemError), even joined with
+// validation errors, produce a 500 Internal Server Error without exposing their message.
+//
+// Example:
+//
+//	if err := input.Validate(); err != nil {
+//	    httpvalidate.WriteError(w, err)
+//	    return
+//	}
+func WriteError(w http.ResponseWriter, err error) {
+	writeError(w, negotiateErrorEncoder(""), err)
+}
+
+// WriteErrorFor is like WriteError, writing the response with the encoder preferred by the
+// Accept header of r (see SetErrorEncoders).
+//
+// Example:
+//
+//	if err := input.Validate(); err != nil {
+//	    httpvalidate.WriteErrorFor(w, r, err)
+//	    return
+//	}
+func WriteErrorFor(w http.ResponseWriter, r *http.Request, err error) {
+	writeError(w, negotiateErrorEncoder(r.Header.Get("Accept")), err)
+}
+
+func writeError(w http.ResponseWriter, encoder ErrorEncoder, err error) {
+	status := http.StatusBadRequest
+	if validation.IsSystemError(err) {
+		status = http.StatusInternalServerError
+		err = errors.New(http.StatusText(status))
+	}
+	w.Header().Set("Content-Type", encoder.MediaType)
+	w.WriteHeader(status)
+	_ = encoder.Encode(w, status, err)
+}
+
+// details flattens joined and field-attributed errors into a list of details,
+// joining nested field names with dots and appending indexes as is ("items[2].sku").
+func details(err error, field string) []ErrorDetail {
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		var out []ErrorDetail
+		for _, e := range joined.Unwrap() {
+			out = append(out, details(e, field)...)
+		}
+		return out
+	}
+	if fieldErr, ok := err.(*validation.FieldError); ok {
+		return details(fieldErr.Unwrap(), joinField(field, fieldErr.Field()))
+	}
+	return []ErrorDetail{{Field: field, Message: err.Error()}}
+}
+
+func joinField(parent, child string) string {
+	if parent == "" || strings.HasPrefix(child, "[") {
+		return parent + child
+	}
+	return parent + "." + child
+}
@@ -0,0 +1,54 @@
+package httpvalidate_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/quantumcycle/protego/httpvalidate"
+	"github.com/quantumcycle/protego/validation"
+)
+
+func TestWriteError(t *testing.T) {
+
+	t.Run("writes validation errors as 400 with field details", func(t *testing.T) {
+		g := NewWithT(t)
+		err := errors.Join(
+			validation.NewFieldError("address", validation.NewFieldError("city", validation.NewValidationError("required"))),
+			validation.NewValidationError("invalid payload"),
+		)
+		w := httptest.NewRecorder()
+		httpvalidate.WriteError(w, err)
+		g.Expect(w.Code).To(Equal(http.StatusBadRequest))
+
+		var body httpvalidate.ErrorResponse
+		g.Expect(json.Unmarshal(w.Body.Bytes(), &body)).To(Succeed())
+		g.Expect(body.Errors).To(Equal([]httpvalidate.ErrorDetail{
+			{Field: "address.city", Message: "required"},
+			{Message: "invalid payload"},
+		}))
+	})
+
+	t.Run("writes other errors as 500 without details", func(t *testing.T) {
+		g := NewWithT(t)
+		w := httptest.NewRecorder()
+		httpvalidate.WriteError(w, errors.New("connection refused"))
+		g.Expect(w.Code).To(Equal(http.StatusInternalServerError))
+		g.Expect(w.Body.String()).NotTo(ContainSubstring("connection refused"))
+	})
+
+	t.Run("writes system errors joined with validation errors as 500", func(t *testing.T) {
+		g := NewWithT(t)
+		err := errors.Join(
+			validation.NewFieldError("name", validation.NewValidationError("required")),
+			validation.NewFieldError("email", errors.New("connection refused")),
+		)
+		w := httptest.NewRecorder()
+		httpvalidate.WriteError(w, err)
+		g.Expect(w.Code).To(Equal(http.StatusInternalServerError))
+	})
+}
@@ -0,0 +1,231 @@
+// Package identity validates identity-provider configuration entered by customers setting
+// up single sign-on: OpenID Connect issuers and SAML 2.0 metadata.
+//
+//	err := validation.ValidateWithTimeout(ctx, 5*time.Second, input.Issuer, identity.IsOIDCIssuer(nil))
+//
+//	err := validation.Validate(input.Metadata, identity.IsSAMLMetadataXML())
+package identity
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/quantumcycle/protego/validation"
+)
+
+// maxDocumentSize bounds the size of the documents read from identity providers.
+const maxDocumentSize = 1 << 20
+
+// IsOIDCIssuer validates an OpenID Connect issuer URL by fetching its discovery document,
+// "/.well-known/openid-configuration" (OpenID Connect Discovery 1.0): the issuer must be an
+// https URL without query or fragment, its document must be JSON declaring the issuer
+// itself, and list the metadata relying parties require, such as the authorization
+// endpoint and the JWKS URI.
+//
+// The issuer is entered by customers, so fetching it must not reach the network of the
+// server: a nil client means one that times out after 10 seconds, ignores proxies set in
+// the environment and refuses to connect to the special-purpose addresses of IANA, such as
+// loopback, private, carrier-grade NAT, link-local and NAT64 ones, also when redirected,
+// reporting them as a validation error. A client passed instead must guard against such
+// requests itself, for instance by sending them through an egress proxy. Whatever the
+// client, redirects to URLs that are not https are not followed.
+//
+// An issuer answering with a client error, or a document that does not comply, is a
+// validation error. Transport errors and server errors are returned as is: they are system
+// errors (see validation.IsSystemError), to be retried or answered with a 5xx status.
+//
+// Example:
+//
+//	err := validation.ValidateWithTimeout(ctx, 5*time.Second, input.Issuer, identity.IsOIDCIssuer(nil))
+func IsOIDCIssuer(client *http.Client) validation.ValidatorCtx[string] {
+	if client == nil {
+		client = publicClient
+	}
+	client = httpsOnly(client)
+	return func(ctx context.Context, v string) error {
+		u, err := url.Parse(v)
+		if err != nil || u.Scheme != "https" || u.Host == "" || u.RawQuery != "" || u.Fragment != "" {
+			return validation.NewValidationError("must be an https URL without query or fragment")
+		}
+
+		endpoint := strings.TrimSuffix(v, "/") + "/.well-known/openid-configuration"
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Accept", "application/json")
+		resp, err := client.Do(req)
+		if errors.Is(err, errNonPublicAddress) {
+			return validation.NewValidationError("must resolve to a public address")
+		}
+		if errors.Is(err, errInsecureRedirect) {
+			return validation.NewValidationError("must not redirect to a URL that is not https")
+		}
+		if err != nil {
+			return fmt.Errorf("fetching OpenID Connect discovery document: %w", err)
+		}
+		defer resp.Body.Close()
+		switch {
+		case resp.StatusCode >= 500:
+			return fmt.Errorf("fetching OpenID Connect discovery document: %s", resp.Status)
+		case resp.StatusCode != http.StatusOK:
+			return validation.NewValidationError(fmt.Sprintf("must serve an OpenID Connect discovery document, got %s", resp.Status))
+		}
+
+		var doc discoveryDocument
+		body, err := io.ReadAll(io.LimitReader(resp.Body, maxDocumentSize+1))
+		if err != nil {
+			return fmt.Errorf("reading OpenID Connect discovery document: %w", err)
+		}
+		if len(body) > maxDocumentSize || json.Unmarshal(body, &doc) != nil {
+			return validation.NewValidationError("must serve a JSON OpenID Connect discovery document")
+		}
+		return doc.validate(v)
+	}
+}
+
+// errInsecureRedirect is the error of a client of IsOIDCIssuer redirected to a URL that is
+// not https.
+var errInsecureRedirect = errors.New("redirect to a URL that is not https")
+
+// httpsOnly returns a copy of client refusing redirects to URLs that are not https, before
+// applying the redirect policy of client.
+func httpsOnly(client *http.Client) *http.Client {
+	c := *client
+	c.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if req.URL.Scheme != "https" {
+			return errInsecureRedirect
+		}
+		if client.CheckRedirect != nil {
+			return client.CheckRedirect(req, via)
+		}
+		if len(via) >= 10 {
+			return errors.New("stopped after 10 redirects")
+		}
+		return nil
+	}
+	return &c
+}
+
+// errNonPublicAddress is the error of publicClient connecting to an address that is not
+// public.
+var errNonPublicAddress = errors.New("address is not public")
+
+// publicClient is the client of IsOIDCIssuer when none is passed, connecting to public
+// addresses only.
+var publicClient = &http.Client{
+	Timeout: 10 * time.Second,
+	Transport: &http.Transport{
+		DialContext: (&net.Dialer{
+			Timeout: 5 * time.Second,
+			Control: refuseNonPublic,
+		}).DialContext,
+		TLSHandshakeTimeout:   5 * time.Second,
+		ResponseHeaderTimeout: 5 * time.Second,
+		MaxIdleConns:          10,
+		IdleConnTimeout:       90 * time.Second,
+	},
+}
+
+// specialPurposePrefixes are the address blocks of the IANA IPv4 and IPv6 Special-Purpose
+// Address Registries, and multicast, that do not reach the public internet or may reach
+// the network of the server, such as 100.100.100.200, the metadata service of some clouds,
+// or 64:ff9b::a9fe:a9fe, 169.254.169.254 through NAT64.
+var specialPurposePrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),       // this network
+	netip.MustParsePrefix("10.0.0.0/8"),      // private
+	netip.MustParsePrefix("100.64.0.0/10"),   // carrier-grade NAT
+	netip.MustParsePrefix("127.0.0.0/8"),     // loopback
+	netip.MustParsePrefix("169.254.0.0/16"),  // link-local
+	netip.MustParsePrefix("172.16.0.0/12"),   // private
+	netip.MustParsePrefix("192.0.0.0/24"),    // IETF protocol assignments
+	netip.MustParsePrefix("192.0.2.0/24"),    // documentation
+	netip.MustParsePrefix("192.88.99.0/24"),  // 6to4 relay anycast
+	netip.MustParsePrefix("192.168.0.0/16"),  // private
+	netip.MustParsePrefix("198.18.0.0/15"),   // benchmarking
+	netip.MustParsePrefix("198.51.100.0/24"), // documentation
+	netip.MustParsePrefix("203.0.113.0/24"),  // documentation
+	netip.MustParsePrefix("224.0.0.0/4"),     // multicast
+	netip.MustParsePrefix("240.0.0.0/4"),     // reserved and broadcast
+	netip.MustParsePrefix("::/128"),          // unspecified
+	netip.MustParsePrefix("::1/128"),         // loopback
+	netip.MustParsePrefix("64:ff9b::/96"),    // NAT64
+	netip.MustParsePrefix("64:ff9b:1::/48"),  // local NAT64
+	netip.MustParsePrefix("100::/64"),        // discard
+	netip.MustParsePrefix("2001::/23"),       // IETF protocol assignments, such as Teredo
+	netip.MustParsePrefix("2001:db8::/32"),   // documentation
+	netip.MustParsePrefix("2002::/16"),       // 6to4
+	netip.MustParsePrefix("fc00::/7"),        // unique local
+	netip.MustParsePrefix("fe80::/10"),       // link-local
+	netip.MustParsePrefix("ff00::/8"),        // multicast
+}
+
+// refuseNonPublic refuses connections to special-purpose addresses (see
+// specialPurposePrefixes). It runs once the host is resolved, so names resolving to such
+// addresses are refused as well.
+func refuseNonPublic(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return err
+	}
+	addr = addr.Unmap()
+	for _, prefix := range specialPurposePrefixes {
+		if prefix.Contains(addr) {
+			return fmt.Errorf("connecting to %s: %w", addr, errNonPublicAddress)
+		}
+	}
+	return nil
+}
+
+// discoveryDocument holds the metadata of an OpenID provider checked by IsOIDCIssuer.
+type discoveryDocument struct {
+	Issuer                           string   `json:"issuer"`
+	AuthorizationEndpoint            string   `json:"authorization_endpoint"`
+	JWKSURI                          string   `json:"jwks_uri"`
+	ResponseTypesSupported           []string `json:"response_types_supported"`
+	SubjectTypesSupported            []string `json:"subject_types_supported"`
+	IDTokenSigningAlgValuesSupported []string `json:"id_token_signing_alg_values_supported"`
+}
+
+// validate checks the document of issuer, reporting the first problem.
+func (d discoveryDocument) validate(issuer string) error {
+	// the issuer must match exactly, or tokens would fail validation (section 4.3)
+	if d.Issuer != issuer {
+		return validation.NewValidationError(fmt.Sprintf("must match the issuer of its discovery document, %q", d.Issuer))
+	}
+	for _, endpoint := range []struct{ name, value string }{
+		{"authorization_endpoint", d.AuthorizationEndpoint},
+		{"jwks_uri", d.JWKSURI},
+	} {
+		if u, err := url.Parse(endpoint.value); err != nil || u.Scheme != "https" || u.Host == "" {
+			return validation.NewValidationError(fmt.Sprintf("discovery document must declare an https %s", endpoint.name))
+		}
+	}
+	for _, list := range []struct {
+		name   string
+		values []string
+	}{
+		{"response_types_supported", d.ResponseTypesSupported},
+		{"subject_types_supported", d.SubjectTypesSupported},
+		{"id_token_signing_alg_values_supported", d.IDTokenSigningAlgValuesSupported},
+	} {
+		if len(list.values) == 0 {
+			return validation.NewValidationError(fmt.Sprintf("discovery document must declare %s", list.name))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,146 @@
+package identity_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/quantumcycle/protego/identity"
+	"github.com/quantumcycle/protego/validation"
+)
+
+// newIssuer starts an OpenID provider serving the discovery document returned by document,
+// which receives the issuer URL.
+func newIssuer(t *testing.T, status int, document func(issuer string) map[string]any) *httptest.Server {
+	var server *httptest.Server
+	server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/.well-known/openid-configuration" {
+			http.NotFound(w, r)
+			return
+		}
+		w.WriteHeader(status)
+		_ = json.NewEncoder(w).Encode(document(server.URL))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func validDocument(issuer string) map[string]any {
+	return map[string]any{
+		"issuer":                                issuer,
+		"authorization_endpoint":                issuer + "/authorize",
+		"token_endpoint":                        issuer + "/token",
+		"jwks_uri":                              issuer + "/jwks",
+		"response_types_supported":              []string{"code"},
+		"subject_types_supported":               []string{"public"},
+		"id_token_signing_alg_values_supported": []string{"RS256"},
+	}
+}
+
+func TestIsOIDCIssuer(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("accepts compliant issuers", func(t *testing.T) {
+		g := NewWithT(t)
+		server := newIssuer(t, http.StatusOK, validDocument)
+		g.Expect(identity.IsOIDCIssuer(server.Client())(ctx, server.URL)).To(Succeed())
+	})
+
+	t.Run("rejects URLs that are not https", func(t *testing.T) {
+		g := NewWithT(t)
+		for _, issuer := range []string{"http://idp.example.com", "idp.example.com", "https://idp.example.com?tenant=1", "https://idp.example.com#x"} {
+			err := identity.IsOIDCIssuer(nil)(ctx, issuer)
+			g.Expect(err).To(MatchError("must be an https URL without query or fragment"), issuer)
+		}
+	})
+
+	t.Run("refuses non-public addresses by default", func(t *testing.T) {
+		g := NewWithT(t)
+		server := newIssuer(t, http.StatusOK, validDocument)
+		err := identity.IsOIDCIssuer(nil)(ctx, server.URL)
+		g.Expect(err).To(MatchError("must resolve to a public address"))
+		g.Expect(validation.IsValidationError(err)).To(BeTrue())
+
+		for _, issuer := range []string{"https://localhost", "https://10.0.0.1", "https://169.254.169.254", "https://[::1]", "https://[::ffff:192.168.1.1]", "https://0.0.0.0",
+			"https://0.1.2.3", "https://100.100.100.200", "https://100.64.0.1", "https://198.18.0.1", "https://[64:ff9b::a9fe:a9fe]", "https://[fd00::1]",
+		} {
+			g.Expect(identity.IsOIDCIssuer(nil)(ctx, issuer)).To(MatchError("must resolve to a public address"), issuer)
+		}
+	})
+
+	t.Run("refuses redirects to URLs that are not https", func(t *testing.T) {
+		g := NewWithT(t)
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Redirect(w, r, "http://idp.example.com/.well-known/openid-configuration", http.StatusFound)
+		}))
+		defer server.Close()
+		err := identity.IsOIDCIssuer(server.Client())(ctx, server.URL)
+		g.Expect(err).To(MatchError("must not redirect to a URL that is not https"))
+		g.Expect(validation.IsValidationError(err)).To(BeTrue())
+	})
+
+	t.Run("rejects issuers without discovery document", func(t *testing.T) {
+		g := NewWithT(t)
+		server := newIssuer(t, http.StatusOK, validDocument)
+		err := identity.IsOIDCIssuer(server.Client())(ctx, server.URL+"/tenant")
+		g.Expect(err).To(MatchError("must serve an OpenID Connect discovery document, got 404 Not Found"))
+		g.Expect(validation.IsValidationError(err)).To(BeTrue())
+	})
+
+	t.Run("rejects documents of another issuer", func(t *testing.T) {
+		g := NewWithT(t)
+		server := newIssuer(t, http.StatusOK, func(issuer string) map[string]any {
+			doc := validDocument(issuer)
+			doc["issuer"] = "https://other.example.com"
+			return doc
+		})
+		err := identity.IsOIDCIssuer(server.Client())(ctx, server.URL)
+		g.Expect(err).To(MatchError(`must match the issuer of its discovery document, "https://other.example.com"`))
+	})
+
+	t.Run("rejects documents missing required metadata", func(t *testing.T) {
+		g := NewWithT(t)
+		server := newIssuer(t, http.StatusOK, func(issuer string) map[string]any {
+			doc := validDocument(issuer)
+			delete(doc, "id_token_signing_alg_values_supported")
+			return doc
+		})
+		err := identity.IsOIDCIssuer(server.Client())(ctx, server.URL)
+		g.Expect(err).To(MatchError("discovery document must declare id_token_signing_alg_values_supported"))
+
+		server = newIssuer(t, http.StatusOK, func(issuer string) map[string]any {
+			doc := validDocument(issuer)
+			doc["jwks_uri"] = "http://insecure.example.com/jwks"
+			return doc
+		})
+		err = identity.IsOIDCIssuer(server.Client())(ctx, server.URL)
+		g.Expect(err).To(MatchError("discovery document must declare an https jwks_uri"))
+	})
+
+	t.Run("rejects documents that are not JSON", func(t *testing.T) {
+		g := NewWithT(t)
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = w.Write([]byte("<html></html>"))
+		}))
+		defer server.Close()
+		err := identity.IsOIDCIssuer(server.Client())(ctx, server.URL)
+		g.Expect(err).To(MatchError("must serve a JSON OpenID Connect discovery document"))
+	})
+
+	t.Run("returns server and transport errors as system errors", func(t *testing.T) {
+		g := NewWithT(t)
+		server := newIssuer(t, http.StatusBadGateway, validDocument)
+		err := identity.IsOIDCIssuer(server.Client())(ctx, server.URL)
+		g.Expect(err).To(MatchError("fetching OpenID Connect discovery document: 502 Bad Gateway"))
+		g.Expect(validation.IsSystemError(err)).To(BeTrue())
+
+		server.Close()
+		err = identity.IsOIDCIssuer(server.Client())(ctx, server.URL)
+		g.Expect(err).To(HaveOccurred())
+		g.Expect(validation.IsSystemError(err)).To(BeTrue())
+	})
+}
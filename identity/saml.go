@@ -0,0 +1,120 @@
+package identity
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/xml"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/quantumcycle/protego/validation"
+)
+
+// samlProtocolNS identifies the SAML 2.0 protocol in protocolSupportEnumeration.
+const samlProtocolNS = "urn:oasis:names:tc:SAML:2.0:protocol"
+
+type entityDescriptor struct {
+	XMLName xml.Name        `xml:"urn:oasis:names:tc:SAML:2.0:metadata EntityDescriptor"`
+	ID      string          `xml:"entityID,attr"`
+	IDPs    []idpDescriptor `xml:"urn:oasis:names:tc:SAML:2.0:metadata IDPSSODescriptor"`
+}
+
+type idpDescriptor struct {
+	Protocols string          `xml:"protocolSupportEnumeration,attr"`
+	Keys      []keyDescriptor `xml:"urn:oasis:names:tc:SAML:2.0:metadata KeyDescriptor"`
+	Services  []endpoint      `xml:"urn:oasis:names:tc:SAML:2.0:metadata SingleSignOnService"`
+}
+
+type keyDescriptor struct {
+	Use          string   `xml:"use,attr"`
+	Certificates []string `xml:"http://www.w3.org/2000/09/xmldsig# KeyInfo>X509Data>X509Certificate"`
+}
+
+type endpoint struct {
+	Binding  string `xml:"Binding,attr"`
+	Location string `xml:"Location,attr"`
+}
+
+// IsSAMLMetadataXML validates the structure of the SAML 2.0 metadata of an identity
+// provider, as exported by the provider and pasted or uploaded by customers: an
+// EntityDescriptor with an entityID and an IDPSSODescriptor supporting the SAML 2.0
+// protocol, with at least one single sign-on service at an https location and one signing
+// certificate. The signature of the metadata is not verified. Documents with a DOCTYPE are
+// rejected, as entity declarations are an attack vector on XML parsers.
+//
+// Example:
+//
+//	validation.Validate(input.Metadata, identity.IsSAMLMetadataXML())
+func IsSAMLMetadataXML() validation.Validator[string] {
+	return func(v string) error {
+		if len(v) > maxDocumentSize {
+			return validation.NewValidationError("must not be larger than 1 MiB")
+		}
+		if hasDoctype(v) {
+			return validation.NewValidationError("must not have a DOCTYPE")
+		}
+		var entity entityDescriptor
+		if err := xml.Unmarshal([]byte(v), &entity); err != nil {
+			return validation.NewValidationError("must be a SAML 2.0 EntityDescriptor")
+		}
+		if strings.TrimSpace(entity.ID) == "" {
+			return validation.NewValidationError("must have an entityID")
+		}
+		i := slices.IndexFunc(entity.IDPs, func(idp idpDescriptor) bool {
+			return slices.Contains(strings.Fields(idp.Protocols), samlProtocolNS)
+		})
+		if i < 0 {
+			return validation.NewValidationError("must describe a SAML 2.0 identity provider")
+		}
+		idp := entity.IDPs[i]
+		if !slices.ContainsFunc(idp.Services, endpoint.valid) {
+			return validation.NewValidationError("must have a single sign-on service at an https location")
+		}
+		if !slices.ContainsFunc(idp.Keys, keyDescriptor.signs) {
+			return validation.NewValidationError("must have a valid signing certificate")
+		}
+		return nil
+	}
+}
+
+// hasDoctype reports whether the XML document v declares a DOCTYPE.
+func hasDoctype(v string) bool {
+	decoder := xml.NewDecoder(strings.NewReader(v))
+	for {
+		token, err := decoder.RawToken()
+		if err != nil {
+			return false
+		}
+		switch t := token.(type) {
+		case xml.Directive:
+			if bytes.HasPrefix(bytes.TrimSpace(t), []byte("DOCTYPE")) {
+				return true
+			}
+		case xml.StartElement:
+			return false // a DOCTYPE must precede the root element
+		}
+	}
+}
+
+func (e endpoint) valid() bool {
+	u, err := url.Parse(e.Location)
+	return e.Binding != "" && err == nil && u.Scheme == "https" && u.Host != ""
+}
+
+// signs reports whether the key descriptor holds a certificate for signing: without use,
+// keys are used for both signing and encryption.
+func (k keyDescriptor) signs() bool {
+	if k.Use != "" && k.Use != "signing" {
+		return false
+	}
+	return slices.ContainsFunc(k.Certificates, func(encoded string) bool {
+		der, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(encoded), ""))
+		if err != nil {
+			return false
+		}
+		_, err = x509.ParseCertificate(der)
+		return err == nil
+	})
+}
@@ -0,0 +1,107 @@
+package identity_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/quantumcycle/protego/identity"
+)
+
+func newTestCertificate(t *testing.T) string {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "idp.example.com"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return base64.StdEncoding.EncodeToString(der)
+}
+
+const metadataTemplate = `<?xml version="1.0" encoding="UTF-8"?>
+<md:EntityDescriptor xmlns:md="urn:oasis:names:tc:SAML:2.0:metadata" xmlns:ds="http://www.w3.org/2000/09/xmldsig#" entityID="https://idp.example.com/metadata">
+  <md:IDPSSODescriptor WantAuthnRequestsSigned="false" protocolSupportEnumeration="urn:oasis:names:tc:SAML:2.0:protocol">
+    <md:KeyDescriptor use="signing">
+      <ds:KeyInfo>
+        <ds:X509Data>
+          <ds:X509Certificate>CERTIFICATE</ds:X509Certificate>
+        </ds:X509Data>
+      </ds:KeyInfo>
+    </md:KeyDescriptor>
+    <md:SingleSignOnService Binding="urn:oasis:names:tc:SAML:2.0:bindings:HTTP-Redirect" Location="https://idp.example.com/sso"/>
+  </md:IDPSSODescriptor>
+</md:EntityDescriptor>`
+
+func TestIsSAMLMetadataXML(t *testing.T) {
+	metadata := strings.Replace(metadataTemplate, "CERTIFICATE", newTestCertificate(t), 1)
+	validator := identity.IsSAMLMetadataXML()
+
+	t.Run("accepts identity provider metadata", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(validator(metadata)).To(Succeed())
+	})
+
+	t.Run("accepts keys without use and wrapped certificates", func(t *testing.T) {
+		g := NewWithT(t)
+		cert := newTestCertificate(t)
+		wrapped := cert[:64] + "\n          " + cert[64:]
+		v := strings.Replace(strings.Replace(metadataTemplate, ` use="signing"`, "", 1), "CERTIFICATE", wrapped, 1)
+		g.Expect(validator(v)).To(Succeed())
+	})
+
+	t.Run("rejects documents that are not metadata", func(t *testing.T) {
+		g := NewWithT(t)
+		for _, v := range []string{"", "not xml", `<EntityDescriptor entityID="x"/>`, `<md:EntitiesDescriptor xmlns:md="urn:oasis:names:tc:SAML:2.0:metadata"/>`} {
+			g.Expect(validator(v)).To(MatchError("must be a SAML 2.0 EntityDescriptor"), v)
+		}
+	})
+
+	t.Run("rejects DOCTYPE declarations", func(t *testing.T) {
+		g := NewWithT(t)
+		v := strings.Replace(metadata, "?>\n", "?>\n<!DOCTYPE md [<!ENTITY x \"y\">]>\n", 1)
+		g.Expect(validator(v)).To(MatchError("must not have a DOCTYPE"))
+	})
+
+	t.Run("rejects metadata without entityID", func(t *testing.T) {
+		g := NewWithT(t)
+		v := strings.Replace(metadata, `entityID="https://idp.example.com/metadata"`, "", 1)
+		g.Expect(validator(v)).To(MatchError("must have an entityID"))
+	})
+
+	t.Run("rejects metadata without SAML 2.0 identity provider", func(t *testing.T) {
+		g := NewWithT(t)
+		v := strings.Replace(metadata, "urn:oasis:names:tc:SAML:2.0:protocol", "urn:oasis:names:tc:SAML:1.1:protocol", 1)
+		g.Expect(validator(v)).To(MatchError("must describe a SAML 2.0 identity provider"))
+	})
+
+	t.Run("rejects metadata without https single sign-on service", func(t *testing.T) {
+		g := NewWithT(t)
+		v := strings.Replace(metadata, "https://idp.example.com/sso", "http://idp.example.com/sso", 1)
+		g.Expect(validator(v)).To(MatchError("must have a single sign-on service at an https location"))
+	})
+
+	t.Run("rejects metadata without valid signing certificate", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(validator(strings.Replace(metadataTemplate, "CERTIFICATE", "Zm9v", 1))).To(MatchError("must have a valid signing certificate"))
+		encryption := strings.Replace(metadata, `use="signing"`, `use="encryption"`, 1)
+		g.Expect(validator(encryption)).To(MatchError("must have a valid signing certificate"))
+	})
+}
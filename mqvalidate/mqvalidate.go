@@ -0,0 +1,159 @@
+// Package mqvalidate validates message-queue payloads before they reach business handlers.
+//
+// Handlers of any consumer library are wrapped by describing how to read the payload of its
+// message type. Payloads are decoded and validated, and messages that fail are routed to a
+// dead-letter callback instead of the handler.
+//
+// Usage with Kafka (segmentio/kafka-go):
+//
+//	orders := mqvalidate.Wrap(func(m kafka.Message) []byte { return m.Value }, processOrder).
+//	    DeadLetter(func(ctx context.Context, m kafka.Message, err error) error {
+//	        return dlq.WriteMessages(ctx, kafka.Message{Key: m.Key, Value: m.Value})
+//	    })
+//	for {
+//	    msg, err := reader.FetchMessage(ctx)
+//	    ...
+//	    if err := orders.Handle(ctx, msg); err == nil {
+//	        reader.CommitMessages(ctx, msg)
+//	    }
+//	}
+//
+// Usage with NATS:
+//
+//	orders := mqvalidate.Wrap(func(m *nats.Msg) []byte { return m.Data }, processOrder).DeadLetter(toDLQ)
+//	nc.Subscribe("orders", func(m *nats.Msg) {
+//	    if orders.Handle(context.Background(), m) == nil {
+//	        m.Ack()
+//	    }
+//	})
+//
+// Usage with SQS (aws-lambda-go):
+//
+//	orders := mqvalidate.Wrap(func(m events.SQSMessage) []byte { return []byte(m.Body) }, processOrder).DeadLetter(toDLQ)
+package mqvalidate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"sync/atomic"
+
+	"github.com/quantumcycle/protego/validation"
+)
+
+// HandlerFunc processes a message and its validated payload.
+type HandlerFunc[M, T any] func(ctx context.Context, msg M, payload T) error
+
+// DeadLetterFunc receives a message whose payload could not be decoded or failed validation.
+// Returning nil acknowledges the message; a returned error is reported to the consumer.
+type DeadLetterFunc[M any] func(ctx context.Context, msg M, err error) error
+
+// Middleware decodes and validates the payload of messages of type M before handing them,
+// decoded as T, to a handler.
+type Middleware[M, T any] struct {
+	payload    func(M) []byte
+	handler    HandlerFunc[M, T]
+	validators []validation.ValidatorCtx[T]
+	decode     func(data []byte, target any) error
+	deadLetter DeadLetterFunc[M]
+	sampleRate float64
+
+	received, validated, invalid atomic.Uint64
+}
+
+// Stats are the counts of messages handled by a Middleware.
+type Stats struct {
+	// Received is the number of messages handled.
+	Received uint64
+	// Validated is the number of messages whose payload was validated, fewer than
+	// Received when sampling.
+	Validated uint64
+	// Invalid is the number of messages failing decoding or validation.
+	Invalid uint64
+}
+
+// Wrap creates a Middleware reading raw payloads with payload and decoding them as JSON.
+// Decoded payloads are validated with their Validate method when T implements
+// validation.Validatable or validation.ValidatableCtx, then with the given validators.
+//
+// Example:
+//
+//	orders := mqvalidate.Wrap(func(m *nats.Msg) []byte { return m.Data }, processOrder,
+//	    customerExists(db),
+//	)
+func Wrap[M, T any](payload func(M) []byte, handler HandlerFunc[M, T], validators ...validation.ValidatorCtx[T]) *Middleware[M, T] {
+	return &Middleware[M, T]{
+		payload:    payload,
+		handler:    handler,
+		validators: validators,
+		decode:     json.Unmarshal,
+		sampleRate: 1,
+	}
+}
+
+// Decoder replaces JSON decoding of payloads, e.g. with a protobuf or Avro decoder.
+func (m *Middleware[M, T]) Decoder(decode func(data []byte, target any) error) *Middleware[M, T] {
+	m.decode = decode
+	return m
+}
+
+// DeadLetter routes messages failing decoding or validation to fn instead of returning
+// the validation error from Handle.
+func (m *Middleware[M, T]) DeadLetter(fn DeadLetterFunc[M]) *Middleware[M, T] {
+	m.deadLetter = fn
+	return m
+}
+
+// Sample validates the payloads of a random fraction rate of the messages, handing the
+// others to the handler once decoded, like validation.Sampled, for streams too large to
+// validate every message of. Stats tells how many were validated and found invalid.
+//
+// Example:
+//
+//	spans := mqvalidate.Wrap(data, exportSpan).Sample(0.05).DeadLetter(dropSpan)
+func (m *Middleware[M, T]) Sample(rate float64) *Middleware[M, T] {
+	m.sampleRate = rate
+	return m
+}
+
+// Stats returns the counts of messages handled so far, for metrics.
+func (m *Middleware[M, T]) Stats() Stats {
+	return Stats{Received: m.received.Load(), Validated: m.validated.Load(), Invalid: m.invalid.Load()}
+}
+
+// Handle decodes and validates the payload of msg, then calls the handler.
+//
+// Invalid messages are passed to the dead-letter callback when one is set, and the
+// validation error is returned otherwise. Errors that are not validation errors, such as
+// a validator failing to reach a database, are returned without dead-lettering the
+// message, so the consumer can retry it.
+func (m *Middleware[M, T]) Handle(ctx context.Context, msg M) error {
+	m.received.Add(1)
+	payload, err := m.validate(ctx, msg)
+	if err != nil {
+		if !validation.IsSystemError(err) {
+			m.invalid.Add(1)
+		}
+		if m.deadLetter != nil && !validation.IsSystemError(err) {
+			return m.deadLetter(ctx, msg, err)
+		}
+		return err
+	}
+	return m.handler(ctx, msg, payload)
+}
+
+func (m *Middleware[M, T]) validate(ctx context.Context, msg M) (T, error) {
+	var payload T
+	if err := m.decode(m.payload(msg), &payload); err != nil {
+		return payload, validation.WrapError(fmt.Errorf("must be a valid payload: %w", err))
+	}
+	if m.sampleRate < 1 && (m.sampleRate <= 0 || rand.Float64() >= m.sampleRate) {
+		return payload, nil
+	}
+	m.validated.Add(1)
+	if err := validation.ValidateNestedCtx(ctx, payload); err != nil {
+		return payload, err
+	}
+	return payload, validation.ValidateCtx(ctx, payload, m.validators...)
+}
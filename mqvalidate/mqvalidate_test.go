@@ -0,0 +1,150 @@
+package mqvalidate_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/quantumcycle/protego/mqvalidate"
+	"github.com/quantumcycle/protego/validation"
+)
+
+type message struct {
+	ID   string
+	Data []byte
+}
+
+type order struct {
+	ID       string `json:"id"`
+	Quantity int    `json:"quantity"`
+}
+
+func (o order) Validate() error {
+	return validation.ValidateStruct(
+		validation.Field(&o, &o.ID, validation.Required[string]()),
+	)
+}
+
+type deadLetter struct {
+	ids  []string
+	errs []error
+}
+
+func (d *deadLetter) send(_ context.Context, msg message, err error) error {
+	d.ids = append(d.ids, msg.ID)
+	d.errs = append(d.errs, err)
+	return nil
+}
+
+func TestMiddleware(t *testing.T) {
+	ctx := context.Background()
+	data := func(m message) []byte { return m.Data }
+	positive := validation.WithContext(validation.Custom(func(o order) error {
+		return validation.NewFieldError("quantity", validation.Validate(o.Quantity, validation.Positive[int]()))
+	}))
+
+	setup := func() (*mqvalidate.Middleware[message, order], *[]order, *deadLetter) {
+		var handled []order
+		dlq := &deadLetter{}
+		m := mqvalidate.Wrap(data, func(_ context.Context, _ message, o order) error {
+			handled = append(handled, o)
+			return nil
+		}, positive).DeadLetter(dlq.send)
+		return m, &handled, dlq
+	}
+
+	t.Run("hands valid payloads to the handler", func(t *testing.T) {
+		g := NewWithT(t)
+		m, handled, dlq := setup()
+		g.Expect(m.Handle(ctx, message{ID: "1", Data: []byte(`{"id":"o-1","quantity":2}`)})).To(Succeed())
+		g.Expect(*handled).To(Equal([]order{{ID: "o-1", Quantity: 2}}))
+		g.Expect(dlq.ids).To(BeEmpty())
+	})
+
+	t.Run("dead-letters payloads failing Validate", func(t *testing.T) {
+		g := NewWithT(t)
+		m, handled, dlq := setup()
+		g.Expect(m.Handle(ctx, message{ID: "1", Data: []byte(`{"quantity":2}`)})).To(Succeed())
+		g.Expect(*handled).To(BeEmpty())
+		g.Expect(dlq.ids).To(Equal([]string{"1"}))
+		g.Expect(dlq.errs[0]).To(MatchError("id: required"))
+	})
+
+	t.Run("dead-letters payloads failing validators", func(t *testing.T) {
+		g := NewWithT(t)
+		m, handled, dlq := setup()
+		g.Expect(m.Handle(ctx, message{ID: "2", Data: []byte(`{"id":"o-1","quantity":0}`)})).To(Succeed())
+		g.Expect(*handled).To(BeEmpty())
+		g.Expect(dlq.errs[0]).To(MatchError("quantity: must be positive"))
+	})
+
+	t.Run("dead-letters undecodable payloads", func(t *testing.T) {
+		g := NewWithT(t)
+		m, _, dlq := setup()
+		g.Expect(m.Handle(ctx, message{ID: "3", Data: []byte(`{"id":`)})).To(Succeed())
+		g.Expect(dlq.ids).To(Equal([]string{"3"}))
+		g.Expect(dlq.errs[0]).To(MatchError(ContainSubstring("must be a valid payload")))
+	})
+
+	t.Run("returns validation errors without a dead-letter callback", func(t *testing.T) {
+		g := NewWithT(t)
+		m := mqvalidate.Wrap(data, func(context.Context, message, order) error { return nil })
+		err := m.Handle(ctx, message{Data: []byte(`{}`)})
+		g.Expect(validation.IsValidationError(err)).To(BeTrue())
+	})
+
+	t.Run("returns system errors without dead-lettering", func(t *testing.T) {
+		g := NewWithT(t)
+		unavailable := errors.New("database unavailable")
+		dlq := &deadLetter{}
+		m := mqvalidate.Wrap(data, func(context.Context, message, order) error { return nil },
+			func(context.Context, order) error { return unavailable },
+		).DeadLetter(dlq.send)
+		err := m.Handle(ctx, message{Data: []byte(`{"id":"o-1"}`)})
+		g.Expect(err).To(Equal(unavailable))
+		g.Expect(dlq.ids).To(BeEmpty())
+	})
+
+	t.Run("uses a custom decoder", func(t *testing.T) {
+		g := NewWithT(t)
+		var got order
+		m := mqvalidate.Wrap(data, func(_ context.Context, _ message, o order) error {
+			got = o
+			return nil
+		}).Decoder(func(data []byte, target any) error {
+			target.(*order).ID = string(data)
+			return nil
+		})
+		g.Expect(m.Handle(ctx, message{Data: []byte("o-9")})).To(Succeed())
+		g.Expect(got.ID).To(Equal("o-9"))
+	})
+	t.Run("counts handled messages", func(t *testing.T) {
+		g := NewWithT(t)
+		m, _, _ := setup()
+		_ = m.Handle(ctx, message{Data: []byte(`{"id":"o-1","quantity":2}`)})
+		_ = m.Handle(ctx, message{Data: []byte(`{"id":"o-2","quantity":0}`)})
+		_ = m.Handle(ctx, message{Data: []byte(`{`)})
+		g.Expect(m.Stats()).To(Equal(mqvalidate.Stats{Received: 3, Validated: 2, Invalid: 2}))
+	})
+
+	t.Run("validates a sample of the messages", func(t *testing.T) {
+		g := NewWithT(t)
+		m, handled, dlq := setup()
+		m.Sample(0)
+		g.Expect(m.Handle(ctx, message{Data: []byte(`{"quantity":0}`)})).To(Succeed())
+		g.Expect(*handled).To(HaveLen(1))
+		g.Expect(dlq.ids).To(BeEmpty())
+
+		m.Sample(0.5)
+		for i := 0; i < 1000; i++ {
+			_ = m.Handle(ctx, message{Data: []byte(`{"quantity":0}`)})
+		}
+		stats := m.Stats()
+		g.Expect(stats.Received).To(BeEquivalentTo(1001))
+		g.Expect(stats.Validated).To(BeNumerically("~", 500, 100))
+		g.Expect(stats.Invalid).To(Equal(stats.Validated))
+		g.Expect(*handled).To(HaveLen(int(1001 - stats.Invalid)))
+	})
+}
@@ -0,0 +1,154 @@
+package playground_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/quantumcycle/protego/playground"
+	"github.com/quantumcycle/protego/validation"
+)
+
+func TestCompile(t *testing.T) {
+	schema, err := playground.SchemaFromStruct(schemaSignup{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	validate, err := playground.Compile[schemaSignup](schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	valid := schemaSignup{Email: "ada@example.com", Age: 36, Tags: []string{"go"}, Address: schemaAddress{Zip: "75001", Country: "FR"}}
+
+	t.Run("passes valid structs", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(validation.Validate(valid, validate)).To(Succeed())
+	})
+
+	t.Run("reports the same field paths as the schema", func(t *testing.T) {
+		g := NewWithT(t)
+		input := valid
+		input.Email = "nope"
+		input.Age = 12
+		input.Tags = []string{"ok", "not ok"}
+		input.Address.Zip = "7500"
+		input.Billing = &schemaAddress{Zip: "ABCDE", Country: "France"}
+		err := validation.Validate(input, validate)
+		g.Expect(validation.IsValidationError(err)).To(BeTrue())
+		g.Expect(validation.ToFormErrors(err)).To(Equal(validation.ToFormErrors(schema.Validate(input))))
+		g.Expect(validation.ToFormErrors(err)).To(HaveKey("billing.country"))
+		g.Expect(err.Error()).To(ContainSubstring("billing: zip: "))
+		g.Expect(err.Error()).To(ContainSubstring("billing: country: "))
+	})
+
+	t.Run("skips the fields of nil pointers", func(t *testing.T) {
+		g := NewWithT(t)
+		input := valid
+		input.Billing = nil
+		g.Expect(validation.Validate(input, validate)).To(Succeed())
+	})
+
+	t.Run("compiles pointer validators", func(t *testing.T) {
+		g := NewWithT(t)
+		validatePtr, err := playground.Compile[*schemaSignup](schema)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(validatePtr(&valid)).To(Succeed())
+		g.Expect(validatePtr(nil)).To(MatchError("required"))
+	})
+
+	t.Run("skips nested fields when the struct field fails its tag", func(t *testing.T) {
+		g := NewWithT(t)
+		type order struct {
+			Shipping *schemaAddress `json:"shipping" validate:"required"`
+		}
+		orderSchema, err := playground.SchemaFromStruct(order{})
+		g.Expect(err).ToNot(HaveOccurred())
+		validateOrder, err := playground.Compile[order](orderSchema)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(validateOrder(order{})).To(MatchError(`shipping: must satisfy "required"`))
+		invalid := order{Shipping: &schemaAddress{}}
+		g.Expect(validation.ToFormErrors(validateOrder(invalid))).To(Equal(validation.ToFormErrors(orderSchema.Validate(invalid))))
+	})
+
+	t.Run("compiles recursive types", func(t *testing.T) {
+		g := NewWithT(t)
+		employees, err := playground.SchemaFromStruct(schemaEmployee{})
+		g.Expect(err).ToNot(HaveOccurred())
+		validateEmployee, err := playground.Compile[schemaEmployee](employees)
+		g.Expect(err).ToNot(HaveOccurred())
+
+		valid := schemaEmployee{Email: "ada@example.com", Team: &schemaTeam{Name: "core", Lead: &schemaEmployee{Email: "grace@example.com"}}}
+		g.Expect(validateEmployee(valid)).To(Succeed())
+		for _, invalid := range []schemaEmployee{
+			{Email: "nope", Team: &schemaTeam{Lead: &schemaEmployee{Email: "nope", Team: &schemaTeam{}}}},
+			{Team: &schemaTeam{Name: "core", Lead: &schemaEmployee{Email: "grace@example.com", Team: &schemaTeam{}}}},
+		} {
+			err := validateEmployee(invalid)
+			g.Expect(err).To(HaveOccurred())
+			g.Expect(validation.ToFormErrors(err)).To(Equal(validation.ToFormErrors(employees.Validate(invalid))))
+		}
+		g.Expect(validation.ToFormErrors(validateEmployee(schemaEmployee{Email: "ada@example.com", Team: &schemaTeam{Lead: &schemaEmployee{Team: &schemaTeam{}}}}))).To(Equal(map[string][]string{
+			"team.name":           {`must satisfy "required"`},
+			"team.lead.email":     {`must satisfy "required"`},
+			"team.lead.team.name": {`must satisfy "required"`},
+		}))
+	})
+
+	t.Run("rejects other types", func(t *testing.T) {
+		g := NewWithT(t)
+		_, err := playground.Compile[schemaAddress](schema)
+		g.Expect(err).To(MatchError("playground: schema of playground_test.schemaSignup cannot compile to a validator of playground_test.schemaAddress"))
+	})
+}
+
+// benchLimits, benchSection and benchConfig make a large rule set of cheap tags, where the
+// cost of walking the schema is not hidden by that of the tags.
+type benchLimits struct {
+	CPU     int `json:"cpu" validate:"gte=0,lte=100"`
+	Memory  int `json:"memory" validate:"gte=0,lte=100"`
+	Disk    int `json:"disk" validate:"gte=0,lte=100"`
+	Network int `json:"network" validate:"gte=0,lte=100"`
+	Threads int `json:"threads" validate:"gte=1,lte=64"`
+	Files   int `json:"files" validate:"gte=1,lte=1024"`
+}
+
+type benchSection struct {
+	Name     string       `json:"name" validate:"required,max=50"`
+	Limits   benchLimits  `json:"limits"`
+	Override *benchLimits `json:"override"`
+}
+
+type benchConfig struct {
+	ID      string       `json:"id" validate:"required"`
+	API     benchSection `json:"api"`
+	Worker  benchSection `json:"worker"`
+	Cron    benchSection `json:"cron"`
+	Gateway benchSection `json:"gateway"`
+}
+
+func BenchmarkCompile(b *testing.B) {
+	schema, err := playground.SchemaFromStruct(benchConfig{})
+	if err != nil {
+		b.Fatal(err)
+	}
+	compiled, err := playground.Compile[benchConfig](schema)
+	if err != nil {
+		b.Fatal(err)
+	}
+	limits := benchLimits{CPU: 50, Memory: 50, Disk: 50, Network: 50, Threads: 8, Files: 256}
+	section := benchSection{Name: "default", Limits: limits, Override: &limits}
+	config := benchConfig{ID: "prod", API: section, Worker: section, Cron: section, Gateway: section}
+
+	b.Run("schema", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = schema.Validate(config)
+		}
+	})
+	b.Run("compiled", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = compiled(config)
+		}
+	})
+}
@@ -1,6 +1,8 @@
 package playground_test
 
 import (
+	"errors"
+	"reflect"
 	"testing"
 
 	. "github.com/onsi/gomega"
@@ -75,3 +77,45 @@ func TestPlaygroundValidatorsPass(t *testing.T) {
 		g.Expect(err).To(BeNil())
 	})
 }
+
+func TestTagError(t *testing.T) {
+	t.Run("preserves the tag and its parameter", func(t *testing.T) {
+		g := NewWithT(t)
+		err := validation.Validate("ab", playground.FromTag[string]("min=3"))
+		g.Expect(err).To(MatchError(`must satisfy "min=3"`))
+
+		var tagErr *playground.TagError
+		g.Expect(errors.As(err, &tagErr)).To(BeTrue())
+		g.Expect(tagErr.Tag).To(Equal("min"))
+		g.Expect(tagErr.Param).To(Equal("3"))
+		g.Expect(tagErr.FieldError().Kind()).To(Equal(reflect.String))
+	})
+
+	t.Run("keeps the alias name", func(t *testing.T) {
+		g := NewWithT(t)
+		err := validation.Validate("blue", playground.FromTag[string]("iscolor"))
+		g.Expect(err).To(MatchError(`must satisfy "iscolor"`))
+
+		var tagErr *playground.TagError
+		g.Expect(errors.As(err, &tagErr)).To(BeTrue())
+		g.Expect(tagErr.FieldError().ActualTag()).To(Equal("hexcolor|rgb|rgba|hsl|hsla"))
+	})
+
+	t.Run("attributes dive failures to the element index", func(t *testing.T) {
+		g := NewWithT(t)
+		err := validation.Validate([]string{"a", "b c"}, playground.FromTag[[]string]("dive,alphanum"))
+		g.Expect(err).To(MatchError(`[1]: must satisfy "alphanum"`))
+		g.Expect(validation.IsValidationError(err)).To(BeTrue())
+	})
+
+	t.Run("provides the code and params of the JSON form", func(t *testing.T) {
+		g := NewWithT(t)
+		err := validation.NewFieldError("name", validation.Validate("ab", playground.FromTag[string]("min=3")))
+		g.Expect(validation.ErrorObjects(err)).To(Equal([]validation.ErrorObject{{
+			Code:    "min",
+			Message: `must satisfy "min=3"`,
+			Field:   "name",
+			Params:  map[string]any{"param": "3"},
+		}}))
+	})
+}
@@ -0,0 +1,123 @@
+package playground
+
+import (
+	"errors"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/go-playground/validator/v10"
+
+	"github.com/quantumcycle/protego/validation"
+)
+
+// Options configures an Instance.
+type Options struct {
+	// TagName is the struct tag read by SchemaFromStruct, "validate" by default. Gin
+	// applications use "binding".
+	TagName string
+	// RequiredStructEnabled makes the required tag fail on zero structs, which
+	// go-playground otherwise ignores (see validator.WithRequiredStructEnabled).
+	RequiredStructEnabled bool
+}
+
+// Instance is a go-playground validator with its own tag registrations, isolated from the
+// shared one used by FromTag and the pre-built validators. It is safe for concurrent use,
+// registrations included.
+type Instance struct {
+	mu       sync.RWMutex // go-playground does not allow registrations concurrent with validations
+	validate *validator.Validate
+	opts     Options
+}
+
+// New creates an Instance, such as for a library registering its own tags without
+// affecting, or being affected by, the registrations of the application.
+//
+// Example:
+//
+//	var pg = playground.New(playground.Options{RequiredStructEnabled: true})
+//
+//	func init() {
+//	    pg.RegisterAlias("username", "min=3,max=20,alphanum")
+//	}
+//
+//	var IsUsername = playground.FromTagOn[string](pg, "username")
+func New(opts Options) *Instance {
+	if opts.TagName == "" {
+		opts.TagName = "validate"
+	}
+	var options []validator.Option
+	if opts.RequiredStructEnabled {
+		options = append(options, validator.WithRequiredStructEnabled())
+	}
+	return &Instance{validate: validator.New(options...), opts: opts}
+}
+
+// RegisterValidation registers a custom tag on the instance.
+func (i *Instance) RegisterValidation(tag string, fn validator.Func) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return i.validate.RegisterValidation(tag, fn)
+}
+
+// RegisterAlias registers a tag standing for a combination of tags on the instance.
+func (i *Instance) RegisterAlias(alias, tags string) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.validate.RegisterAlias(alias, tags)
+}
+
+// FromTagOn is FromTag using the tags registered on inst.
+//
+// Example:
+//
+//	var IsSKU = playground.FromTagOn[string](pg, "sku")
+func FromTagOn[T any](inst *Instance, tag string) validation.Validator[T] {
+	return func(v T) error {
+		return inst.check(v, tag)
+	}
+}
+
+// check validates v against a go-playground tag. Failures of elements validated with dive
+// are attributed to their index, e.g. "[1]: must satisfy \"alphanum\"".
+func (i *Instance) check(v any, tag string) error {
+	if i.opts.RequiredStructEnabled && isZeroStruct(v) && slices.Contains(strings.Split(tag, ","), "required") {
+		// go-playground skips structs when validating single values
+		return validation.WrapError(&TagError{Tag: "required"})
+	}
+	return tagErrors(i.validateVar(v, tag))
+}
+
+// tagErrors converts the error of a go-playground validation to TagErrors.
+func tagErrors(err error) error {
+	if err == nil {
+		return nil
+	}
+	var fieldErrors validator.ValidationErrors
+	if !errors.As(err, &fieldErrors) {
+		return validation.WrapError(err)
+	}
+	errs := make([]error, len(fieldErrors))
+	for n, fe := range fieldErrors {
+		tagErr := validation.WrapError(&TagError{Tag: fe.Tag(), Param: fe.Param(), fieldError: fe})
+		if fe.Field() != "" {
+			tagErr = validation.NewFieldError(fe.Field(), tagErr)
+		}
+		errs[n] = tagErr
+	}
+	return errors.Join(errs...)
+}
+
+func isZeroStruct(v any) bool {
+	rv := reflect.ValueOf(v)
+	return rv.Kind() == reflect.Struct && rv.IsZero()
+}
+
+// validateVar runs the tag under the read lock, released even when go-playground panics on
+// an undefined tag.
+func (i *Instance) validateVar(v any, tag string) error {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return i.validate.Var(v, tag)
+}
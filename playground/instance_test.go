@@ -0,0 +1,74 @@
+package playground_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	. "github.com/onsi/gomega"
+
+	"github.com/quantumcycle/protego/playground"
+	"github.com/quantumcycle/protego/validation"
+)
+
+func TestInstance(t *testing.T) {
+	t.Run("keeps registrations isolated", func(t *testing.T) {
+		g := NewWithT(t)
+		pg := playground.New(playground.Options{})
+		err := pg.RegisterValidation("even_length", func(fl validator.FieldLevel) bool {
+			return len(fl.Field().String())%2 == 0
+		})
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(validation.Validate("ab", playground.FromTagOn[string](pg, "even_length"))).To(Succeed())
+		g.Expect(validation.Validate("abc", playground.FromTagOn[string](pg, "even_length"))).To(MatchError(`must satisfy "even_length"`))
+
+		other := playground.New(playground.Options{})
+		g.Expect(func() { _ = validation.Validate("ab", playground.FromTagOn[string](other, "even_length")) }).To(Panic())
+		g.Expect(func() { _ = validation.Validate("ab", playground.FromTag[string]("even_length")) }).To(Panic())
+	})
+
+	t.Run("registers aliases", func(t *testing.T) {
+		g := NewWithT(t)
+		pg := playground.New(playground.Options{})
+		pg.RegisterAlias("slug", "lowercase,excludesall= ")
+		g.Expect(validation.Validate("a-slug", playground.FromTagOn[string](pg, "slug"))).To(Succeed())
+		g.Expect(validation.Validate("A Slug", playground.FromTagOn[string](pg, "slug"))).To(HaveOccurred())
+	})
+
+	t.Run("supports required structs", func(t *testing.T) {
+		type address struct{ City string }
+		type order struct {
+			Address address `validate:"required"`
+		}
+		g := NewWithT(t)
+		strict, err := playground.New(playground.Options{RequiredStructEnabled: true}).SchemaFromStruct(order{})
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(strict.Validate(order{})).To(MatchError(`Address: must satisfy "required"`))
+		g.Expect(strict.Validate(order{Address: address{City: "Paris"}})).To(Succeed())
+	})
+
+	t.Run("reads the configured struct tag", func(t *testing.T) {
+		type login struct {
+			Email string `json:"email" binding:"required,email"`
+		}
+		g := NewWithT(t)
+		schema, err := playground.New(playground.Options{TagName: "binding"}).SchemaFromStruct(login{})
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(schema.Validate(login{Email: "nope"})).To(MatchError(`email: must satisfy "email"`))
+	})
+
+	t.Run("compiles schemas with its own tags", func(t *testing.T) {
+		type product struct {
+			SKU string `json:"sku" validate:"sku_code"`
+		}
+		g := NewWithT(t)
+		pg := playground.New(playground.Options{})
+		g.Expect(pg.RegisterValidation("sku_code", func(fl validator.FieldLevel) bool {
+			return strings.HasPrefix(fl.Field().String(), "SKU-")
+		})).To(Succeed())
+		schema, err := pg.SchemaFromStruct(product{})
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(schema.Validate(product{SKU: "SKU-1"})).To(Succeed())
+		g.Expect(schema.Validate(product{SKU: "1"})).To(MatchError(`sku: must satisfy "sku_code"`))
+	})
+}
@@ -19,12 +19,40 @@
 package playground
 
 import (
+	"fmt"
+
 	"github.com/go-playground/validator/v10"
 
 	"github.com/quantumcycle/protego/validation"
 )
 
-var sharedValidator = validator.New()
+// shared is the instance used by FromTag, SchemaFromStruct and the pre-built validators.
+var shared = New(Options{})
+
+// RegisterValidation registers a custom tag on the validator shared by FromTag and the
+// pre-built validators. It is safe to call concurrently with validations, though tags are
+// usually registered at startup, before the validators using them run. Libraries should
+// register their tags on their own Instance (see New) rather than on the shared one.
+//
+// Example:
+//
+//	err := playground.RegisterValidation("sku", func(fl validator.FieldLevel) bool {
+//	    return skuPattern.MatchString(fl.Field().String())
+//	})
+//	var IsSKU = playground.FromTag[string]("sku")
+func RegisterValidation(tag string, fn validator.Func) error {
+	return shared.RegisterValidation(tag, fn)
+}
+
+// RegisterAlias registers a tag standing for a combination of tags on the shared validator,
+// like RegisterValidation.
+//
+// Example:
+//
+//	playground.RegisterAlias("username", "min=3,max=20,alphanum")
+func RegisterAlias(alias, tags string) {
+	shared.RegisterAlias(alias, tags)
+}
 
 // FromTag creates a type-safe validation.Validator[T] from a go-playground/validator tag string.
 // This allows you to leverage ANY of go-playground's 100+ built-in validators.
@@ -40,12 +68,53 @@ var sharedValidator = validator.New()
 //
 // See https://pkg.go.dev/github.com/go-playground/validator/v10 for all available tags.
 func FromTag[T any](tag string) validation.Validator[T] {
-	return func(v T) error {
-		if err := sharedValidator.Var(v, tag); err != nil {
-			return validation.WrapError(err)
-		}
+	return FromTagOn[T](shared, tag)
+}
+
+// TagError is the error of a failed go-playground tag, returned wrapped in a
+// validation.Error by FromTag and the pre-built validators. It keeps the tag name and
+// parameter so that failures can be translated or mapped to error codes:
+//
+//	var tagErr *playground.TagError
+//	if errors.As(err, &tagErr) && tagErr.Tag == "min" {
+//	    msg = fmt.Sprintf(tr("at least %s characters"), tagErr.Param)
+//	}
+type TagError struct {
+	// Tag is the name of the failed tag, such as "min" or "email".
+	Tag string
+	// Param is the parameter of the tag, such as "3" for min=3, empty if it has none.
+	Param string
+
+	fieldError validator.FieldError
+}
+
+// Error returns the failed tag and its parameter, e.g. `must satisfy "min=3"`.
+func (e *TagError) Error() string {
+	if e.Param == "" {
+		return fmt.Sprintf("must satisfy %q", e.Tag)
+	}
+	return fmt.Sprintf("must satisfy %q", e.Tag+"="+e.Param)
+}
+
+// Code returns the failed tag, the code of the error in its JSON form (see
+// validation.ErrorObjects).
+func (e *TagError) Code() string {
+	return e.Tag
+}
+
+// Params returns the parameter of the tag under the "param" key, nil if it has none.
+func (e *TagError) Params() map[string]any {
+	if e.Param == "" {
 		return nil
 	}
+	return map[string]any{"param": e.Param}
+}
+
+// FieldError returns the underlying go-playground error, with details such as the
+// actual tag of an alias. It is nil for required structs (see Options.RequiredStructEnabled),
+// which go-playground does not check on single values.
+func (e *TagError) FieldError() validator.FieldError {
+	return e.fieldError
 }
 
 // FromTagWithMessage creates a validator from a go-playground tag with a custom error message.
@@ -57,7 +126,7 @@ func FromTag[T any](tag string) validation.Validator[T] {
 //	)
 func FromTagWithMessage[T any](tag, message string) validation.Validator[T] {
 	return func(v T) error {
-		if err := sharedValidator.Var(v, tag); err != nil {
+		if err := shared.check(v, tag); err != nil {
 			return validation.NewValidationError(message)
 		}
 		return nil
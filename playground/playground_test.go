@@ -1,8 +1,12 @@
 package playground_test
 
 import (
+	"fmt"
+	"strings"
+	"sync"
 	"testing"
 
+	"github.com/go-playground/validator/v10"
 	. "github.com/onsi/gomega"
 
 	"github.com/quantumcycle/protego/playground"
@@ -104,3 +108,40 @@ func TestIsSemver(t *testing.T) {
 		g.Expect(err).NotTo(BeNil())
 	})
 }
+
+func TestRegisterValidation(t *testing.T) {
+	t.Run("registers custom tags", func(t *testing.T) {
+		g := NewWithT(t)
+		err := playground.RegisterValidation("sku", func(fl validator.FieldLevel) bool {
+			return strings.HasPrefix(fl.Field().String(), "SKU-")
+		})
+		g.Expect(err).ToNot(HaveOccurred())
+		isSKU := playground.FromTag[string]("sku")
+		g.Expect(validation.Validate("SKU-42", isSKU)).To(Succeed())
+		g.Expect(validation.Validate("42", isSKU)).To(MatchError(`must satisfy "sku"`))
+	})
+
+	t.Run("registers aliases", func(t *testing.T) {
+		g := NewWithT(t)
+		playground.RegisterAlias("username", "min=3,max=20,alphanum")
+		isUsername := playground.FromTag[string]("username")
+		g.Expect(validation.Validate("ada", isUsername)).To(Succeed())
+		g.Expect(validation.Validate("a d", isUsername)).To(MatchError(`must satisfy "username"`))
+	})
+
+	t.Run("is safe concurrently with validations", func(t *testing.T) {
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				_ = validation.Validate("test@example.com", playground.IsEmail)
+			}()
+			go func(i int) {
+				defer wg.Done()
+				_ = playground.RegisterValidation(fmt.Sprintf("noop%d", i), func(validator.FieldLevel) bool { return true })
+			}(i)
+		}
+		wg.Wait()
+	})
+}
@@ -0,0 +1,178 @@
+package playground
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/quantumcycle/protego/validation"
+)
+
+// Schema validates structs with the `validate:"..."` tags of their fields, as compiled by
+// SchemaFromStruct.
+type Schema struct {
+	inst      *Instance
+	typ       reflect.Type
+	fields    []schemaField
+	compiling bool
+}
+
+type schemaField struct {
+	index  int
+	name   string
+	tag    string  // go-playground tag, empty for a nested struct without one
+	nested *Schema // schema of a nested struct field, nil if it has no tags
+}
+
+// crossFieldTags are the go-playground tags comparing a field with other fields, which need
+// the whole struct and cannot run on a single field.
+var crossFieldTags = map[string]bool{
+	"eqfield": true, "nefield": true, "gtfield": true, "gtefield": true, "ltfield": true, "ltefield": true,
+	"eqcsfield": true, "necsfield": true, "gtcsfield": true, "gtecsfield": true, "ltcsfield": true, "ltecsfield": true,
+	"fieldcontains": true, "fieldexcludes": true,
+	"required_if": true, "required_unless": true, "required_with": true, "required_with_all": true,
+	"required_without": true, "required_without_all": true,
+	"excluded_if": true, "excluded_unless": true, "excluded_with": true, "excluded_with_all": true,
+	"excluded_without": true, "excluded_without_all": true,
+}
+
+// SchemaFromStruct compiles the `validate:"..."` tags of the exported fields of a struct,
+// such as a request type already validated with go-playground's validator.Struct, into a
+// Schema. Nested structs and struct pointers are compiled too, including those of recursive
+// types such as a category with a parent category. This eases an incremental migration:
+// the tags keep working while fields move to protego validators one by one.
+//
+// Errors are attributed to field paths named after the json tag when present, like
+// validation.Field, e.g. "address: zip: ..." (see validation.ToFormErrors).
+//
+// Tags comparing fields with each other, such as eqfield or required_if, are not supported
+// and make SchemaFromStruct return an error: write them as protego rules instead.
+//
+// Example:
+//
+//	var signupSchema = must(playground.SchemaFromStruct(SignupRequest{}))
+//
+//	func (r SignupRequest) Validate() error {
+//	    return errors.Join(
+//	        signupSchema.Validate(r),
+//	        validation.NewFieldError("password_confirmation", validation.Validate(r.PasswordConfirmation, validation.Equals(r.Password))),
+//	    )
+//	}
+func SchemaFromStruct(v any) (*Schema, error) {
+	return shared.SchemaFromStruct(v)
+}
+
+// SchemaFromStruct is like the package-level SchemaFromStruct, reading the struct tag of
+// Options.TagName and running it with the tags registered on the instance.
+func (i *Instance) SchemaFromStruct(v any) (*Schema, error) {
+	t := reflect.TypeOf(v)
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("playground: SchemaFromStruct needs a struct, got %T", v)
+	}
+	return i.compileSchema(t, t.Name(), map[reflect.Type]*Schema{})
+}
+
+// compileSchema compiles the schema of t, reusing the schemas of compiled, by type. A
+// schema is recorded before its fields are compiled, so that fields of recursive types,
+// such as the parent of a category, reference the schema being compiled rather than
+// compiling it again without end.
+func (i *Instance) compileSchema(t reflect.Type, path string, compiled map[reflect.Type]*Schema) (*Schema, error) {
+	if s, ok := compiled[t]; ok {
+		return s, nil
+	}
+	s := &Schema{inst: i, typ: t, compiling: true}
+	compiled[t] = s
+	for n := 0; n < t.NumField(); n++ {
+		f := t.Field(n)
+		tag := f.Tag.Get(i.opts.TagName)
+		if !f.IsExported() || tag == "-" {
+			continue
+		}
+		for _, part := range strings.Split(tag, ",") {
+			name, _, _ := strings.Cut(part, "=")
+			if crossFieldTags[name] {
+				return nil, fmt.Errorf("playground: field %s.%s: tag %q compares fields and is not supported", path, f.Name, name)
+			}
+		}
+		field := schemaField{index: n, name: fieldName(f), tag: tag}
+		if st := structType(f.Type); st != nil {
+			nested, err := i.compileSchema(st, path+"."+f.Name, compiled)
+			if err != nil {
+				return nil, err
+			}
+			// the fields of a schema still being compiled are not known yet
+			if len(nested.fields) > 0 || nested.compiling {
+				field.nested = nested
+			}
+		}
+		if field.tag != "" || field.nested != nil {
+			s.fields = append(s.fields, field)
+		}
+	}
+	s.compiling = false
+	return s, nil
+}
+
+// structType returns the struct type of a struct or struct pointer field, nil for other
+// types and for time.Time, which has no fields to validate.
+func structType(t reflect.Type) reflect.Type {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || t == reflect.TypeOf(time.Time{}) {
+		return nil
+	}
+	return t
+}
+
+// fieldName returns the json tag name of a field when present, its Go name otherwise.
+func fieldName(f reflect.StructField) string {
+	if name, _, _ := strings.Cut(f.Tag.Get("json"), ","); name != "" && name != "-" {
+		return name
+	}
+	return f.Name
+}
+
+// Validate validates v, a struct or struct pointer of the type the schema was compiled
+// from, running every field tag and joining the errors of all fields.
+func (s *Schema) Validate(v any) error {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return validation.NewValidationError("required")
+		}
+		rv = rv.Elem()
+	}
+	if rv.Type() != s.typ {
+		return fmt.Errorf("playground: schema of %s cannot validate %T", s.typ, v)
+	}
+	return s.validate(rv)
+}
+
+func (s *Schema) validate(rv reflect.Value) error {
+	report := validation.NewReport()
+	defer report.Release()
+	for _, f := range s.fields {
+		value := rv.Field(f.index)
+		if f.tag != "" {
+			if err := s.inst.check(value.Interface(), f.tag); err != nil {
+				report.Add(validation.NewFieldError(f.name, err))
+				continue
+			}
+		}
+		if f.nested != nil {
+			if value.Kind() == reflect.Pointer {
+				if value.IsNil() {
+					continue
+				}
+				value = value.Elem()
+			}
+			report.Add(validation.NewFieldError(f.name, f.nested.validate(value)))
+		}
+	}
+	return report.Err()
+}
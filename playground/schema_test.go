@@ -0,0 +1,121 @@
+package playground_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/quantumcycle/protego/playground"
+	"github.com/quantumcycle/protego/validation"
+)
+
+type schemaAddress struct {
+	Zip     string `json:"zip" validate:"required,numeric,len=5"`
+	Country string `json:"country" validate:"omitempty,iso3166_1_alpha2"`
+}
+
+type schemaSignup struct {
+	Email    string         `json:"email" validate:"required,email"`
+	Age      int            `json:"age" validate:"gte=18"`
+	Nickname string         `validate:"-"`
+	Tags     []string       `json:"tags" validate:"max=3,dive,alphanum"`
+	Address  schemaAddress  `json:"address"`
+	Billing  *schemaAddress `json:"billing"`
+	internal string         `validate:"required"`
+}
+
+// schemaCategory, schemaEmployee and schemaTeam are recursive types, directly and through
+// each other.
+type schemaCategory struct {
+	Name   string          `json:"name" validate:"required"`
+	Parent *schemaCategory `json:"parent"`
+}
+
+type schemaEmployee struct {
+	Email string      `json:"email" validate:"required,email"`
+	Team  *schemaTeam `json:"team"`
+}
+
+type schemaTeam struct {
+	Name string          `json:"name" validate:"required"`
+	Lead *schemaEmployee `json:"lead"`
+}
+
+func TestSchemaFromStruct(t *testing.T) {
+	schema, err := playground.SchemaFromStruct(schemaSignup{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	valid := schemaSignup{Email: "ada@example.com", Age: 36, Tags: []string{"go"}, Address: schemaAddress{Zip: "75001", Country: "FR"}}
+
+	t.Run("passes valid structs and pointers", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(schema.Validate(valid)).To(Succeed())
+		g.Expect(schema.Validate(&valid)).To(Succeed())
+	})
+
+	t.Run("attributes errors to field paths", func(t *testing.T) {
+		g := NewWithT(t)
+		input := valid
+		input.Email = "nope"
+		input.Age = 12
+		input.Address.Zip = "7500"
+		input.Billing = &schemaAddress{Zip: "ABCDE"}
+		err := schema.Validate(input)
+		g.Expect(validation.IsValidationError(err)).To(BeTrue())
+		g.Expect(validation.ToFormErrors(err)).To(HaveKey("email"))
+		g.Expect(validation.ToFormErrors(err)).To(HaveKey("age"))
+		g.Expect(validation.ToFormErrors(err)).To(HaveKey("address.zip"))
+		g.Expect(validation.ToFormErrors(err)).To(HaveKey("billing.zip"))
+		g.Expect(validation.ToFormErrors(err)).To(HaveLen(4))
+	})
+
+	t.Run("validates slices with dive", func(t *testing.T) {
+		g := NewWithT(t)
+		input := valid
+		input.Tags = []string{"ok", "not ok"}
+		g.Expect(validation.ToFormErrors(schema.Validate(input))).To(HaveKey("tags[1]"))
+	})
+
+	t.Run("validates recursive types", func(t *testing.T) {
+		g := NewWithT(t)
+		categories, err := playground.SchemaFromStruct(schemaCategory{})
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(categories.Validate(schemaCategory{Name: "phones", Parent: &schemaCategory{Name: "electronics"}})).To(Succeed())
+		err = categories.Validate(schemaCategory{Name: "phones", Parent: &schemaCategory{Parent: &schemaCategory{}}})
+		g.Expect(validation.ToFormErrors(err)).To(Equal(map[string][]string{
+			"parent.name":        {`must satisfy "required"`},
+			"parent.parent.name": {`must satisfy "required"`},
+		}))
+
+		employees, err := playground.SchemaFromStruct(schemaEmployee{})
+		g.Expect(err).ToNot(HaveOccurred())
+		err = employees.Validate(schemaEmployee{Email: "ada@example.com", Team: &schemaTeam{Name: "core", Lead: &schemaEmployee{Email: "nope"}}})
+		g.Expect(validation.ToFormErrors(err)).To(HaveKey("team.lead.email"))
+	})
+
+	t.Run("rejects other types", func(t *testing.T) {
+		g := NewWithT(t)
+		err := schema.Validate(schemaAddress{})
+		g.Expect(err).To(HaveOccurred())
+		g.Expect(validation.IsSystemError(err)).To(BeTrue())
+	})
+}
+
+func TestSchemaFromStructErrors(t *testing.T) {
+	t.Run("requires a struct", func(t *testing.T) {
+		g := NewWithT(t)
+		_, err := playground.SchemaFromStruct("text")
+		g.Expect(err).To(MatchError("playground: SchemaFromStruct needs a struct, got string"))
+	})
+
+	t.Run("rejects cross-field tags", func(t *testing.T) {
+		g := NewWithT(t)
+		type passwordChange struct {
+			Password     string `validate:"required"`
+			Confirmation string `validate:"required,eqfield=Password"`
+		}
+		_, err := playground.SchemaFromStruct(&passwordChange{})
+		g.Expect(err).To(MatchError(`playground: field passwordChange.Confirmation: tag "eqfield" compares fields and is not supported`))
+	})
+}
@@ -0,0 +1,182 @@
+// Package protovalidate validates protobuf messages from the constraints declared in their
+// descriptors.
+//
+// Field constraints written with buf protovalidate (buf.validate.field) or protoc-gen-validate
+// (validate.rules) options are read at runtime and translated to protego validators, so
+// generated Go types can be validated without generating validation code:
+//
+//	message CreateUserRequest {
+//	  string name = 1 [(buf.validate.field).string = {min_len: 3, max_len: 50}];
+//	  int32 age = 2 [(buf.validate.field).int32.gte = 18];
+//	}
+//
+// The Go package defining the option extension must be linked into the binary, otherwise the
+// options cannot be decoded and are ignored.
+//
+// Usage in a gRPC unary interceptor:
+//
+//	func validate(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+//	    if msg, ok := req.(proto.Message); ok {
+//	        if err := protovalidate.Validate(msg); err != nil {
+//	            return nil, status.Error(codes.InvalidArgument, err.Error())
+//	        }
+//	    }
+//	    return handler(ctx, req)
+//	}
+package protovalidate
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/quantumcycle/protego/validation"
+)
+
+// Validate validates msg against the constraints declared on its fields, recursing into
+// nested messages. Errors are attributed to the proto field names, such as
+// "address: city: required" or "tags[1]: must be at least 2 characters".
+//
+// Constraints that have no protego equivalent are reported as a non-validation error.
+func Validate(msg proto.Message) error {
+	if msg == nil {
+		return nil
+	}
+	m := msg.ProtoReflect()
+	if !m.IsValid() {
+		return nil
+	}
+	return validateMessage(m)
+}
+
+// For returns a validator for messages of type M. Unlike Validate, it reports unsupported
+// constraints when called, for M and every message reachable from it.
+//
+// Example:
+//
+//	validateRequest, err := protovalidate.For[*userpb.CreateUserRequest]()
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	err = validation.Validate(req, validateRequest)
+func For[M proto.Message]() (validation.Validator[M], error) {
+	var zero M
+	if err := compileAll(zero.ProtoReflect().Descriptor(), make(map[protoreflect.FullName]bool)); err != nil {
+		return nil, err
+	}
+	return func(msg M) error {
+		return Validate(msg)
+	}, nil
+}
+
+// compiled caches the rules of each message descriptor.
+var compiled sync.Map // protoreflect.MessageDescriptor -> compileResult
+
+type compileResult struct {
+	fields []fieldRule
+	err    error
+}
+
+func compile(md protoreflect.MessageDescriptor) ([]fieldRule, error) {
+	if cached, ok := compiled.Load(md); ok {
+		result := cached.(compileResult)
+		return result.fields, result.err
+	}
+	fields, err := compileMessage(md)
+	compiled.Store(md, compileResult{fields: fields, err: err})
+	return fields, err
+}
+
+func compileAll(md protoreflect.MessageDescriptor, seen map[protoreflect.FullName]bool) error {
+	if seen[md.FullName()] {
+		return nil
+	}
+	seen[md.FullName()] = true
+	if _, err := compile(md); err != nil {
+		return err
+	}
+	fields := md.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		if fd.IsMap() {
+			fd = fd.MapValue()
+		}
+		if fd.Message() != nil {
+			if err := compileAll(fd.Message(), seen); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func validateMessage(m protoreflect.Message) error {
+	fields, err := compile(m.Descriptor())
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, rule := range fields {
+		errs = append(errs, rule.validate(m)...)
+	}
+	return errors.Join(errs...)
+}
+
+// validate checks the field of m and returns errors attributed to the field.
+func (r fieldRule) validate(m protoreflect.Message) []error {
+	name := string(r.fd.Name())
+	if !m.Has(r.fd) {
+		if r.required {
+			return []error{validation.NewFieldError(name, validation.NewValidationError("required"))}
+		}
+		if r.fd.HasPresence() {
+			return nil
+		}
+	}
+
+	value := m.Get(r.fd)
+	var errs []error
+	if r.check != nil {
+		errs = append(errs, validation.NewFieldError(name, r.check(value)))
+	}
+	switch {
+	case r.fd.IsList():
+		list := value.List()
+		for i := 0; i < list.Len(); i++ {
+			errs = append(errs, validation.NewFieldError(fmt.Sprintf("%s[%d]", name, i), r.validateValue(r.fd, list.Get(i))))
+		}
+	case r.fd.IsMap():
+		entries := value.Map()
+		keys := make([]protoreflect.MapKey, 0, entries.Len())
+		entries.Range(func(k protoreflect.MapKey, _ protoreflect.Value) bool {
+			keys = append(keys, k)
+			return true
+		})
+		sort.Slice(keys, func(i, j int) bool {
+			return keys[i].String() < keys[j].String()
+		})
+		for _, k := range keys {
+			errs = append(errs, validation.NewFieldError(fmt.Sprintf("%s[%s]", name, k.String()), r.validateValue(r.fd.MapValue(), entries.Get(k))))
+		}
+	default:
+		errs = append(errs, validation.NewFieldError(name, r.validateValue(r.fd, value)))
+	}
+	return errs
+}
+
+// validateValue checks a single value of the field, or an element of a repeated field:
+// the item constraints, then the constraints of the nested message.
+func (r fieldRule) validateValue(fd protoreflect.FieldDescriptor, value protoreflect.Value) error {
+	var errs []error
+	if r.items != nil {
+		errs = append(errs, r.items(value))
+	}
+	if fd.Message() != nil && !r.skip {
+		errs = append(errs, validateMessage(value.Message()))
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,331 @@
+package protovalidate_test
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/quantumcycle/protego/protovalidate"
+	"github.com/quantumcycle/protego/validation"
+)
+
+// The option messages below mirror the subset of buf/validate/validate.proto and
+// validate/validate.proto used by the tests.
+
+func field(name string, number int32, kind descriptorpb.FieldDescriptorProto_Type, typeName string, repeated bool) *descriptorpb.FieldDescriptorProto {
+	label := descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL
+	if repeated {
+		label = descriptorpb.FieldDescriptorProto_LABEL_REPEATED
+	}
+	f := &descriptorpb.FieldDescriptorProto{
+		Name:     proto.String(name),
+		JsonName: proto.String(name),
+		Number:   proto.Int32(number),
+		Label:    label.Enum(),
+		Type:     kind.Enum(),
+	}
+	if typeName != "" {
+		f.TypeName = proto.String(typeName)
+	}
+	return f
+}
+
+const (
+	tBool    = descriptorpb.FieldDescriptorProto_TYPE_BOOL
+	tString  = descriptorpb.FieldDescriptorProto_TYPE_STRING
+	tInt32   = descriptorpb.FieldDescriptorProto_TYPE_INT32
+	tUint64  = descriptorpb.FieldDescriptorProto_TYPE_UINT64
+	tMessage = descriptorpb.FieldDescriptorProto_TYPE_MESSAGE
+)
+
+func message(name string, fields ...*descriptorpb.FieldDescriptorProto) *descriptorpb.DescriptorProto {
+	return &descriptorpb.DescriptorProto{Name: proto.String(name), Field: fields}
+}
+
+func extension(name, typeName string, number int32) *descriptorpb.FieldDescriptorProto {
+	x := field(name, number, tMessage, typeName, false)
+	x.Extendee = proto.String(".google.protobuf.FieldOptions")
+	return x
+}
+
+func newFile(t *testing.T, files *protoregistry.Files, fdp *descriptorpb.FileDescriptorProto) protoreflect.FileDescriptor {
+	t.Helper()
+	fd, err := protodesc.NewFile(fdp, files)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := files.RegisterFile(fd); err != nil {
+		t.Fatal(err)
+	}
+	return fd
+}
+
+type schema struct {
+	buf  protoreflect.ExtensionType
+	pgv  protoreflect.ExtensionType
+	user protoreflect.MessageDescriptor
+	bad  protoreflect.MessageDescriptor
+}
+
+// options builds field options setting the constraint extension xt, with rules given as
+// dotted paths to values, such as "string.min_len": uint64(3).
+func options(xt protoreflect.ExtensionType, rules map[string]any) *descriptorpb.FieldOptions {
+	constraints := xt.New().Message()
+	for path, value := range rules {
+		m := constraints
+		names := strings.Split(path, ".")
+		for _, name := range names[:len(names)-1] {
+			m = m.Mutable(m.Descriptor().Fields().ByName(protoreflect.Name(name))).Message()
+		}
+		fd := m.Descriptor().Fields().ByName(protoreflect.Name(names[len(names)-1]))
+		if list, ok := value.([]string); ok {
+			l := m.Mutable(fd).List()
+			for _, v := range list {
+				l.Append(protoreflect.ValueOfString(v))
+			}
+			continue
+		}
+		m.Set(fd, protoreflect.ValueOf(value))
+	}
+	opts := &descriptorpb.FieldOptions{}
+	opts.ProtoReflect().Set(xt.TypeDescriptor(), protoreflect.ValueOfMessage(constraints))
+	return opts
+}
+
+func withOptions(f *descriptorpb.FieldDescriptorProto, opts *descriptorpb.FieldOptions) *descriptorpb.FieldDescriptorProto {
+	f.Options = opts
+	return f
+}
+
+func newSchema(t *testing.T) schema {
+	files := new(protoregistry.Files)
+	if err := files.RegisterFile(descriptorpb.File_google_protobuf_descriptor_proto); err != nil {
+		t.Fatal(err)
+	}
+
+	bufFile := newFile(t, files, &descriptorpb.FileDescriptorProto{
+		Name:       proto.String("buf/validate/validate.proto"),
+		Package:    proto.String("buf.validate"),
+		Dependency: []string{"google/protobuf/descriptor.proto"},
+		MessageType: []*descriptorpb.DescriptorProto{
+			message("FieldRules",
+				field("required", 25, tBool, "", false),
+				field("string", 14, tMessage, ".buf.validate.StringRules", false),
+				field("int32", 3, tMessage, ".buf.validate.Int32Rules", false),
+				field("repeated", 18, tMessage, ".buf.validate.RepeatedRules", false),
+			),
+			message("StringRules",
+				field("min_len", 2, tUint64, "", false),
+				field("max_len", 3, tUint64, "", false),
+				field("pattern", 6, tString, "", false),
+				field("in", 10, tString, "", true),
+				field("email", 12, tBool, "", false),
+			),
+			message("Int32Rules",
+				field("gt", 4, tInt32, "", false),
+				field("gte", 5, tInt32, "", false),
+				field("lte", 3, tInt32, "", false),
+			),
+			message("RepeatedRules",
+				field("min_items", 1, tUint64, "", false),
+				field("max_items", 2, tUint64, "", false),
+				field("unique", 3, tBool, "", false),
+				field("items", 4, tMessage, ".buf.validate.FieldRules", false),
+			),
+		},
+		Extension: []*descriptorpb.FieldDescriptorProto{extension("field", ".buf.validate.FieldRules", 1159)},
+	})
+	pgvFile := newFile(t, files, &descriptorpb.FileDescriptorProto{
+		Name:       proto.String("validate/validate.proto"),
+		Package:    proto.String("validate"),
+		Dependency: []string{"google/protobuf/descriptor.proto"},
+		MessageType: []*descriptorpb.DescriptorProto{
+			message("FieldRules",
+				field("message", 17, tMessage, ".validate.MessageRules", false),
+				field("string", 14, tMessage, ".validate.StringRules", false),
+			),
+			message("MessageRules",
+				field("skip", 1, tBool, "", false),
+				field("required", 2, tBool, "", false),
+			),
+			message("StringRules",
+				field("min_len", 2, tUint64, "", false),
+			),
+		},
+		Extension: []*descriptorpb.FieldDescriptorProto{extension("rules", ".validate.FieldRules", 1071)},
+	})
+	s := schema{
+		buf: dynamicpb.NewExtensionType(bufFile.Extensions().Get(0)),
+		pgv: dynamicpb.NewExtensionType(pgvFile.Extensions().Get(0)),
+	}
+
+	userFile := newFile(t, files, &descriptorpb.FileDescriptorProto{
+		Name:       proto.String("user.proto"),
+		Package:    proto.String("test"),
+		Syntax:     proto.String("proto3"),
+		Dependency: []string{"buf/validate/validate.proto", "validate/validate.proto"},
+		MessageType: []*descriptorpb.DescriptorProto{
+			message("Address",
+				withOptions(field("city", 1, tString, "", false), options(s.buf, map[string]any{"required": true})),
+			),
+			message("User",
+				withOptions(field("name", 1, tString, "", false), options(s.buf, map[string]any{
+					"string.min_len": uint64(3), "string.max_len": uint64(10),
+				})),
+				withOptions(field("age", 2, tInt32, "", false), options(s.buf, map[string]any{"int32.gte": int32(18)})),
+				withOptions(field("tags", 3, tString, "", true), options(s.buf, map[string]any{
+					"repeated.max_items": uint64(2), "repeated.unique": true, "repeated.items.string.min_len": uint64(2),
+				})),
+				withOptions(field("address", 4, tMessage, ".test.Address", false), options(s.pgv, map[string]any{"message.required": true})),
+				field("previous", 5, tMessage, ".test.Address", true),
+				withOptions(field("role", 6, tString, "", false), options(s.buf, map[string]any{"string.in": []string{"admin", "member"}})),
+				withOptions(field("draft", 7, tMessage, ".test.Address", false), options(s.pgv, map[string]any{"message.skip": true})),
+				withOptions(field("nickname", 8, tString, "", false), options(s.pgv, map[string]any{"string.min_len": uint64(2)})),
+			),
+			message("Bad",
+				withOptions(field("email", 1, tString, "", false), options(s.buf, map[string]any{"string.email": true})),
+			),
+		},
+	})
+	s.user = userFile.Messages().ByName("User")
+	s.bad = userFile.Messages().ByName("Bad")
+	return s
+}
+
+type values map[string]any
+
+func newMessage(md protoreflect.MessageDescriptor, fields values) *dynamicpb.Message {
+	msg := dynamicpb.NewMessage(md)
+	for name, value := range fields {
+		fd := md.Fields().ByName(protoreflect.Name(name))
+		switch v := value.(type) {
+		case []string:
+			list := msg.Mutable(fd).List()
+			for _, s := range v {
+				list.Append(protoreflect.ValueOfString(s))
+			}
+		case []values:
+			list := msg.Mutable(fd).List()
+			for _, m := range v {
+				list.Append(protoreflect.ValueOfMessage(newMessage(fd.Message(), m)))
+			}
+		case values:
+			msg.Set(fd, protoreflect.ValueOfMessage(newMessage(fd.Message(), v)))
+		default:
+			msg.Set(fd, protoreflect.ValueOf(value))
+		}
+	}
+	return msg
+}
+
+func TestValidate(t *testing.T) {
+	s := newSchema(t)
+	valid := func() values {
+		return values{
+			"name":     "John",
+			"age":      int32(30),
+			"tags":     []string{"go", "grpc"},
+			"address":  values{"city": "Paris"},
+			"role":     "admin",
+			"nickname": "Jojo",
+		}
+	}
+
+	t.Run("accepts valid messages", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(protovalidate.Validate(newMessage(s.user, valid()))).To(Succeed())
+	})
+
+	t.Run("accepts nil messages", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(protovalidate.Validate(nil)).To(Succeed())
+	})
+
+	t.Run("checks scalar constraints", func(t *testing.T) {
+		g := NewWithT(t)
+		fields := valid()
+		fields["name"] = "Jo"
+		fields["age"] = int32(12)
+		fields["role"] = "owner"
+		err := protovalidate.Validate(newMessage(s.user, fields))
+		g.Expect(validation.IsValidationError(err)).To(BeTrue())
+		g.Expect(err.Error()).To(ContainSubstring("name: must be at least 3 characters"))
+		g.Expect(err.Error()).To(ContainSubstring("age: must be at least 18"))
+		g.Expect(err.Error()).To(ContainSubstring("role: must be one of"))
+	})
+
+	t.Run("checks implicit presence fields at their zero value", func(t *testing.T) {
+		g := NewWithT(t)
+		fields := valid()
+		delete(fields, "name")
+		err := protovalidate.Validate(newMessage(s.user, fields))
+		g.Expect(err).To(MatchError("name: must be at least 3 characters"))
+	})
+
+	t.Run("checks repeated constraints and items", func(t *testing.T) {
+		g := NewWithT(t)
+		fields := valid()
+		fields["tags"] = []string{"go", "x", "go"}
+		err := protovalidate.Validate(newMessage(s.user, fields))
+		g.Expect(err).To(HaveOccurred())
+		g.Expect(err.Error()).To(ContainSubstring("tags: must have at most 2 items"))
+		g.Expect(err.Error()).To(ContainSubstring("tags[1]: must be at least 2 characters"))
+	})
+
+	t.Run("checks required messages", func(t *testing.T) {
+		g := NewWithT(t)
+		fields := valid()
+		delete(fields, "address")
+		err := protovalidate.Validate(newMessage(s.user, fields))
+		g.Expect(err).To(MatchError("address: required"))
+	})
+
+	t.Run("validates nested messages", func(t *testing.T) {
+		g := NewWithT(t)
+		fields := valid()
+		fields["address"] = values{}
+		fields["previous"] = []values{{"city": "Lyon"}, {}}
+		err := protovalidate.Validate(newMessage(s.user, fields))
+		g.Expect(err).To(HaveOccurred())
+		g.Expect(err.Error()).To(ContainSubstring("address: city: required"))
+		g.Expect(err.Error()).To(ContainSubstring("previous[1]: city: required"))
+		g.Expect(err.Error()).ToNot(ContainSubstring("previous[0]"))
+	})
+
+	t.Run("skips nested messages when requested", func(t *testing.T) {
+		g := NewWithT(t)
+		fields := valid()
+		fields["draft"] = values{}
+		g.Expect(protovalidate.Validate(newMessage(s.user, fields))).To(Succeed())
+	})
+
+	t.Run("reads protoc-gen-validate rules", func(t *testing.T) {
+		g := NewWithT(t)
+		fields := valid()
+		fields["nickname"] = "J"
+		err := protovalidate.Validate(newMessage(s.user, fields))
+		g.Expect(err).To(MatchError("nickname: must be at least 2 characters"))
+	})
+
+	t.Run("reports unsupported rules as system errors", func(t *testing.T) {
+		g := NewWithT(t)
+		err := protovalidate.Validate(newMessage(s.bad, values{"email": "john@example.com"}))
+		g.Expect(err).To(MatchError(ContainSubstring(`unsupported rule "string.email"`)))
+		g.Expect(validation.IsValidationError(err)).To(BeFalse())
+	})
+}
+
+func TestFor(t *testing.T) {
+	g := NewWithT(t)
+	validator, err := protovalidate.For[*wrapperspb.StringValue]()
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(validation.Validate(wrapperspb.String("value"), validator)).To(Succeed())
+}
@@ -0,0 +1,407 @@
+package protovalidate
+
+import (
+	"cmp"
+	"fmt"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/quantumcycle/protego/validation"
+)
+
+// Option extensions holding field constraints.
+const (
+	bufExtension protoreflect.FullName = "buf.validate.field"
+	pgvExtension protoreflect.FullName = "validate.rules"
+)
+
+// fieldRule holds the translated constraints of a field.
+type fieldRule struct {
+	fd       protoreflect.FieldDescriptor
+	required bool
+	// skip disables validation of the nested message.
+	skip bool
+	// check validates the field value; for repeated and map fields, the whole collection.
+	check func(protoreflect.Value) error
+	// items validates each element of a repeated field.
+	items func(protoreflect.Value) error
+}
+
+func compileMessage(md protoreflect.MessageDescriptor) ([]fieldRule, error) {
+	var rules []fieldRule
+	fields := md.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		constraints := fieldConstraints(fd)
+		valueMessage := fd.Message()
+		if fd.IsMap() {
+			valueMessage = fd.MapValue().Message()
+		}
+		if constraints == nil && valueMessage == nil {
+			continue
+		}
+		rule := fieldRule{fd: fd}
+		if constraints != nil {
+			if err := rule.compile(constraints); err != nil {
+				return nil, fmt.Errorf("%s: %w", fd.FullName(), err)
+			}
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// fieldConstraints returns the constraint option declared on fd, or nil.
+func fieldConstraints(fd protoreflect.FieldDescriptor) protoreflect.Message {
+	options := fd.Options()
+	if options == nil {
+		return nil
+	}
+	var constraints protoreflect.Message
+	options.ProtoReflect().Range(func(xd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		if xd.IsExtension() && (xd.FullName() == bufExtension || xd.FullName() == pgvExtension) {
+			constraints = v.Message()
+			return false
+		}
+		return true
+	})
+	return constraints
+}
+
+func (r *fieldRule) compile(constraints protoreflect.Message) error {
+	var err error
+	rangeRules(constraints, func(xd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch name := xd.Name(); {
+		case name == "required":
+			r.required = v.Bool()
+		case name == "message":
+			err = r.compileMessageRules(v.Message())
+		case name == "repeated" && r.fd.IsList():
+			r.check, r.items, err = compileRepeated(r.fd, v.Message())
+		case name == "map" && r.fd.IsMap():
+			r.check, err = compileMap(v.Message())
+		case r.fd.IsList() || r.fd.IsMap():
+			err = fmt.Errorf("unsupported rule %q on a collection", name)
+		default:
+			r.check, err = compileValue(r.fd, name, v.Message())
+		}
+		return err == nil
+	})
+	return err
+}
+
+// compileMessageRules translates protoc-gen-validate message rules.
+func (r *fieldRule) compileMessageRules(rules protoreflect.Message) error {
+	var err error
+	rangeRules(rules, func(xd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch xd.Name() {
+		case "required":
+			r.required = v.Bool()
+		case "skip":
+			r.skip = v.Bool()
+		default:
+			err = unsupported("message", xd.Name())
+		}
+		return err == nil
+	})
+	return err
+}
+
+func compileRepeated(fd protoreflect.FieldDescriptor, rules protoreflect.Message) (check, items func(protoreflect.Value) error, err error) {
+	var validators []validation.Validator[[]any]
+	rangeRules(rules, func(xd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch xd.Name() {
+		case "min_items":
+			validators = append(validators, validation.MinItems[any](int(v.Uint())))
+		case "max_items":
+			validators = append(validators, validation.MaxItems[any](int(v.Uint())))
+		case "unique":
+			if fd.Kind() == protoreflect.BytesKind || fd.Message() != nil {
+				err = fmt.Errorf("unsupported rule \"repeated.unique\" on %s elements", fd.Kind())
+			} else if v.Bool() {
+				validators = append(validators, validation.UniqueItems[any]())
+			}
+		case "items":
+			items, err = compileItems(fd, v.Message())
+		default:
+			err = unsupported("repeated", xd.Name())
+		}
+		return err == nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	check = func(value protoreflect.Value) error {
+		list := value.List()
+		elements := make([]any, list.Len())
+		for i := range elements {
+			elements[i] = list.Get(i).Interface()
+		}
+		return validation.Validate(elements, validators...)
+	}
+	return check, items, nil
+}
+
+// compileItems translates the constraints applied to each element of a repeated field.
+func compileItems(fd protoreflect.FieldDescriptor, constraints protoreflect.Message) (func(protoreflect.Value) error, error) {
+	var checks []func(protoreflect.Value) error
+	var err error
+	rangeRules(constraints, func(xd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		var check func(protoreflect.Value) error
+		check, err = compileValue(fd, xd.Name(), v.Message())
+		checks = append(checks, check)
+		return err == nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return all(checks), nil
+}
+
+func compileMap(rules protoreflect.Message) (func(protoreflect.Value) error, error) {
+	var checks []func(protoreflect.Value) error
+	var err error
+	rangeRules(rules, func(xd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		limit := int(v.Uint())
+		switch xd.Name() {
+		case "min_pairs":
+			checks = append(checks, func(value protoreflect.Value) error {
+				if value.Map().Len() < limit {
+					return validation.NewValidationError(fmt.Sprintf("must have at least %d entries", limit))
+				}
+				return nil
+			})
+		case "max_pairs":
+			checks = append(checks, func(value protoreflect.Value) error {
+				if value.Map().Len() > limit {
+					return validation.NewValidationError(fmt.Sprintf("must have at most %d entries", limit))
+				}
+				return nil
+			})
+		default:
+			err = unsupported("map", xd.Name())
+		}
+		return err == nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return all(checks), nil
+}
+
+// compileValue translates the type rules named kind, such as "string" or "int32", for a
+// single value of fd.
+func compileValue(fd protoreflect.FieldDescriptor, kind protoreflect.Name, rules protoreflect.Message) (func(protoreflect.Value) error, error) {
+	if kind != protoreflect.Name(fd.Kind().String()) {
+		return nil, fmt.Errorf("unsupported rule %q on a %s field", kind, fd.Kind())
+	}
+	switch fd.Kind() {
+	case protoreflect.StringKind:
+		return compileString(rules)
+	case protoreflect.BytesKind:
+		return compileBytes(rules)
+	case protoreflect.BoolKind:
+		return compileBool(rules)
+	case protoreflect.EnumKind:
+		return compileEnum(fd.Enum(), rules)
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return compileNumber(string(kind), rules, protoreflect.Value.Int, cmp.Compare[int64])
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind, protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return compileNumber(string(kind), rules, protoreflect.Value.Uint, cmp.Compare[uint64])
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		return compileNumber(string(kind), rules, protoreflect.Value.Float, cmp.Compare[float64])
+	default:
+		return nil, fmt.Errorf("unsupported rule %q", kind)
+	}
+}
+
+func compileString(rules protoreflect.Message) (func(protoreflect.Value) error, error) {
+	var validators []validation.Validator[string]
+	var err error
+	rangeRules(rules, func(xd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch xd.Name() {
+		case "const":
+			validators = append(validators, validation.In(false, v.String()))
+		case "len":
+			validators = append(validators, validation.Length(int(v.Uint()), int(v.Uint())))
+		case "min_len":
+			validators = append(validators, validation.MinLength(int(v.Uint())))
+		case "max_len":
+			validators = append(validators, validation.MaxLength(int(v.Uint())))
+		case "pattern":
+			validators = append(validators, validation.MatchesPattern(v.String()))
+		case "prefix":
+			validators = append(validators, validation.StartsWith(v.String()))
+		case "suffix":
+			validators = append(validators, validation.EndsWith(v.String()))
+		case "contains":
+			validators = append(validators, validation.Contains(v.String()))
+		case "in":
+			validators = append(validators, validation.In(false, listOf(v.List(), protoreflect.Value.String)...))
+		case "not_in":
+			validators = append(validators, validation.NotIn(false, listOf(v.List(), protoreflect.Value.String)...))
+		default:
+			err = unsupported("string", xd.Name())
+		}
+		return err == nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return func(value protoreflect.Value) error {
+		return validation.Validate(value.String(), validators...)
+	}, nil
+}
+
+func compileBytes(rules protoreflect.Message) (func(protoreflect.Value) error, error) {
+	var checks []func(protoreflect.Value) error
+	var err error
+	rangeRules(rules, func(xd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		limit := int(v.Uint())
+		switch xd.Name() {
+		case "min_len":
+			checks = append(checks, func(value protoreflect.Value) error {
+				if len(value.Bytes()) < limit {
+					return validation.NewValidationError(fmt.Sprintf("must be at least %d bytes", limit))
+				}
+				return nil
+			})
+		case "max_len":
+			checks = append(checks, func(value protoreflect.Value) error {
+				if len(value.Bytes()) > limit {
+					return validation.NewValidationError(fmt.Sprintf("must be at most %d bytes", limit))
+				}
+				return nil
+			})
+		default:
+			err = unsupported("bytes", xd.Name())
+		}
+		return err == nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return all(checks), nil
+}
+
+func compileBool(rules protoreflect.Message) (func(protoreflect.Value) error, error) {
+	var validators []validation.Validator[bool]
+	var err error
+	rangeRules(rules, func(xd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		if xd.Name() != "const" {
+			err = unsupported("bool", xd.Name())
+			return false
+		}
+		validators = append(validators, validation.In(false, v.Bool()))
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return func(value protoreflect.Value) error {
+		return validation.Validate(value.Bool(), validators...)
+	}, nil
+}
+
+func compileEnum(ed protoreflect.EnumDescriptor, rules protoreflect.Message) (func(protoreflect.Value) error, error) {
+	check, err := compileNumber("enum", rules, protoreflect.Value.Enum, cmp.Compare[protoreflect.EnumNumber])
+	if err != nil {
+		return nil, err
+	}
+	definedOnly := rules.Descriptor().Fields().ByName("defined_only")
+	if definedOnly == nil || !rules.Get(definedOnly).Bool() {
+		return check, nil
+	}
+	return all([]func(protoreflect.Value) error{
+		func(value protoreflect.Value) error {
+			if ed.Values().ByNumber(value.Enum()) == nil {
+				return validation.NewValidationError("must be a defined enum value")
+			}
+			return nil
+		},
+		check,
+	}), nil
+}
+
+// compileNumber translates comparison rules (const, lt, lte, gt, gte, in, not_in), reading
+// values with get.
+func compileNumber[T comparable](kind string, rules protoreflect.Message, get func(protoreflect.Value) T, compare func(a, b T) int) (func(protoreflect.Value) error, error) {
+	var validators []validation.Validator[T]
+	var err error
+	rangeRules(rules, func(xd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch xd.Name() {
+		case "const":
+			validators = append(validators, validation.In(false, get(v)))
+		case "in":
+			validators = append(validators, validation.In(false, listOf(v.List(), get)...))
+		case "not_in":
+			validators = append(validators, validation.NotIn(false, listOf(v.List(), get)...))
+		case "lt":
+			validators = append(validators, bound(get(v), "less than", func(c int) bool { return c < 0 }, compare))
+		case "lte":
+			validators = append(validators, bound(get(v), "at most", func(c int) bool { return c <= 0 }, compare))
+		case "gt":
+			validators = append(validators, bound(get(v), "greater than", func(c int) bool { return c > 0 }, compare))
+		case "gte":
+			validators = append(validators, bound(get(v), "at least", func(c int) bool { return c >= 0 }, compare))
+		case "defined_only":
+			// Checked by compileEnum.
+		default:
+			err = unsupported(kind, xd.Name())
+		}
+		return err == nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return func(value protoreflect.Value) error {
+		return validation.Validate(get(value), validators...)
+	}, nil
+}
+
+// bound validates that compare(value, limit) satisfies ok.
+func bound[T any](limit T, description string, ok func(int) bool, compare func(a, b T) int) validation.Validator[T] {
+	return func(v T) error {
+		if !ok(compare(v, limit)) {
+			return validation.NewValidationError(fmt.Sprintf("must be %s %v", description, limit))
+		}
+		return nil
+	}
+}
+
+func listOf[T any](list protoreflect.List, get func(protoreflect.Value) T) []T {
+	values := make([]T, list.Len())
+	for i := range values {
+		values[i] = get(list.Get(i))
+	}
+	return values
+}
+
+// all combines checks, returning the first error.
+func all(checks []func(protoreflect.Value) error) func(protoreflect.Value) error {
+	return func(value protoreflect.Value) error {
+		for _, check := range checks {
+			if err := check(value); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// rangeRules calls f for each populated field of rules in declaration order, so rules
+// are checked in a stable order, until f returns false.
+func rangeRules(rules protoreflect.Message, f func(protoreflect.FieldDescriptor, protoreflect.Value) bool) {
+	fields := rules.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		if rules.Has(fd) && !f(fd, rules.Get(fd)) {
+			return
+		}
+	}
+}
+
+func unsupported(kind string, rule protoreflect.Name) error {
+	return fmt.Errorf("unsupported rule \"%s.%s\"", kind, rule)
+}
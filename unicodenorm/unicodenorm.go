@@ -0,0 +1,65 @@
+// Package unicodenorm validates and normalizes the Unicode normalization form of strings,
+// so that identifiers differing only in form, which look identical, are not both accepted.
+// It depends on golang.org/x/text, which the core validation package does not.
+//
+//	err := validation.Validate(input.Username, unicodenorm.IsNFKC())
+//
+//	validation.MapKey("name", true, unicodenorm.IsNFC()).Normalize(unicodenorm.NFC)
+package unicodenorm
+
+import (
+	"fmt"
+
+	"golang.org/x/text/unicode/norm"
+
+	"github.com/quantumcycle/protego/validation"
+)
+
+// IsNFC validates that a string is in Unicode normalization form NFC, where characters are
+// composed: "é" is one code point, not "e" followed by a combining accent. Identifiers
+// differing only in normalization form look identical, so requiring one form prevents
+// duplicate accounts. Use NFC to normalize values instead of rejecting them.
+//
+// Example:
+//
+//	validation.Validate(username, unicodenorm.IsNFC())
+func IsNFC() validation.Validator[string] {
+	return isNormalized(norm.NFC, "NFC")
+}
+
+// IsNFKC validates that a string is in Unicode normalization form NFKC, which also replaces
+// compatibility characters with their canonical equivalent, such as fullwidth "Ａ" with "A"
+// or the ligature "ﬁ" with "fi". It is the stricter form, for identifiers such as
+// usernames. Use NFKC to normalize values instead of rejecting them.
+//
+// Example:
+//
+//	validation.Validate(username, unicodenorm.IsNFKC())
+func IsNFKC() validation.Validator[string] {
+	return isNormalized(norm.NFKC, "NFKC")
+}
+
+func isNormalized(form norm.Form, name string) validation.Validator[string] {
+	msg := fmt.Sprintf("must be in Unicode normalization form %s", name)
+	return func(v string) error {
+		if !form.IsNormalString(v) {
+			return validation.NewValidationError(msg)
+		}
+		return nil
+	}
+}
+
+// NFC is a validation.Normalizer converting s to Unicode normalization form NFC (see IsNFC).
+//
+// Example:
+//
+//	validation.MapKey("name", true, unicodenorm.IsNFC()).Normalize(unicodenorm.NFC)
+func NFC(s string) string {
+	return norm.NFC.String(s)
+}
+
+// NFKC is a validation.Normalizer converting s to Unicode normalization form NFKC (see
+// IsNFKC).
+func NFKC(s string) string {
+	return norm.NFKC.String(s)
+}
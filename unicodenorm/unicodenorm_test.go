@@ -0,0 +1,42 @@
+package unicodenorm_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/quantumcycle/protego/unicodenorm"
+	"github.com/quantumcycle/protego/validation"
+)
+
+func TestUnicodeNormalization(t *testing.T) {
+	composed, decomposed := "José", "José"
+
+	t.Run("IsNFC requires composed characters", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(validation.Validate(composed, unicodenorm.IsNFC())).To(Succeed())
+		g.Expect(validation.Validate("ﬁle", unicodenorm.IsNFC())).To(Succeed())
+		g.Expect(validation.Validate(decomposed, unicodenorm.IsNFC())).To(MatchError("must be in Unicode normalization form NFC"))
+	})
+
+	t.Run("IsNFKC also requires compatibility characters to be replaced", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(validation.Validate(composed, unicodenorm.IsNFKC())).To(Succeed())
+		g.Expect(validation.Validate("ﬁle", unicodenorm.IsNFKC())).To(MatchError("must be in Unicode normalization form NFKC"))
+		g.Expect(validation.Validate("ＡＢＣ", unicodenorm.IsNFKC())).To(HaveOccurred())
+		g.Expect(validation.Validate(decomposed, unicodenorm.IsNFKC())).To(HaveOccurred())
+	})
+
+	t.Run("normalizes strings", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(unicodenorm.NFC(decomposed)).To(Equal(composed))
+		g.Expect(unicodenorm.NFKC("ﬁle ＡＢＣ")).To(Equal("file ABC"))
+		g.Expect(validation.Validate(unicodenorm.NFKC("ＡＢＣ"), unicodenorm.IsNFKC())).To(Succeed())
+	})
+
+	t.Run("prevents duplicates differing in normalization form", func(t *testing.T) {
+		g := NewWithT(t)
+		taken := validation.NotInNormalized(unicodenorm.NFKC, composed)
+		g.Expect(validation.Validate(decomposed, taken)).To(MatchError(`cannot be "José"`))
+	})
+}
@@ -0,0 +1,45 @@
+// Package uuidvalidate provides validators for github.com/google/uuid values.
+//
+//	err := validation.ValidateStruct(
+//	    validation.Field(in, &in.ID, uuidvalidate.NotNilUUID(), uuidvalidate.IsUUIDVersion(4)),
+//	)
+//
+// To validate UUIDs held in strings, use validation.IsUUIDString, or parse them with
+// coerce.ToUUID to apply these validators.
+package uuidvalidate
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/quantumcycle/protego/validation"
+)
+
+// IsUUIDVersion validates that a UUID has the given version and the RFC 4122 variant.
+//
+// Example:
+//
+//	validation.Validate(id, uuidvalidate.IsUUIDVersion(4))
+func IsUUIDVersion(version uuid.Version) validation.Validator[uuid.UUID] {
+	return func(v uuid.UUID) error {
+		if v.Version() != version || v.Variant() != uuid.RFC4122 {
+			return validation.NewValidationError(fmt.Sprintf("must be a valid UUID v%d", version))
+		}
+		return nil
+	}
+}
+
+// NotNilUUID validates that a UUID is not the nil UUID (all zeros).
+//
+// Example:
+//
+//	validation.Validate(id, uuidvalidate.NotNilUUID())
+func NotNilUUID() validation.Validator[uuid.UUID] {
+	return func(v uuid.UUID) error {
+		if v == uuid.Nil {
+			return validation.NewValidationError("must not be the nil UUID")
+		}
+		return nil
+	}
+}
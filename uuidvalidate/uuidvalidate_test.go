@@ -0,0 +1,37 @@
+package uuidvalidate_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/gomega"
+
+	"github.com/quantumcycle/protego/uuidvalidate"
+	"github.com/quantumcycle/protego/validation"
+)
+
+func TestIsUUIDVersion(t *testing.T) {
+	t.Run("passes with the expected version", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(validation.Validate(uuid.New(), uuidvalidate.IsUUIDVersion(4))).To(Succeed())
+		g.Expect(validation.Validate(uuid.Must(uuid.NewV7()), uuidvalidate.IsUUIDVersion(7))).To(Succeed())
+	})
+
+	t.Run("fails with another version", func(t *testing.T) {
+		g := NewWithT(t)
+		err := validation.Validate(uuid.Must(uuid.NewV7()), uuidvalidate.IsUUIDVersion(4))
+		g.Expect(err).To(MatchError("must be a valid UUID v4"))
+		g.Expect(validation.IsValidationError(err)).To(BeTrue())
+	})
+
+	t.Run("fails with the nil UUID", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(validation.Validate(uuid.Nil, uuidvalidate.IsUUIDVersion(4))).To(MatchError("must be a valid UUID v4"))
+	})
+}
+
+func TestNotNilUUID(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(validation.Validate(uuid.New(), uuidvalidate.NotNilUUID())).To(Succeed())
+	g.Expect(validation.Validate(uuid.Nil, uuidvalidate.NotNilUUID())).To(MatchError("must not be the nil UUID"))
+}
@@ -0,0 +1,80 @@
+package validation
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// majorityAges lists the countries, by ISO 3166-1 alpha-2 code, whose age of majority is
+// not 18. Federal countries where it varies by state use the most common age.
+var majorityAges = map[string]int{
+	"AE": 21, // United Arab Emirates
+	"BH": 21, // Bahrain
+	"CI": 21, // Côte d'Ivoire
+	"CM": 21, // Cameroon
+	"DZ": 19, // Algeria
+	"EG": 21, // Egypt
+	"GA": 21, // Gabon
+	"KR": 19, // South Korea
+	"KW": 21, // Kuwait
+	"LS": 21, // Lesotho
+	"MG": 21, // Madagascar
+	"NZ": 20, // New Zealand
+	"PR": 21, // Puerto Rico
+	"SG": 21, // Singapore
+	"SZ": 21, // Eswatini
+	"TH": 20, // Thailand
+}
+
+// LegalAge returns the age of majority of a country given by its ISO 3166-1 alpha-2 code,
+// such as 18 for "FR", 19 for "KR" or 21 for "SG". Codes are case-insensitive and
+// surrounding whitespace is ignored, so "kr" is 19 as well. Countries not known to differ,
+// including unknown codes, use 18.
+func LegalAge(countryCode string) int {
+	if age, ok := majorityAges[strings.ToUpper(strings.TrimSpace(countryCode))]; ok {
+		return age
+	}
+	return 18
+}
+
+// IsOfLegalAge validates that a birthdate makes the person at least the age of majority of
+// the country (see LegalAge). People born on February 29 come of age on March 1 in common years.
+//
+// Example:
+//
+//	validation.Validate(user.BirthDate, validation.IsOfLegalAge(user.Country))
+func IsOfLegalAge(countryCode string) Validator[time.Time] {
+	age := LegalAge(countryCode)
+	return func(birth time.Time) error {
+		if birth.AddDate(age, 0, 0).After(time.Now()) {
+			return NewValidationError(fmt.Sprintf("must be at least %d years old", age))
+		}
+		return nil
+	}
+}
+
+// IsOfLegalAgeDateFormat is IsOfLegalAge for a birthdate string parsed with layout.
+//
+// Example:
+//
+//	validation.Validate(input.BirthDate, validation.IsOfLegalAgeDateFormat("KR", "02/01/2006"))
+func IsOfLegalAgeDateFormat(countryCode, layout string) Validator[string] {
+	isOfLegalAge := IsOfLegalAge(countryCode)
+	return func(v string) error {
+		birth, err := time.Parse(layout, v)
+		if err != nil {
+			return NewValidationError("invalid date format")
+		}
+		return isOfLegalAge(birth)
+	}
+}
+
+// IsOfLegalAgeDate is IsOfLegalAge for a birthdate string in ISO8601 date format (YYYY-MM-DD).
+//
+// Example:
+//
+//	validation.Validate(input.BirthDate, validation.IsOfLegalAgeDate(input.Country))
+func IsOfLegalAgeDate(countryCode string) Validator[string] {
+	return IsOfLegalAgeDateFormat(countryCode, "2006-01-02")
+}
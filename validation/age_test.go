@@ -0,0 +1,49 @@
+package validation_test
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/quantumcycle/protego/validation"
+)
+
+func TestLegalAge(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(validation.LegalAge("FR")).To(Equal(18))
+	g.Expect(validation.LegalAge("KR")).To(Equal(19))
+	g.Expect(validation.LegalAge("SG")).To(Equal(21))
+	g.Expect(validation.LegalAge("kr")).To(Equal(19))
+	g.Expect(validation.LegalAge(" Sg ")).To(Equal(21))
+	g.Expect(validation.LegalAge("XX")).To(Equal(18))
+}
+
+func TestIsOfLegalAge(t *testing.T) {
+	now := time.Now()
+
+	t.Run("uses the age of majority of the country", func(t *testing.T) {
+		g := NewWithT(t)
+		nineteen := now.AddDate(-19, 0, -1)
+		g.Expect(validation.Validate(nineteen, validation.IsOfLegalAge("FR"))).To(Succeed())
+		g.Expect(validation.Validate(nineteen, validation.IsOfLegalAge("KR"))).To(Succeed())
+		g.Expect(validation.Validate(nineteen, validation.IsOfLegalAge("SG"))).To(MatchError("must be at least 21 years old"))
+		g.Expect(validation.Validate(nineteen, validation.IsOfLegalAge("sg"))).To(MatchError("must be at least 21 years old"))
+	})
+
+	t.Run("comes of age on the birthday", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(validation.Validate(now.AddDate(-18, 0, 0), validation.IsOfLegalAge("FR"))).To(Succeed())
+		g.Expect(validation.Validate(now.AddDate(-18, 0, 1), validation.IsOfLegalAge("FR"))).To(MatchError("must be at least 18 years old"))
+	})
+
+	t.Run("parses birthdate strings", func(t *testing.T) {
+		g := NewWithT(t)
+		adult := now.AddDate(-30, 0, 0)
+		minor := now.AddDate(-10, 0, 0)
+		g.Expect(validation.Validate(adult.Format("2006-01-02"), validation.IsOfLegalAgeDate("US"))).To(Succeed())
+		g.Expect(validation.Validate(minor.Format("2006-01-02"), validation.IsOfLegalAgeDate("US"))).To(MatchError("must be at least 18 years old"))
+		g.Expect(validation.Validate(adult.Format("02/01/2006"), validation.IsOfLegalAgeDateFormat("US", "02/01/2006"))).To(Succeed())
+		g.Expect(validation.Validate("31/02/2000", validation.IsOfLegalAgeDateFormat("US", "02/01/2006"))).To(MatchError("invalid date format"))
+	})
+}
@@ -0,0 +1,104 @@
+package validation_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/quantumcycle/protego/validation"
+)
+
+// Benchmarks of hot validators, run with: go test -bench . -benchmem ./validation
+
+func skuCodes(n int) []string {
+	codes := make([]string, n)
+	for i := range codes {
+		codes[i] = fmt.Sprintf("SKU-%04d", i)
+	}
+	return codes
+}
+
+func BenchmarkIn(b *testing.B) {
+	for _, n := range []int{5, 500} {
+		codes := skuCodes(n)
+		last := codes[n-1]
+		b.Run(fmt.Sprintf("%d/case-sensitive", n), func(b *testing.B) {
+			in := validation.In(false, codes...)
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				_ = in(last)
+			}
+		})
+		b.Run(fmt.Sprintf("%d/case-insensitive", n), func(b *testing.B) {
+			in := validation.In(true, codes...)
+			lowered := strings.ToLower(last)
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				_ = in(lowered)
+			}
+		})
+	}
+}
+
+func BenchmarkNotIn(b *testing.B) {
+	notIn := validation.NotIn(true, "admin", "root", "system", "support", "postmaster")
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = notIn("alice")
+	}
+}
+
+func BenchmarkMatchesPattern(b *testing.B) {
+	b.Run("constructed once", func(b *testing.B) {
+		matches := validation.MatchesPattern(`^[A-Z]{3}-\d{4}$`)
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = matches("ABC-1234")
+		}
+	})
+	b.Run("constructed per call", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = validation.Validate("ABC-1234", validation.MatchesPattern(`^[A-Z]{3}-\d{4}$`))
+		}
+	})
+}
+
+func BenchmarkValidateAnyMap(b *testing.B) {
+	m := map[string]any{"name": "widget", "price": 9.99, "quantity": float64(3), "active": true}
+	rules := []validation.MapKeyRule[any]{
+		validation.MapKey("name", true, validation.StringValidator(validation.MinLength(3))),
+		validation.MapKey("price", true, validation.FloatValidator(validation.Min(0.0))),
+		validation.MapKey("quantity", true, validation.IntValidator(validation.Range(1, 100))),
+		validation.MapKey("active", false, validation.BoolValidator(func(bool) error { return nil })),
+	}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = validation.ValidateAnyMap(m, false, rules...)
+	}
+}
+
+func BenchmarkValidate(b *testing.B) {
+	validators := []validation.Validator[string]{
+		validation.Required[string](),
+		validation.MinLength(3),
+		validation.MaxLength(64),
+		validation.Contains("@"),
+	}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = validation.Validate("ada@example.com", validators...)
+	}
+}
+
+func BenchmarkEach(b *testing.B) {
+	values := make([]int, 1000)
+	for i := range values {
+		values[i] = i - 500 // half of the elements fail
+	}
+	each := validation.Each(validation.Min(0))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = each(values)
+	}
+}
@@ -0,0 +1,93 @@
+package validation
+
+import "fmt"
+
+// CodeBudgetExceeded is the code of the errors of payloads exceeding a Budget.
+const CodeBudgetExceeded = "budget_exceeded"
+
+// Budget bounds the resources a single validation of an untrusted payload may consume, so a
+// hostile payload cannot take a disproportionate share of the CPU. Zero fields are not
+// limited. Payloads exceeding the budget are rejected before any rule runs, with a
+// validation error of code CodeBudgetExceeded.
+type Budget struct {
+	// MaxDepth is the maximum nesting of maps and slices, as counted by MaxDepth.
+	MaxDepth int
+	// MaxBytes is the maximum JSON-encoded size of the payload, as counted by MaxTotalSize.
+	MaxBytes int
+	// MaxRegexExecutions is the maximum number of values matched by rules created by
+	// PatternRule, including those of EachRule and nested schemas.
+	MaxRegexExecutions int
+}
+
+// WithinBudget validates that a decoded JSON document does not exceed the depth and size of
+// budget. Like MaxDepth and MaxTotalSize, traversal stops as soon as a limit is exceeded.
+//
+// Example:
+//
+//	validation.Validate(payload, validation.WithinBudget[map[string]any](validation.Budget{MaxDepth: 16, MaxBytes: 1 << 20}))
+func WithinBudget[T any](budget Budget) Validator[T] {
+	return func(v T) error {
+		return budget.check(any(v))
+	}
+}
+
+// WithBudget returns a copy of the schema rejecting payloads exceeding budget before
+// applying its rules. Regex executions are counted on the payload before validating it:
+// every value of a key present matched by a PatternRule, every element of an array matched
+// by EachRule(PatternRule(...)), and the executions of nested schemas, such as those of
+// SchemaRule.
+//
+// Example:
+//
+//	var commentSchema = validation.NewSchema(...).WithBudget(validation.Budget{MaxBytes: 64 * 1024, MaxRegexExecutions: 20})
+func (s *Schema) WithBudget(budget Budget) *Schema {
+	c := s.clone()
+	c.budget = budget
+	return c
+}
+
+func (b Budget) check(v any) error {
+	if b.MaxDepth > 0 && exceedsDepth(v, b.MaxDepth) {
+		return budgetExceeded(fmt.Sprintf("must not be nested deeper than %d levels", b.MaxDepth), b.MaxDepth)
+	}
+	if b.MaxBytes > 0 && encodedSize(v, b.MaxBytes) > b.MaxBytes {
+		return budgetExceeded(fmt.Sprintf("must not exceed %d bytes", b.MaxBytes), b.MaxBytes)
+	}
+	return nil
+}
+
+// checkBudget checks m against the budget of the schema, counting the regex executions
+// validating it would take.
+func (s *Schema) checkBudget(m map[string]any) error {
+	if err := s.budget.check(m); err != nil {
+		return err
+	}
+	if s.budget.MaxRegexExecutions <= 0 {
+		return nil
+	}
+	if s.regexExecutions(m) > s.budget.MaxRegexExecutions {
+		return budgetExceeded(fmt.Sprintf("must not require more than %d pattern matches", s.budget.MaxRegexExecutions), s.budget.MaxRegexExecutions)
+	}
+	return nil
+}
+
+// regexExecutions returns the number of regex executions validating m would take.
+func (s *Schema) regexExecutions(m map[string]any) int {
+	executions := 0
+	for _, k := range s.keys {
+		v, exists := m[k.name]
+		if !exists {
+			continue
+		}
+		for _, r := range k.rules {
+			if r.regexes != nil {
+				executions += r.regexes(v)
+			}
+		}
+	}
+	return executions
+}
+
+func budgetExceeded(msg string, maximum int) error {
+	return NewCodedError(CodeBudgetExceeded, msg, map[string]any{"max": maximum})
+}
@@ -0,0 +1,123 @@
+package validation_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/quantumcycle/protego/validation"
+)
+
+func TestBudget(t *testing.T) {
+	nested := func(depth int) any {
+		var doc any = "leaf"
+		for i := 0; i < depth; i++ {
+			doc = map[string]any{"child": doc}
+		}
+		return doc
+	}
+	code := func(err error) string {
+		var valErr *validation.Error
+		if !errors.As(err, &valErr) {
+			return ""
+		}
+		return valErr.Code()
+	}
+
+	t.Run("accepts documents within budget", func(t *testing.T) {
+		g := NewWithT(t)
+		within := validation.WithinBudget[any](validation.Budget{MaxDepth: 5, MaxBytes: 1024})
+		g.Expect(within(nested(5))).To(Succeed())
+		g.Expect(validation.WithinBudget[any](validation.Budget{})(nested(100))).To(Succeed())
+	})
+
+	t.Run("rejects documents nested too deep", func(t *testing.T) {
+		g := NewWithT(t)
+		err := validation.WithinBudget[any](validation.Budget{MaxDepth: 5})(nested(6))
+		g.Expect(err).To(MatchError("must not be nested deeper than 5 levels"))
+		g.Expect(validation.IsValidationError(err)).To(BeTrue())
+		g.Expect(code(err)).To(Equal(validation.CodeBudgetExceeded))
+	})
+
+	t.Run("rejects documents too large", func(t *testing.T) {
+		g := NewWithT(t)
+		doc := map[string]any{"body": strings.Repeat("a", 2048)}
+		err := validation.WithinBudget[map[string]any](validation.Budget{MaxBytes: 1024})(doc)
+		g.Expect(err).To(MatchError("must not exceed 1024 bytes"))
+		g.Expect(code(err)).To(Equal(validation.CodeBudgetExceeded))
+	})
+
+	t.Run("bounds the validation of schemas", func(t *testing.T) {
+		g := NewWithT(t)
+		calls := 0
+		counted := validation.NewRule("counted", nil, func(any) error {
+			calls++
+			return nil
+		})
+		schema := validation.NewSchema(
+			validation.Key("a", validation.PatternRule("^[a-z]+$"), counted),
+			validation.Key("b", validation.PatternRule("^[a-z]+$")),
+			validation.Key("c", validation.PatternRule("^[a-z]+$")),
+		).AllowExtra().WithBudget(validation.Budget{MaxBytes: 100, MaxRegexExecutions: 2})
+
+		g.Expect(schema.Validate(map[string]any{"a": "x", "b": "y"})).To(Succeed())
+		g.Expect(calls).To(Equal(1))
+
+		err := schema.Validate(map[string]any{"a": "x", "b": "y", "c": "z"})
+		g.Expect(err).To(MatchError("must not require more than 2 pattern matches"))
+		g.Expect(code(err)).To(Equal(validation.CodeBudgetExceeded))
+
+		g.Expect(schema.Validate(map[string]any{"a": "x", "d": strings.Repeat("a", 200)})).To(MatchError("must not exceed 100 bytes"))
+		g.Expect(calls).To(Equal(1))
+	})
+
+	t.Run("counts the pattern matches of arrays and nested schemas", func(t *testing.T) {
+		g := NewWithT(t)
+		tags := func(n int) []any {
+			elements := make([]any, n)
+			for i := range elements {
+				elements[i] = "tag"
+			}
+			return elements
+		}
+		address := validation.NewSchema(
+			validation.Key("zip", validation.PatternRule("^[0-9]{5}$")),
+			validation.Key("tags", validation.EachRule(validation.PatternRule("^[a-z]+$"))),
+		)
+		schema := validation.NewSchema(
+			validation.Key("tags", validation.EachRule(validation.PatternRule("^[a-z]+$"))),
+			validation.Key("address", validation.SchemaRule(address)),
+			validation.Key("addresses", validation.EachRule(validation.SchemaRule(address))),
+		).WithBudget(validation.Budget{MaxRegexExecutions: 10})
+
+		g.Expect(schema.Validate(map[string]any{"tags": tags(10)})).To(Succeed())
+		err := schema.Validate(map[string]any{"tags": tags(11)})
+		g.Expect(err).To(MatchError("must not require more than 10 pattern matches"))
+		g.Expect(code(err)).To(Equal(validation.CodeBudgetExceeded))
+
+		g.Expect(schema.Validate(map[string]any{
+			"tags":    tags(4),
+			"address": map[string]any{"zip": "12345", "tags": tags(5)},
+		})).To(Succeed())
+		g.Expect(schema.Validate(map[string]any{
+			"address":   map[string]any{"zip": "12345"},
+			"addresses": []any{map[string]any{"zip": "12345", "tags": tags(5)}, map[string]any{"zip": "12345", "tags": tags(3)}},
+		})).To(MatchError("must not require more than 10 pattern matches"))
+	})
+
+	t.Run("counts the pattern matches of recursive schemas", func(t *testing.T) {
+		g := NewWithT(t)
+		category := validation.Recursive(10, func(self validation.Rule) *validation.Schema {
+			return validation.NewSchema(
+				validation.Key("slug", validation.PatternRule("^[a-z-]+$")),
+				validation.Key("children", validation.EachRule(self)),
+			)
+		}).WithBudget(validation.Budget{MaxRegexExecutions: 3})
+
+		child := map[string]any{"slug": "child"}
+		g.Expect(category.Validate(map[string]any{"slug": "root", "children": []any{child, child}})).To(Succeed())
+		g.Expect(category.Validate(map[string]any{"slug": "root", "children": []any{child, child, child}})).To(MatchError("must not require more than 3 pattern matches"))
+	})
+}
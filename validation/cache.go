@@ -0,0 +1,152 @@
+package validation
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"io"
+	"reflect"
+	"sort"
+	"sync"
+)
+
+// WithCache returns a copy of the schema caching the results of the last size distinct
+// payloads it validated, keyed by a hash of their JSON encoding and Go types, for payloads validated
+// repeatedly such as webhook retries and pub/sub redeliveries. Only schemas whose rules
+// are idempotent, giving the same result for the same payload, may be cached: rules
+// looking up a database may not. System errors (see IsSystemError) are not cached. The
+// values of Lenient schemas are normalized before the lookup, so cached payloads are
+// normalized too.
+//
+// The Go types are part of the key because rules may tell apart values that encode alike,
+// such as int64(1), float64(1) and json.Number("1").
+//
+// Hashing encodes the payload, which costs about as much as cheap rules: caching pays off
+// for schemas with expensive rules. Payloads that cannot be encoded, such as those holding
+// NaN, are validated without the cache.
+//
+// Example:
+//
+//	var webhookSchema = validation.NewSchema(...).WithCache(1024)
+func (s *Schema) WithCache(size int) *Schema {
+	c := s.clone()
+	c.cache = newResultCache(size)
+	return c
+}
+
+// clone returns a copy of the schema, with an empty cache of the same size: its results do
+// not apply to the copy, which is about to be changed.
+func (s *Schema) clone() *Schema {
+	c := *s
+	if s.cache != nil {
+		c.cache = newResultCache(s.cache.size)
+	}
+	return &c
+}
+
+// resultCache is an LRU cache of validation results keyed by payload hash.
+type resultCache struct {
+	size int
+
+	mu      sync.Mutex
+	order   *list.List // of *cachedResult, most recently used first
+	entries map[[sha256.Size]byte]*list.Element
+}
+
+type cachedResult struct {
+	key [sha256.Size]byte
+	err error
+}
+
+func newResultCache(size int) *resultCache {
+	return &resultCache{size: size, order: list.New(), entries: make(map[[sha256.Size]byte]*list.Element)}
+}
+
+// validate returns the cached result of validate for m, computing and caching it if needed.
+func (c *resultCache) validate(m map[string]any, validate func(map[string]any) error) error {
+	if c.size <= 0 {
+		return validate(m)
+	}
+	encoded, err := json.Marshal(m)
+	if err != nil {
+		return validate(m)
+	}
+	h := sha256.New()
+	h.Write(encoded)
+	writeTypes(h, reflect.ValueOf(m))
+	var key [sha256.Size]byte
+	h.Sum(key[:0])
+
+	c.mu.Lock()
+	if e, ok := c.entries[key]; ok {
+		c.order.MoveToFront(e)
+		c.mu.Unlock()
+		return e.Value.(*cachedResult).err
+	}
+	c.mu.Unlock()
+
+	err = validate(m)
+	if IsSystemError(err) {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.entries[key]; !ok {
+		c.entries[key] = c.order.PushFront(&cachedResult{key: key, err: err})
+		if c.order.Len() > c.size {
+			oldest := c.order.Back()
+			c.order.Remove(oldest)
+			delete(c.entries, oldest.Value.(*cachedResult).key)
+		}
+	}
+	return err
+}
+
+// writeTypes writes to h the dynamic types of v and of the values it holds, in the order of
+// their JSON encoding.
+func writeTypes(h hash.Hash, v reflect.Value) {
+	if !v.IsValid() {
+		h.Write([]byte{0})
+		return
+	}
+	io.WriteString(h, v.Type().String())
+	h.Write([]byte{0})
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if !v.IsNil() {
+			writeTypes(h, v.Elem())
+		}
+	case reflect.Map:
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool { return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j]) })
+		for _, k := range keys {
+			writeTypes(h, v.MapIndex(k))
+		}
+	case reflect.Slice, reflect.Array:
+		if hasFixedTypes(v.Type().Elem()) {
+			return
+		}
+		for i := 0; i < v.Len(); i++ {
+			writeTypes(h, v.Index(i))
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() {
+				writeTypes(h, v.Field(i))
+			}
+		}
+	}
+}
+
+// hasFixedTypes reports whether all values of type t have the same dynamic type, such as
+// the bytes of a []byte, so that writeTypes need not visit them.
+func hasFixedTypes(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Pointer, reflect.Interface, reflect.Map, reflect.Slice, reflect.Array, reflect.Struct:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,100 @@
+package validation_test
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/quantumcycle/protego/validation"
+)
+
+func TestSchemaCache(t *testing.T) {
+	counted := func(calls *int, err error) validation.Rule {
+		return validation.NewRule("counted", nil, func(any) error {
+			*calls++
+			return err
+		})
+	}
+
+	t.Run("caches results by payload", func(t *testing.T) {
+		g := NewWithT(t)
+		calls := 0
+		schema := validation.NewSchema(validation.Key("name", counted(&calls, nil), validation.MinLengthRule(3))).WithCache(10)
+		g.Expect(schema.Validate(map[string]any{"name": "alice"})).To(Succeed())
+		g.Expect(schema.Validate(map[string]any{"name": "alice"})).To(Succeed())
+		g.Expect(calls).To(Equal(1))
+
+		err := schema.Validate(map[string]any{"name": "al"})
+		g.Expect(schema.Validate(map[string]any{"name": "al"})).To(Equal(err))
+		g.Expect(calls).To(Equal(2))
+	})
+
+	t.Run("tells apart payloads of different types", func(t *testing.T) {
+		g := NewWithT(t)
+		integer := validation.NewRule("int64", nil, func(v any) error {
+			if _, ok := v.(int64); !ok {
+				return validation.NewValidationError("must be an int64")
+			}
+			return nil
+		})
+		schema := validation.NewSchema(validation.Key("n", integer)).WithCache(10)
+		g.Expect(schema.Validate(map[string]any{"n": int64(1)})).To(Succeed())
+		g.Expect(schema.Validate(map[string]any{"n": float64(1)})).To(HaveOccurred())
+		g.Expect(schema.Validate(map[string]any{"n": json.Number("1")})).To(HaveOccurred())
+		g.Expect(schema.Validate(map[string]any{"n": []any{int64(1)}})).To(HaveOccurred())
+		g.Expect(schema.Validate(map[string]any{"n": int64(1)})).To(Succeed())
+	})
+
+	t.Run("normalizes payloads of lenient schemas on cache hits", func(t *testing.T) {
+		g := NewWithT(t)
+		calls := 0
+		schema := validation.NewSchema(validation.Key("name", counted(&calls, nil))).WithStrictness(validation.Lenient).WithCache(10)
+		for i := 0; i < 2; i++ {
+			payload := map[string]any{"name": "  bob  "}
+			g.Expect(schema.Validate(payload)).To(Succeed())
+			g.Expect(payload["name"]).To(Equal("bob"))
+		}
+		g.Expect(schema.Validate(map[string]any{"name": "bob"})).To(Succeed())
+		g.Expect(calls).To(Equal(1))
+	})
+
+	t.Run("evicts the least recently used results", func(t *testing.T) {
+		g := NewWithT(t)
+		calls := 0
+		schema := validation.NewSchema(validation.Key("n", counted(&calls, nil))).WithCache(2)
+		for _, n := range []float64{1, 2, 1, 3, 1, 2} {
+			g.Expect(schema.Validate(map[string]any{"n": n})).To(Succeed())
+		}
+		g.Expect(calls).To(Equal(4)) // 1, 2, 3, then 2 again, evicted by 3
+	})
+
+	t.Run("does not cache system errors", func(t *testing.T) {
+		g := NewWithT(t)
+		calls := 0
+		schema := validation.NewSchema(validation.Key("n", counted(&calls, errors.New("connection refused")))).WithCache(10)
+		g.Expect(validation.IsSystemError(schema.Validate(map[string]any{"n": 1.0}))).To(BeTrue())
+		g.Expect(validation.IsSystemError(schema.Validate(map[string]any{"n": 1.0}))).To(BeTrue())
+		g.Expect(calls).To(Equal(2))
+	})
+
+	t.Run("validates payloads that cannot be encoded", func(t *testing.T) {
+		g := NewWithT(t)
+		calls := 0
+		schema := validation.NewSchema(validation.Key("n", counted(&calls, nil))).WithCache(10)
+		g.Expect(schema.Validate(map[string]any{"n": math.NaN()})).To(Succeed())
+		g.Expect(schema.Validate(map[string]any{"n": math.NaN()})).To(Succeed())
+		g.Expect(calls).To(Equal(2))
+	})
+
+	t.Run("does not share results with modified copies", func(t *testing.T) {
+		g := NewWithT(t)
+		schema := validation.NewSchema(validation.Key("name")).WithCache(10)
+		payload := map[string]any{"name": "alice", "extra": true}
+		g.Expect(schema.Validate(payload)).To(HaveOccurred())
+		g.Expect(schema.AllowExtra().Validate(payload)).To(Succeed())
+		g.Expect(schema.Validate(payload)).To(HaveOccurred())
+	})
+}
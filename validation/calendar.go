@@ -0,0 +1,104 @@
+package validation
+
+import (
+	"fmt"
+	"time"
+)
+
+// HolidayCalendar tells working days from weekends and holidays.
+// Implement it to plug a country or company calendar into IsBusinessDay and WithinBusinessDays.
+type HolidayCalendar interface {
+	IsBusinessDay(date time.Time) bool
+}
+
+// WeekendCalendar is a HolidayCalendar where every day but Saturday and Sunday is a business day.
+var WeekendCalendar HolidayCalendar = holidayCalendar{}
+
+// NewHolidayCalendar creates a HolidayCalendar where weekends and the given holidays are not
+// business days. Holidays are compared by calendar date, in their own location.
+//
+// Example:
+//
+//	calendar := validation.NewHolidayCalendar(
+//	    time.Date(2025, 12, 25, 0, 0, 0, 0, time.UTC),
+//	    time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+//	)
+func NewHolidayCalendar(holidays ...time.Time) HolidayCalendar {
+	c := holidayCalendar{holidays: make(map[civilDate]bool, len(holidays))}
+	for _, h := range holidays {
+		c.holidays[dateOf(h)] = true
+	}
+	return c
+}
+
+type civilDate struct {
+	year  int
+	month time.Month
+	day   int
+}
+
+func dateOf(t time.Time) civilDate {
+	y, m, d := t.Date()
+	return civilDate{y, m, d}
+}
+
+type holidayCalendar struct {
+	holidays map[civilDate]bool
+}
+
+func (c holidayCalendar) IsBusinessDay(date time.Time) bool {
+	if wd := date.Weekday(); wd == time.Saturday || wd == time.Sunday {
+		return false
+	}
+	return !c.holidays[dateOf(date)]
+}
+
+// IsBusinessDay validates that a date is a business day of the calendar.
+// A nil calendar means WeekendCalendar.
+//
+// Example:
+//
+//	validation.Validate(input.DeliveryDate, validation.IsBusinessDay(calendar))
+func IsBusinessDay(calendar HolidayCalendar) Validator[time.Time] {
+	if calendar == nil {
+		calendar = WeekendCalendar
+	}
+	return func(v time.Time) error {
+		if !calendar.IsBusinessDay(v) {
+			return NewValidationError("must be a business day")
+		}
+		return nil
+	}
+}
+
+// WithinBusinessDays validates that a date is today or at most n business days after today,
+// in the location of the date. A nil calendar means WeekendCalendar.
+//
+// Example:
+//
+//	// the SLA promises a resolution within 5 business days
+//	validation.Validate(ticket.DueDate, validation.WithinBusinessDays(5, calendar))
+func WithinBusinessDays(n int, calendar HolidayCalendar) Validator[time.Time] {
+	if calendar == nil {
+		calendar = WeekendCalendar
+	}
+	return func(v time.Time) error {
+		today := time.Now().In(v.Location())
+		day := time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, v.Location())
+		target := time.Date(v.Year(), v.Month(), v.Day(), 0, 0, 0, 0, v.Location())
+		if target.Before(day) {
+			return NewValidationError(fmt.Sprintf("must be within %d business days", n))
+		}
+		count := 0
+		for day.Before(target) {
+			day = day.AddDate(0, 0, 1)
+			if calendar.IsBusinessDay(day) {
+				count++
+			}
+			if count > n {
+				return NewValidationError(fmt.Sprintf("must be within %d business days", n))
+			}
+		}
+		return nil
+	}
+}
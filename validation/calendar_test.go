@@ -0,0 +1,67 @@
+package validation_test
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/quantumcycle/protego/validation"
+)
+
+func TestIsBusinessDay(t *testing.T) {
+	friday := time.Date(2025, 12, 26, 10, 0, 0, 0, time.UTC)
+	saturday := friday.AddDate(0, 0, 1)
+	christmas := time.Date(2025, 12, 25, 0, 0, 0, 0, time.UTC)
+
+	t.Run("rejects weekends by default", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(validation.Validate(friday, validation.IsBusinessDay(nil))).To(Succeed())
+		g.Expect(validation.Validate(christmas, validation.IsBusinessDay(nil))).To(Succeed())
+		g.Expect(validation.Validate(saturday, validation.IsBusinessDay(nil))).To(MatchError("must be a business day"))
+	})
+
+	t.Run("rejects holidays of the calendar", func(t *testing.T) {
+		g := NewWithT(t)
+		calendar := validation.NewHolidayCalendar(christmas)
+		g.Expect(validation.Validate(christmas.Add(15*time.Hour), validation.IsBusinessDay(calendar))).To(MatchError("must be a business day"))
+		g.Expect(validation.Validate(friday, validation.IsBusinessDay(calendar))).To(Succeed())
+	})
+}
+
+// businessDaysFrom returns the date n business days after start for the weekend calendar.
+func businessDaysFrom(start time.Time, n int) time.Time {
+	d := start
+	for n > 0 {
+		d = d.AddDate(0, 0, 1)
+		if d.Weekday() != time.Saturday && d.Weekday() != time.Sunday {
+			n--
+		}
+	}
+	return d
+}
+
+func TestWithinBusinessDays(t *testing.T) {
+	now := time.Now()
+
+	t.Run("passes from today up to n business days", func(t *testing.T) {
+		g := NewWithT(t)
+		v := validation.WithinBusinessDays(5, nil)
+		g.Expect(validation.Validate(now, v)).To(Succeed())
+		g.Expect(validation.Validate(businessDaysFrom(now, 5), v)).To(Succeed())
+	})
+
+	t.Run("fails after n business days or in the past", func(t *testing.T) {
+		g := NewWithT(t)
+		v := validation.WithinBusinessDays(5, nil)
+		g.Expect(validation.Validate(businessDaysFrom(now, 6), v)).To(MatchError("must be within 5 business days"))
+		g.Expect(validation.Validate(now.AddDate(0, 0, -1), v)).To(MatchError("must be within 5 business days"))
+	})
+
+	t.Run("skips holidays of the calendar", func(t *testing.T) {
+		g := NewWithT(t)
+		next := businessDaysFrom(now, 1)
+		v := validation.WithinBusinessDays(1, validation.NewHolidayCalendar(next))
+		g.Expect(validation.Validate(businessDaysFrom(now, 2), v)).To(Succeed())
+	})
+}
@@ -0,0 +1,268 @@
+package validation
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"net"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+)
+
+// IsPEMCertificate validates that a string holds one or more PEM-encoded X.509
+// certificates, and nothing else, such as a certificate customers upload for their custom
+// domain, followed by its intermediate certificates. The other certificate validators
+// check the first certificate of such chains, the leaf.
+//
+// Example:
+//
+//	validation.Validate(upload.Certificate, validation.IsPEMCertificate())
+func IsPEMCertificate() Validator[string] {
+	return func(v string) error {
+		_, err := parseCertificates(v)
+		return err
+	}
+}
+
+// IsPEMPrivateKey validates that a string holds a single unencrypted PEM-encoded private
+// key, in PKCS #8, PKCS #1 (RSA) or SEC 1 (EC) form. The error never repeats the value.
+//
+// Example:
+//
+//	validation.Validate(upload.PrivateKey, validation.IsPEMPrivateKey())
+func IsPEMPrivateKey() Validator[string] {
+	return func(v string) error {
+		_, err := parsePrivateKey(v)
+		return err
+	}
+}
+
+// CertMatchesKey validates that the public key of a certificate matches the PEM-encoded
+// private key keyPEM, which is typically uploaded alongside it. A key that cannot be
+// parsed matches no certificate: validate it with IsPEMPrivateKey.
+//
+// Example:
+//
+//	validation.Validate(upload.Certificate, validation.IsPEMCertificate(), validation.CertMatchesKey(upload.PrivateKey))
+func CertMatchesKey(keyPEM string) Validator[string] {
+	return func(v string) error {
+		cert, err := leafCertificate(v)
+		if err != nil {
+			return err
+		}
+		key, err := parsePrivateKey(keyPEM)
+		if err != nil {
+			return NewValidationError("must match the private key")
+		}
+		public, ok := key.Public().(interface{ Equal(crypto.PublicKey) bool })
+		if !ok || !public.Equal(cert.PublicKey) {
+			return NewValidationError("must match the private key")
+		}
+		return nil
+	}
+}
+
+// CertNotExpired validates that a certificate is already valid, not expired, and does not
+// expire within the given duration, so that certificates about to expire are renewed
+// before upload.
+//
+// Example:
+//
+//	validation.Validate(upload.Certificate, validation.CertNotExpired(30*24*time.Hour))
+func CertNotExpired(within time.Duration) Validator[string] {
+	return func(v string) error {
+		cert, err := leafCertificate(v)
+		if err != nil {
+			return err
+		}
+		now := time.Now()
+		switch {
+		case now.Before(cert.NotBefore):
+			return NewValidationError("must already be valid")
+		case !now.Before(cert.NotAfter):
+			return NewValidationError("must not be expired")
+		case !now.Add(within).Before(cert.NotAfter):
+			return NewValidationError(fmt.Sprintf("must not expire within %s", formatDuration(within)))
+		}
+		return nil
+	}
+}
+
+// CertCoversDomain validates that a certificate is valid for domain, through its subject
+// alternative names, including wildcards: "*.example.com" covers "shop.example.com".
+//
+// Example:
+//
+//	validation.Validate(upload.Certificate, validation.CertCoversDomain(customDomain))
+func CertCoversDomain(domain string) Validator[string] {
+	return func(v string) error {
+		cert, err := leafCertificate(v)
+		if err != nil {
+			return err
+		}
+		if cert.VerifyHostname(domain) != nil {
+			return NewValidationError(fmt.Sprintf("must cover %q", domain))
+		}
+		return nil
+	}
+}
+
+// leafCertificate parses the certificates of v, returning the first one.
+func leafCertificate(v string) (*x509.Certificate, error) {
+	certs, err := parseCertificates(v)
+	if err != nil {
+		return nil, err
+	}
+	return certs[0], nil
+}
+
+func parseCertificates(v string) ([]*x509.Certificate, error) {
+	invalid := NewValidationError("must be a PEM-encoded certificate")
+	var certs []*x509.Certificate
+	rest := bytes.TrimSpace([]byte(v))
+	for len(rest) > 0 {
+		var block *pem.Block
+		block, rest = decodePEM(rest)
+		if block == nil || block.Type != "CERTIFICATE" {
+			return nil, invalid
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, invalid
+		}
+		certs = append(certs, cert)
+	}
+	if len(certs) == 0 {
+		return nil, invalid
+	}
+	return certs, nil
+}
+
+// decodePEM decodes the PEM block starting data, after whitespace, returning it and the
+// whitespace-trimmed data following it, or a nil block. Unlike pem.Decode, it does not skip
+// text before the block.
+func decodePEM(data []byte) (*pem.Block, []byte) {
+	data = bytes.TrimLeft(data, " \t\r\n")
+	if !bytes.HasPrefix(data, []byte("-----BEGIN ")) {
+		return nil, data
+	}
+	block, rest := pem.Decode(data)
+	return block, bytes.TrimSpace(rest)
+}
+
+// parsePrivateKey parses a single PEM-encoded private key, reporting errors that do not
+// repeat the key.
+func parsePrivateKey(v string) (crypto.Signer, error) {
+	invalid := newSensitiveError("must be a PEM-encoded private key")
+	block, rest := decodePEM([]byte(v))
+	if block == nil || len(rest) > 0 {
+		return nil, invalid
+	}
+	if block.Type == "ENCRYPTED PRIVATE KEY" || block.Headers["Proc-Type"] != "" {
+		return nil, newSensitiveError("must not be encrypted")
+	}
+	var key any
+	var err error
+	switch block.Type {
+	case "PRIVATE KEY":
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	default:
+		return nil, invalid
+	}
+	signer, ok := key.(crypto.Signer)
+	if err != nil || !ok {
+		return nil, invalid
+	}
+	return signer, nil
+}
+
+// CertPolicyOptions configures CertPolicy. Zero fields impose no constraint.
+type CertPolicyOptions struct {
+	// RequiredSANs are subject alternative names the certificate must include, each one
+	// exactly: a DNS name, compared ignoring case, an IP address, an email address or a URI.
+	// Wildcards are not expanded: "*.example.com" only matches a "*.example.com" SAN.
+	RequiredSANs []string
+	// ExtKeyUsages are extended key usages the certificate must allow, such as
+	// x509.ExtKeyUsageClientAuth for mTLS client certificates. A certificate allowing
+	// x509.ExtKeyUsageAny allows them all.
+	ExtKeyUsages []x509.ExtKeyUsage
+	// MaxValidity is the maximum duration between the NotBefore and NotAfter dates of the
+	// certificate, such as 398 days for publicly trusted server certificates.
+	MaxValidity time.Duration
+}
+
+// CertPolicy validates that a certificate complies with an issuance policy, such as the one
+// of client certificates onboarded for mutual TLS, beyond what the other certificate
+// validators check. Constraints are checked in the order of CertPolicyOptions, and the
+// first one not met is reported.
+//
+// Example:
+//
+//	validation.Validate(upload.Certificate, validation.IsPEMCertificate(), validation.CertPolicy(validation.CertPolicyOptions{
+//	    RequiredSANs: []string{"spiffe://example.com/billing"},
+//	    ExtKeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+//	    MaxValidity:  90 * 24 * time.Hour,
+//	}))
+func CertPolicy(opts CertPolicyOptions) Validator[string] {
+	return func(v string) error {
+		cert, err := leafCertificate(v)
+		if err != nil {
+			return err
+		}
+		for _, san := range opts.RequiredSANs {
+			if !hasSAN(cert, san) {
+				return NewValidationError(fmt.Sprintf("must include subject alternative name %q", san))
+			}
+		}
+		for _, usage := range opts.ExtKeyUsages {
+			if !slices.Contains(cert.ExtKeyUsage, usage) && !slices.Contains(cert.ExtKeyUsage, x509.ExtKeyUsageAny) {
+				return NewValidationError(fmt.Sprintf("must allow extended key usage %s", extKeyUsageName(usage)))
+			}
+		}
+		if opts.MaxValidity > 0 && cert.NotAfter.Sub(cert.NotBefore) > opts.MaxValidity {
+			return NewValidationError(fmt.Sprintf("must not be valid for more than %s", formatDuration(opts.MaxValidity)))
+		}
+		return nil
+	}
+}
+
+// hasSAN reports whether cert has the subject alternative name san.
+func hasSAN(cert *x509.Certificate, san string) bool {
+	if ip := net.ParseIP(san); ip != nil {
+		return slices.ContainsFunc(cert.IPAddresses, ip.Equal)
+	}
+	if slices.ContainsFunc(cert.DNSNames, func(name string) bool { return strings.EqualFold(name, san) }) {
+		return true
+	}
+	if slices.Contains(cert.EmailAddresses, san) {
+		return true
+	}
+	return slices.ContainsFunc(cert.URIs, func(u *url.URL) bool { return u.String() == san })
+}
+
+// extKeyUsageName returns the name of usage in OpenSSL configurations, such as "serverAuth".
+func extKeyUsageName(usage x509.ExtKeyUsage) string {
+	switch usage {
+	case x509.ExtKeyUsageServerAuth:
+		return "serverAuth"
+	case x509.ExtKeyUsageClientAuth:
+		return "clientAuth"
+	case x509.ExtKeyUsageCodeSigning:
+		return "codeSigning"
+	case x509.ExtKeyUsageEmailProtection:
+		return "emailProtection"
+	case x509.ExtKeyUsageTimeStamping:
+		return "timeStamping"
+	case x509.ExtKeyUsageOCSPSigning:
+		return "OCSPSigning"
+	}
+	return fmt.Sprintf("%d", usage)
+}
@@ -1,34 +1,70 @@
 package validation
 
 import (
-	"errors"
+	"encoding/json"
 	"fmt"
+	"math"
 	"slices"
+	"strconv"
 	"strings"
 )
 
 // In validates that a value is in the allowed list.
 // If caseInsensitive is true, string values are compared case-insensitively.
+// The list is turned into a set once, so large allowlists are checked in constant time.
 //
 // Example:
 //
 //	validation.Validate(status, validation.In(false, "ACTIVE", "INACTIVE", "PENDING"))
 //	validation.Validate(mode, validation.In(true, "single", "multiple")) // case-insensitive
 func In[T comparable](caseInsensitive bool, allowed ...T) Validator[T] {
-	return func(v T) error {
-		if caseInsensitive {
-			// For strings, do case-insensitive comparison
-			vs := strings.ToLower(fmt.Sprint(v))
-			for _, a := range allowed {
-				if strings.ToLower(fmt.Sprint(a)) == vs {
-					return nil
-				}
+	msg := fmt.Sprintf("must be one of: %v", allowed)
+	params := map[string]any{"values": allowed}
+	if caseInsensitive {
+		// For strings, do case-insensitive comparison
+		lowered := lowerSet(allowed)
+		return func(v T) error {
+			if _, ok := lowered[lower(v)]; !ok {
+				return NewCodedError(CodeIn, msg, params)
 			}
-		} else if slices.Contains(allowed, v) {
 			return nil
 		}
-		return NewValidationError(fmt.Sprintf("must be one of: %v", allowed))
 	}
+	set := makeSet(allowed)
+	return func(v T) error {
+		if _, ok := set[v]; !ok {
+			return NewCodedError(CodeIn, msg, params)
+		}
+		return nil
+	}
+}
+
+// makeSet returns the set of values, built once at construction so that membership checks
+// of large lists take constant time.
+func makeSet[T comparable](values []T) map[T]struct{} {
+	set := make(map[T]struct{}, len(values))
+	for _, v := range values {
+		set[v] = struct{}{}
+	}
+	return set
+}
+
+// lowerSet returns the set of the lower-cased string forms of values, for case-insensitive
+// validators.
+func lowerSet[T any](values []T) map[string]struct{} {
+	set := make(map[string]struct{}, len(values))
+	for _, v := range values {
+		set[lower(v)] = struct{}{}
+	}
+	return set
+}
+
+// lower returns the lower-cased string form of v, without formatting strings.
+func lower[T any](v T) string {
+	if s, ok := any(v).(string); ok {
+		return strings.ToLower(s)
+	}
+	return strings.ToLower(fmt.Sprint(v))
 }
 
 // InSlice validates that a value is in the allowed slice.
@@ -48,23 +84,29 @@ func InSlice[T comparable](caseInsensitive bool, allowed []T) Validator[T] {
 //
 //	validation.Validate(username, validation.NotIn(false, "admin", "root", "system"))
 func NotIn[T comparable](caseInsensitive bool, forbidden ...T) Validator[T] {
-	return func(v T) error {
-		if caseInsensitive {
-			vs := strings.ToLower(fmt.Sprint(v))
-			for _, f := range forbidden {
-				if strings.ToLower(fmt.Sprint(f)) == vs {
-					return NewValidationError(fmt.Sprintf("cannot be one of: %v", forbidden))
-				}
+	msg := fmt.Sprintf("cannot be one of: %v", forbidden)
+	if caseInsensitive {
+		lowered := lowerSet(forbidden)
+		return func(v T) error {
+			if _, ok := lowered[lower(v)]; ok {
+				return NewValidationError(msg)
 			}
-		} else if slices.Contains(forbidden, v) {
-			return NewValidationError(fmt.Sprintf("cannot be one of: %v", forbidden))
+			return nil
+		}
+	}
+	set := makeSet(forbidden)
+	return func(v T) error {
+		if _, ok := set[v]; ok {
+			return NewValidationError(msg)
 		}
 		return nil
 	}
 }
 
 // Each validates each element in a slice using the provided element validator.
-// All errors are collected and returned as a joined error.
+// All errors are collected and returned as a joined error. A system error (see
+// IsSystemError) stops the iteration and is returned without being wrapped as a
+// validation error.
 //
 // Example:
 //
@@ -72,13 +114,40 @@ func NotIn[T comparable](caseInsensitive bool, forbidden ...T) Validator[T] {
 //	validation.Validate(ages, validation.Each(validation.Range(0, 120)))
 func Each[T any](elementValidator Validator[T]) Validator[[]T] {
 	return func(values []T) error {
-		var errs []error
+		report := NewReport()
 		for i, v := range values {
-			if err := elementValidator(v); err != nil {
-				errs = append(errs, WrapError(fmt.Errorf("index %d: %w", i, err)))
+			err := elementValidator(v)
+			if IsSystemError(err) {
+				report.Release()
+				return fmt.Errorf("index %d: %w", i, err)
+			}
+			if err != nil {
+				report.Add(WrapError(fmt.Errorf("index %d: %w", i, err)))
 			}
 		}
-		return errors.Join(errs...)
+		return collect(report)
+	}
+}
+
+// EachFailFast is like Each, but stops at the first failing element and returns its error
+// alone. Use it on large slices where a single invalid element rejects the whole input, so
+// that the remaining elements are not validated for nothing.
+//
+// Example:
+//
+//	validation.Validate(rows, validation.EachFailFast(validation.Nested[Row]()))
+func EachFailFast[T any](elementValidator Validator[T]) Validator[[]T] {
+	return func(values []T) error {
+		for i, v := range values {
+			err := elementValidator(v)
+			if IsSystemError(err) {
+				return fmt.Errorf("index %d: %w", i, err)
+			}
+			if err != nil {
+				return WrapError(fmt.Errorf("index %d: %w", i, err))
+			}
+		}
+		return nil
 	}
 }
 
@@ -142,11 +211,72 @@ func UniqueItems[T comparable]() Validator[[]T] {
 	}
 }
 
+// IsDelimitedSet validates a string holding a set of allowed values separated by sep, such as
+// "read,write" for a scopes parameter, before it is split into a slice. Spaces around items
+// are ignored; empty and duplicate items are rejected, as well as more than maxItems items
+// when maxItems is positive. An empty string is an empty set (use Required to reject it).
+// It panics if sep is empty.
+//
+// Example:
+//
+//	validation.Validate(r.URL.Query().Get("scopes"), validation.IsDelimitedSet(",", 3, "read", "write", "admin"))
+func IsDelimitedSet(sep string, maxItems int, allowed ...string) Validator[string] {
+	if sep == "" {
+		panic("validation: IsDelimitedSet requires a separator")
+	}
+	return func(v string) error {
+		if v == "" {
+			return nil
+		}
+		items := strings.Split(v, sep)
+		if maxItems > 0 && len(items) > maxItems {
+			return NewValidationError(fmt.Sprintf("must have at most %d items", maxItems))
+		}
+		seen := make(map[string]bool, len(items))
+		for i, item := range items {
+			item = strings.TrimSpace(item)
+			switch {
+			case item == "":
+				return NewValidationError(fmt.Sprintf("item at index %d must not be empty", i))
+			case !slices.Contains(allowed, item):
+				return NewValidationError(fmt.Sprintf("item %q must be one of: %v", item, allowed))
+			case seen[item]:
+				return NewValidationError(fmt.Sprintf("item %q at index %d must not be repeated", item, i))
+			}
+			seen[item] = true
+		}
+		return nil
+	}
+}
+
 // MapKeyRule represents a validation rule for a specific key in a map.
 type MapKeyRule[V any] struct {
-	key        string
-	required   bool
-	validators []Validator[V]
+	key          string
+	required     bool
+	validators   []Validator[V]
+	defaultValue V
+	hasDefault   bool
+	normalize    func(V) V
+	group        []string
+	allOrNone    bool
+	condition    MapCondition[V]
+}
+
+// MapCondition is a condition on the content of a map, such as the value of a discriminating
+// key, deciding whether a MapKeyIf rule applies.
+type MapCondition[V any] func(m map[string]V) bool
+
+// KeyEquals is a condition satisfied when key is present in the map with the given value. The
+// type argument is the value type of the map, any for ValidateAnyMap.
+//
+// Example:
+//
+//	validation.KeyEquals[any]("delivery", "ship")
+func KeyEquals[V comparable](key string, value V) MapCondition[V] {
+	return func(m map[string]V) bool {
+		v, exists := m[key]
+		return exists && v == value
+	}
 }
 
 // MapKey creates a validation rule for a map key.
@@ -163,8 +293,79 @@ func MapKey[V any](key string, required bool, validators ...Validator[V]) MapKey
 	}
 }
 
+// MapKeyIf creates a rule for a key that is required and validated only when condition
+// holds, such as the shipping address of an order to deliver. When it does not, the key is
+// ignored: it may be present or absent, with any value. The condition sees the map as left by
+// the rules before it, with their defaults and normalized values.
+//
+// Example:
+//
+//	validation.ValidateAnyMap(order, false,
+//	    validation.MapKey("delivery", true, validation.StringValidator(validation.In(false, "ship", "pickup"))),
+//	    validation.MapKeyIf("shippingAddress", validation.KeyEquals[any]("delivery", "ship"), validation.StringValidator(validation.MinLength(10))),
+//	)
+func MapKeyIf[V any](key string, condition MapCondition[V], validators ...Validator[V]) MapKeyRule[V] {
+	return MapKeyRule[V]{key: key, required: true, validators: validators, condition: condition}
+}
+
+// Default sets the value of the key when it is missing from the map, which makes it
+// optional even if required. The default is written to the map, then normalized and
+// validated like a supplied value.
+//
+// Example:
+//
+//	validation.MapKey("region", false, validation.In(false, "eu", "us")).Default("eu")
+func (r MapKeyRule[V]) Default(value V) MapKeyRule[V] {
+	r.defaultValue, r.hasDefault = value, true
+	return r
+}
+
+// Normalize sets a function canonicalizing the value of the key before it is validated,
+// such as trimming or lowercasing it. The normalized value is written back to the map.
+//
+// Example:
+//
+//	validation.MapKey("host", true, validation.Required[string]()).Normalize(func(h string) string {
+//	    return strings.ToLower(strings.TrimSpace(h))
+//	})
+func (r MapKeyRule[V]) Normalize(fn func(V) V) MapKeyRule[V] {
+	r.normalize = fn
+	return r
+}
+
+// OneOfKeys creates a rule requiring at least one of keys to be present in the map, such as
+// one contact method among several. The keys are defined by the rule, so they are not
+// unexpected keys, and their values can be validated by MapKey rules of their own. The
+// type argument is the value type of the map, which cannot be inferred.
+//
+// Example:
+//
+//	validation.ValidateStringMap(contact, false,
+//	    validation.OneOfKeys[string]("email", "phone"),
+//	    validation.MapKey("email", false, validation.Contains("@")),
+//	)
+func OneOfKeys[V any](keys ...string) MapKeyRule[V] {
+	return MapKeyRule[V]{group: keys}
+}
+
+// AllOrNoneKeys creates a rule requiring keys to be either all present in the map or all
+// absent, such as the coordinates of a location. Like OneOfKeys, the keys are defined by
+// the rule.
+//
+// Example:
+//
+//	validation.ValidateAnyMap(place, false, validation.AllOrNoneKeys[any]("lat", "lng"))
+func AllOrNoneKeys[V any](keys ...string) MapKeyRule[V] {
+	return MapKeyRule[V]{group: keys, allOrNone: true}
+}
+
 // ValidateStringMap validates a map[string]string with the specified rules.
-// If allowExtra is false, any keys not defined in rules will cause an error.
+// If allowExtra is false, any keys not defined in rules will cause an error, suggesting the
+// defined key likely meant when the key looks like a typo of it: `key "nmae" not expected,
+// did you mean "name"?`, with the "suggestion" parameter.
+// Every missing, invalid or unexpected key is reported at once, the errors joined, so users
+// can fix them all in one go; the validators of a key stop at its first error. A system
+// error (see IsSystemError) stops validation and is returned as is.
 //
 // Example:
 //
@@ -175,39 +376,22 @@ func MapKey[V any](key string, required bool, validators ...Validator[V]) MapKey
 //	    validation.MapKey("port", true, validation.IsInt()),
 //	)
 func ValidateStringMap(m map[string]string, allowExtra bool, rules ...MapKeyRule[string]) error {
-	validated := make(map[string]bool)
-
-	for _, rule := range rules {
-		validated[rule.key] = true
-
-		value, exists := m[rule.key]
-		if !exists && rule.required {
-			return NewValidationError(fmt.Sprintf("key %q is required", rule.key))
-		}
-
-		if exists {
-			for _, validator := range rule.validators {
-				if err := validator(value); err != nil {
-					return WrapError(fmt.Errorf("key %q: %w", rule.key, err))
-				}
-			}
-		}
-	}
+	return validateMap(m, allowExtra, rules)
+}
 
-	// Check for extra keys if not allowed
-	if !allowExtra {
-		for key := range m {
-			if !validated[key] {
-				return NewValidationError(fmt.Sprintf("key %q not expected", key))
-			}
-		}
+// keyError attributes a map value error to its key. System errors (see IsSystemError)
+// are not wrapped as validation errors.
+func keyError(key string, err error) error {
+	err = fmt.Errorf("key %q: %w", key, err)
+	if IsSystemError(err) {
+		return err
 	}
-
-	return nil
+	return WrapError(err)
 }
 
 // ValidateAnyMap validates a map[string]any (JSON-style map) with the specified rules.
 // If allowExtra is false, any keys not defined in rules will cause an error.
+// Like ValidateStringMap, every missing, invalid or unexpected key is reported at once.
 //
 // Example:
 //
@@ -217,42 +401,125 @@ func ValidateStringMap(m map[string]string, allowExtra bool, rules ...MapKeyRule
 //	    validation.MapKey("name", true, validation.StringValidator(validation.Required[string]())),
 //	    validation.MapKey("age", false, validation.AnyValidator(func(v any) error {
 //	        age, ok := v.(float64)
-//	        if !ok { return fmt.Errorf("must be a number") }
+//	        if !ok { return validation.NewValidationError("must be a number") }
 //	        return validation.Validate(int(age), validation.Range(0, 120))
 //	    })),
 //	)
 func ValidateAnyMap(m map[string]any, allowExtra bool, rules ...MapKeyRule[any]) error {
-	validated := make(map[string]bool)
+	return validateMap(m, allowExtra, rules)
+}
 
+// validateMap implements ValidateStringMap and ValidateAnyMap. Errors are collected into a
+// pooled Report, so valid maps are validated without allocating; extra keys are looked up
+// in the rules, which are usually few.
+func validateMap[V any](m map[string]V, allowExtra bool, rules []MapKeyRule[V]) error {
+	report := NewReport()
 	for _, rule := range rules {
-		validated[rule.key] = true
-
+		if rule.group != nil || rule.condition != nil && !rule.condition(m) {
+			continue
+		}
 		value, exists := m[rule.key]
-		if !exists && rule.required {
-			return NewValidationError(fmt.Sprintf("key %q is required", rule.key))
+		if !exists && rule.hasDefault {
+			value, exists = rule.defaultValue, true
+			if m != nil {
+				m[rule.key] = value
+			}
 		}
-
-		if exists {
-			for _, validator := range rule.validators {
-				if err := validator(value); err != nil {
-					return WrapError(fmt.Errorf("key %q: %w", rule.key, err))
+		if !exists {
+			if rule.required {
+				report.Add(NewCodedError(CodeRequired, fmt.Sprintf("key %q is required", rule.key), nil))
+			}
+			continue
+		}
+		if rule.normalize != nil {
+			value = rule.normalize(value)
+			if m != nil {
+				m[rule.key] = value
+			}
+		}
+		for _, validator := range rule.validators {
+			if err := validator(value); err != nil {
+				if IsSystemError(err) {
+					report.Release()
+					return keyError(rule.key, err)
 				}
+				report.Add(keyError(rule.key, err))
+				break
 			}
 		}
 	}
 
+	// Key groups are checked once defaults are filled
+	for _, rule := range rules {
+		if rule.group != nil {
+			report.Add(checkKeyGroup(m, rule))
+		}
+	}
+
 	// Check for extra keys if not allowed
 	if !allowExtra {
+		var extra []string
 		for key := range m {
-			if !validated[key] {
-				return NewValidationError(fmt.Sprintf("key %q not expected", key))
+			if !hasRule(rules, key) {
+				extra = append(extra, key)
 			}
 		}
+		slices.Sort(extra)
+		for _, key := range extra {
+			report.Add(unexpectedKey(key, ruleKeys(rules), m))
+		}
 	}
 
+	return collect(report)
+}
+
+// ruleKeys returns the keys defined by rules, in order.
+func ruleKeys[V any](rules []MapKeyRule[V]) []string {
+	var keys []string
+	for _, rule := range rules {
+		if rule.group == nil {
+			keys = append(keys, rule.key)
+		}
+		keys = append(keys, rule.group...)
+	}
+	return keys
+}
+
+func hasRule[V any](rules []MapKeyRule[V], key string) bool {
+	for _, rule := range rules {
+		if rule.key == key && rule.group == nil || slices.Contains(rule.group, key) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkKeyGroup checks the presence of the keys of a OneOfKeys or AllOrNoneKeys rule.
+func checkKeyGroup[V any](m map[string]V, rule MapKeyRule[V]) error {
+	var missing []string
+	for _, key := range rule.group {
+		if _, exists := m[key]; !exists {
+			missing = append(missing, key)
+		}
+	}
+	switch {
+	case rule.allOrNone && len(missing) > 0 && len(missing) < len(rule.group):
+		return NewValidationError(fmt.Sprintf("keys %s must be provided together, missing %s", quoteKeys(rule.group), quoteKeys(missing)))
+	case !rule.allOrNone && len(missing) == len(rule.group):
+		return NewValidationError(fmt.Sprintf("one of keys %s is required", quoteKeys(rule.group)))
+	}
 	return nil
 }
 
+// quoteKeys formats keys for error messages, as in `"email", "phone"`.
+func quoteKeys(keys []string) string {
+	quoted := make([]string, len(keys))
+	for i, key := range keys {
+		quoted[i] = strconv.Quote(key)
+	}
+	return strings.Join(quoted, ", ")
+}
+
 // StringValidator converts a string validator to work with any type by first asserting it's a string.
 // This is useful for ValidateAnyMap when you know a value should be a string.
 //
@@ -271,40 +538,140 @@ func StringValidator(validator Validator[string]) Validator[any] {
 
 // IntValidator converts an int validator to work with any type by first asserting it's a number.
 // This is useful for ValidateAnyMap when you know a value should be an int.
+// Numbers that an int cannot hold exactly are rejected rather than truncated: fractional
+// numbers, and float64 numbers beyond ±2^53, which JSON decoding may already have rounded
+// (decode large IDs with json.Decoder.UseNumber, json.Number being supported).
 //
 // Example:
 //
 //	validation.MapKey("age", true, validation.IntValidator(validation.Range(0, 120)))
 func IntValidator(validator Validator[int]) Validator[any] {
 	return func(v any) error {
-		// JSON numbers are float64
-		switch val := v.(type) {
-		case int:
-			return validator(val)
-		case float64:
-			return validator(int(val))
-		case int64:
-			return validator(int(val))
-		default:
-			return NewValidationError("must be a number")
+		n, err := exactInt64(v)
+		if err != nil {
+			return err
+		}
+		if n < math.MinInt || n > math.MaxInt {
+			return NewValidationError(fmt.Sprintf("must be an integer between %d and %d", math.MinInt, math.MaxInt))
 		}
+		return validator(int(n))
+	}
+}
+
+// Int64Validator is IntValidator for int64 validators, such as for 64-bit IDs.
+//
+// Example:
+//
+//	validation.MapKey("account_id", true, validation.Int64Validator(validation.Positive[int64]()))
+func Int64Validator(validator Validator[int64]) Validator[any] {
+	return func(v any) error {
+		n, err := exactInt64(v)
+		if err != nil {
+			return err
+		}
+		return validator(n)
+	}
+}
+
+// UintValidator is IntValidator for uint validators. Negative numbers are rejected.
+//
+// Example:
+//
+//	validation.MapKey("retries", false, validation.UintValidator(validation.Max[uint](5)))
+func UintValidator(validator Validator[uint]) Validator[any] {
+	return func(v any) error {
+		switch u := v.(type) {
+		case uint:
+			return validator(u)
+		case uint64:
+			if u > math.MaxUint {
+				return NewValidationError(fmt.Sprintf("must be an integer between 0 and %d", uint64(math.MaxUint)))
+			}
+			return validator(uint(u))
+		}
+		n, err := exactInt64(v)
+		if err != nil {
+			return err
+		}
+		if n < 0 {
+			return NewValidationError("must not be negative")
+		}
+		if uint64(n) > math.MaxUint {
+			return NewValidationError(fmt.Sprintf("must be an integer between 0 and %d", uint64(math.MaxUint)))
+		}
+		return validator(uint(n))
+	}
+}
+
+// maxExactFloat is the largest magnitude below which every integer has an exact float64.
+const maxExactFloat = 1<<53 - 1
+
+// exactInt64 converts a number decoded from JSON or passed as a Go integer to an int64,
+// failing when the conversion would lose information.
+func exactInt64(v any) (int64, error) {
+	switch val := v.(type) {
+	case int:
+		return int64(val), nil
+	case int32:
+		return int64(val), nil
+	case int64:
+		return val, nil
+	case uint32:
+		return int64(val), nil
+	case uint:
+		return exactInt64(uint64(val))
+	case uint64:
+		if val > math.MaxInt64 {
+			return 0, NewValidationError(fmt.Sprintf("must be an integer between %d and %d", math.MinInt64, math.MaxInt64))
+		}
+		return int64(val), nil
+	case float32:
+		return exactInt64(float64(val))
+	case float64:
+		if val != math.Trunc(val) { // fractional or NaN
+			return 0, NewValidationError("must be an integer")
+		}
+		if val < -maxExactFloat || val > maxExactFloat {
+			return 0, NewValidationError(fmt.Sprintf("must be an integer between %d and %d", -maxExactFloat, maxExactFloat))
+		}
+		return int64(val), nil
+	case json.Number:
+		if n, err := val.Int64(); err == nil {
+			return n, nil
+		}
+		// exponent notation such as 1e3, fractions, or numbers beyond the int64 range
+		f, err := val.Float64()
+		if err != nil && !math.IsInf(f, 0) {
+			return 0, NewValidationError("must be a number")
+		}
+		return exactInt64(f)
+	default:
+		return 0, NewValidationError("must be a number")
 	}
 }
 
 // FloatValidator converts a float64 validator to work with any type by first asserting it's a number.
 // This is useful for ValidateAnyMap when you know a value should be a float.
+// NaN and infinities, which some JSON extensions decode, are rejected (see FiniteFloat).
 //
 // Example:
 //
 //	validation.MapKey("price", true, validation.FloatValidator(validation.Min(0.0)))
 func FloatValidator(validator Validator[float64]) Validator[any] {
+	finite := FiniteFloat[float64]()
+	validateFinite := func(f float64) error {
+		if err := finite(f); err != nil {
+			return err
+		}
+		return validator(f)
+	}
 	return func(v any) error {
 		// JSON numbers are float64
 		switch val := v.(type) {
 		case float64:
-			return validator(val)
+			return validateFinite(val)
 		case float32:
-			return validator(float64(val))
+			return validateFinite(float64(val))
 		case int:
 			return validator(float64(val))
 		case int64:
@@ -321,7 +688,7 @@ func FloatValidator(validator Validator[float64]) Validator[any] {
 // Example:
 //
 //	validation.MapKey("active", true, validation.BoolValidator(validation.Custom(func(v bool) error {
-//	    if !v { return fmt.Errorf("must be active") }
+//	    if !v { return validation.NewValidationError("must be active") }
 //	    return nil
 //	})))
 func BoolValidator(validator Validator[bool]) Validator[any] {
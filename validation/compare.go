@@ -0,0 +1,113 @@
+package validation
+
+import (
+	"reflect"
+	"strings"
+	"time"
+
+	"golang.org/x/exp/constraints"
+)
+
+// Comparison validators do not repeat the expected value in their errors, as it is often
+// another user input such as a password to confirm.
+
+// Equals validates that a value equals expected.
+//
+// Example:
+//
+//	validation.Validate(input.PasswordConfirmation, validation.Equals(input.Password))
+func Equals[T comparable](expected T) Validator[T] {
+	return func(v T) error {
+		if v != expected {
+			return NewValidationError("must match the expected value")
+		}
+		return nil
+	}
+}
+
+// NotEquals validates that a value differs from forbidden.
+//
+// Example:
+//
+//	validation.Validate(input.NewPassword, validation.NotEquals(input.CurrentPassword))
+func NotEquals[T comparable](forbidden T) Validator[T] {
+	return func(v T) error {
+		if v == forbidden {
+			return NewValidationError("must be different")
+		}
+		return nil
+	}
+}
+
+// DeepEquals is like Equals for values that are not comparable, such as slices and maps,
+// using reflect.DeepEqual.
+//
+// Example:
+//
+//	validation.Validate(input.Scopes, validation.DeepEquals(grant.Scopes))
+func DeepEquals[T any](expected T) Validator[T] {
+	return func(v T) error {
+		if !reflect.DeepEqual(v, expected) {
+			return NewValidationError("must match the expected value")
+		}
+		return nil
+	}
+}
+
+// EqualFold is like Equals for strings, ignoring case (see strings.EqualFold).
+//
+// Example:
+//
+//	validation.Validate(input.EmailConfirmation, validation.EqualFold(input.Email))
+func EqualFold(expected string) Validator[string] {
+	return func(v string) error {
+		if !strings.EqualFold(v, expected) {
+			return NewValidationError("must match the expected value")
+		}
+		return nil
+	}
+}
+
+// PairOrdered validates that a (from, to) pair supplied outside a struct, such as two query
+// parameters, is coherent: from must be before to, or not after it when inclusive is true.
+// The error is attributed to the "from" field.
+//
+// Example:
+//
+//	err := validation.PairOrdered(minPrice, maxPrice, true) // "from: must not be after to"
+func PairOrdered[T constraints.Ordered](from, to T, inclusive bool) error {
+	return pairOrdered(from < to, from == to, inclusive)
+}
+
+// TimesOrdered is PairOrdered for time.Time values.
+//
+// Example:
+//
+//	err := validation.TimesOrdered(query.Since, query.Until, false) // "from: must be before to"
+func TimesOrdered(from, to time.Time, inclusive bool) error {
+	return pairOrdered(from.Before(to), from.Equal(to), inclusive)
+}
+
+func pairOrdered(before, equal, inclusive bool) error {
+	switch {
+	case before || (equal && inclusive):
+		return nil
+	case inclusive:
+		return NewFieldError("from", NewValidationError("must not be after to"))
+	default:
+		return NewFieldError("from", NewValidationError("must be before to"))
+	}
+}
+
+// ValidRange validates that a (min, max) pair supplied outside a struct describes a valid
+// range, min being at most max. The error is attributed to the "min" field.
+//
+// Example:
+//
+//	err := validation.ValidRange(input.MinAge, input.MaxAge) // "min: must not be greater than max"
+func ValidRange[T constraints.Ordered](minimum, maximum T) error {
+	if minimum > maximum {
+		return NewFieldError("min", NewValidationError("must not be greater than max"))
+	}
+	return nil
+}
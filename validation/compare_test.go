@@ -0,0 +1,79 @@
+package validation_test
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/quantumcycle/protego/validation"
+)
+
+func TestEquals(t *testing.T) {
+	t.Run("passes with equal values", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(validation.Validate("s3cret", validation.Equals("s3cret"))).To(Succeed())
+		g.Expect(validation.Validate(true, validation.Equals(true))).To(Succeed())
+	})
+
+	t.Run("fails without echoing the expected value", func(t *testing.T) {
+		g := NewWithT(t)
+		err := validation.Validate("s3cret", validation.Equals("S3cret"))
+		g.Expect(err).To(MatchError("must match the expected value"))
+		g.Expect(validation.IsValidationError(err)).To(BeTrue())
+	})
+}
+
+func TestNotEquals(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(validation.Validate(2, validation.NotEquals(1))).To(Succeed())
+	g.Expect(validation.Validate(1, validation.NotEquals(1))).To(MatchError("must be different"))
+}
+
+func TestDeepEquals(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(validation.Validate([]string{"read", "write"}, validation.DeepEquals([]string{"read", "write"}))).To(Succeed())
+	g.Expect(validation.Validate([]string{"write", "read"}, validation.DeepEquals([]string{"read", "write"}))).
+		To(MatchError("must match the expected value"))
+	g.Expect(validation.Validate(map[string]int{"a": 1}, validation.DeepEquals(map[string]int{"a": 1}))).To(Succeed())
+}
+
+func TestEqualFold(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(validation.Validate("Alice@Example.com", validation.EqualFold("alice@example.com"))).To(Succeed())
+	g.Expect(validation.Validate("bob@example.com", validation.EqualFold("alice@example.com"))).
+		To(MatchError("must match the expected value"))
+}
+
+func TestPairOrdered(t *testing.T) {
+	t.Run("passes with ordered pairs", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(validation.PairOrdered(1, 2, false)).To(Succeed())
+		g.Expect(validation.PairOrdered("a", "a", true)).To(Succeed())
+	})
+
+	t.Run("fails with unordered pairs", func(t *testing.T) {
+		g := NewWithT(t)
+		err := validation.PairOrdered(2, 2, false)
+		g.Expect(err).To(MatchError("from: must be before to"))
+		g.Expect(validation.IsValidationError(err)).To(BeTrue())
+		g.Expect(validation.PairOrdered(3, 2, true)).To(MatchError("from: must not be after to"))
+	})
+}
+
+func TestTimesOrdered(t *testing.T) {
+	g := NewWithT(t)
+	since := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	until := since.Add(time.Hour)
+	g.Expect(validation.TimesOrdered(since, until, false)).To(Succeed())
+	g.Expect(validation.TimesOrdered(since, since.In(time.FixedZone("CET", 3600)), true)).To(Succeed())
+	g.Expect(validation.TimesOrdered(since, since, false)).To(MatchError("from: must be before to"))
+	g.Expect(validation.TimesOrdered(until, since, true)).To(MatchError("from: must not be after to"))
+}
+
+func TestValidRange(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(validation.ValidRange(18, 65)).To(Succeed())
+	g.Expect(validation.ValidRange(1.5, 1.5)).To(Succeed())
+	g.Expect(validation.ValidRange(65, 18)).To(MatchError("min: must not be greater than max"))
+}
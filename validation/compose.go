@@ -0,0 +1,92 @@
+package validation
+
+// Merge returns a schema with the keys of s and other, for endpoint-specific schemas
+// extending a shared base one. A key defined by both takes the definition of other, which
+// can thus override the base. Extra keys are accepted only if both schemas accept them, the
+// strictest of their strictness applies, and the budget of other applies if it has one,
+// that of s otherwise.
+//
+// Example:
+//
+//	var createUser = auditFields.Merge(validation.NewSchema(
+//	    validation.Key("name", validation.MinLengthRule(3)).Required(),
+//	))
+func (s *Schema) Merge(other *Schema) *Schema {
+	keys := make([]SchemaKey, 0, len(s.keys)+len(other.keys))
+	for _, k := range s.keys {
+		if _, overridden := other.key(k.name); !overridden {
+			keys = append(keys, k)
+		}
+	}
+	keys = append(keys, other.keys...)
+
+	merged := NewSchema(keys...).WithStrictness(max(s.strictness, other.strictness))
+	merged.allowExtra = s.allowExtra && other.allowExtra
+	merged.budget = s.budget
+	if other.budget != (Budget{}) {
+		merged.budget = other.budget
+	}
+	return merged
+}
+
+// AllOf returns a schema validating maps against every one of schemas, for composing
+// independent concerns such as audit fields and pagination parameters. Unlike validating
+// the maps with each schema, the keys of one are not extra keys for the others: a key
+// defined by several schemas is required if any requires it, validated by the rules of all,
+// nullable only if all accept null, and immutable if any makes it so. Extra keys are
+// accepted only if all schemas accept them, the strictest strictness applies, and so does
+// the first budget set.
+//
+// Example:
+//
+//	var listOrders = validation.AllOf(pagination, sorting, validation.NewSchema(
+//	    validation.Key("status", validation.InRule("open", "closed")),
+//	))
+func AllOf(schemas ...*Schema) *Schema {
+	var keys []SchemaKey
+	index := map[string]int{}
+	allowExtra := len(schemas) > 0
+	var budget Budget
+	strictness := Strict
+	for i, schema := range schemas {
+		for _, k := range schema.keys {
+			i, defined := index[k.name]
+			if !defined {
+				index[k.name] = len(keys)
+				keys = append(keys, k)
+				continue
+			}
+			combined := &keys[i]
+			combined.required = combined.required || k.required
+			combined.nullable = combined.nullable && k.nullable
+			combined.immutable = combined.immutable || k.immutable
+			combined.rules = append(append([]Rule(nil), combined.rules...), k.rules...)
+		}
+		allowExtra = allowExtra && schema.allowExtra
+		if i == 0 || schema.strictness > strictness {
+			strictness = schema.strictness
+		}
+		if budget == (Budget{}) {
+			budget = schema.budget
+		}
+	}
+
+	all := NewSchema(keys...).WithStrictness(strictness)
+	all.allowExtra, all.budget = allowExtra, budget
+	return all
+}
+
+// AnyOf returns a validator accepting maps valid against at least one of schemas, like Or,
+// for payloads of several accepted shapes. When a discriminating key tells the shape, use
+// Discriminated, whose errors are those of the selected schema only.
+//
+// Example:
+//
+//	validate := validation.AnyOf(byEmail, byPhone)
+func AnyOf(schemas ...*Schema) Validator[map[string]any] {
+	validators := make([]Validator[map[string]any], len(schemas))
+	for i, schema := range schemas {
+		validators[i] = schema.Validate
+	}
+	return Or(validators...)
+}
@@ -0,0 +1,61 @@
+package validation_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/quantumcycle/protego/validation"
+)
+
+func TestSchemaComposition(t *testing.T) {
+	audit := validation.NewSchema(
+		validation.Key("created_by", validation.MinLengthRule(1)).Required(),
+		validation.Key("note", validation.MaxLengthRule(10)),
+	)
+	pagination := validation.NewSchema(
+		validation.Key("limit", validation.RangeRule(1, 100)),
+		validation.Key("note", validation.MinLengthRule(2)).Required(),
+	)
+
+	t.Run("merges schemas, the keys of the other overriding", func(t *testing.T) {
+		g := NewWithT(t)
+		user := audit.Merge(validation.NewSchema(
+			validation.Key("name").Required(),
+			validation.Key("note", validation.MaxLengthRule(100)),
+		))
+		g.Expect(user.Validate(map[string]any{"created_by": "ops", "name": "alice", "note": "a long note here"})).To(Succeed())
+		g.Expect(user.Validate(map[string]any{"name": "alice"})).To(MatchError(`key "created_by" is required`))
+		g.Expect(user.Validate(map[string]any{"created_by": "ops", "name": "alice", "extra": 1.0})).To(MatchError(`key "extra" not expected`))
+		g.Expect(audit.Validate(map[string]any{"created_by": "ops", "note": "a long note here"})).To(HaveOccurred())
+	})
+
+	t.Run("combines the keys of all schemas", func(t *testing.T) {
+		g := NewWithT(t)
+		all := validation.AllOf(audit, pagination)
+		g.Expect(all.Validate(map[string]any{"created_by": "ops", "limit": 10.0, "note": "ok"})).To(Succeed())
+		g.Expect(all.Validate(map[string]any{"created_by": "ops"})).To(MatchError(`key "note" is required`))
+		g.Expect(all.Validate(map[string]any{"created_by": "ops", "note": "x"})).To(MatchError(ContainSubstring("at least 2 characters")))
+		g.Expect(all.Validate(map[string]any{"created_by": "ops", "note": "far too long a note"})).To(MatchError(ContainSubstring("at most 10 characters")))
+		g.Expect(all.Validate(map[string]any{"created_by": "ops", "note": "ok", "limit": 500.0})).To(MatchError(ContainSubstring(`key "limit"`)))
+	})
+
+	t.Run("keeps combined schemas introspectable", func(t *testing.T) {
+		g := NewWithT(t)
+		diff := validation.DiffRules(audit, validation.AllOf(audit, pagination))
+		g.Expect(diff.Breaking()).To(BeTrue())
+		g.Expect(diff.Changes).To(ContainElement(validation.RuleChange{Key: "note", Rule: "required", Kind: validation.RuleTightened, Breaking: true}))
+	})
+
+	t.Run("accepts maps valid against any schema", func(t *testing.T) {
+		g := NewWithT(t)
+		byEmail := validation.NewSchema(validation.Key("email", validation.PatternRule("@")).Required())
+		byPhone := validation.NewSchema(validation.Key("phone", validation.PatternRule(`^\+\d+$`)).Required())
+		validate := validation.AnyOf(byEmail, byPhone)
+		g.Expect(validate(map[string]any{"email": "a@b.c"})).To(Succeed())
+		g.Expect(validate(map[string]any{"phone": "+3312345678"})).To(Succeed())
+		err := validate(map[string]any{"phone": "12"})
+		g.Expect(err).To(MatchError(ContainSubstring("all validators failed")))
+		g.Expect(validation.IsValidationError(err)).To(BeTrue())
+	})
+}
@@ -0,0 +1,113 @@
+package validation
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ValidatorCtx is a context-aware validation function that validates a value of type T.
+// Use it for checks that need request-scoped values, deadlines or cancellation, such as
+// lookups in a database or a remote service.
+type ValidatorCtx[T any] func(context.Context, T) error
+
+// ValidateCtx applies context-aware validators to a value and returns the first error encountered.
+// Validators are applied in order. Validation stops at the first failure, or with the context
+// error when ctx is done before a validator runs.
+//
+// Example:
+//
+//	err := validation.ValidateCtx(ctx, input.Email,
+//	    validation.WithContext(validation.Required[string]()),
+//	    uniqueEmail(db),
+//	)
+func ValidateCtx[T any](ctx context.Context, value T, validators ...ValidatorCtx[T]) error {
+	for _, validator := range validators {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := validator(ctx, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ErrTimeout is returned by ValidateWithTimeout when validation exceeds its time budget.
+// It is not a validation error.
+var ErrTimeout = errors.New("validation timed out")
+
+// ValidateWithTimeout is like ValidateCtx, but bounds the whole validation to d.
+// Validators receive a context with the deadline. If they have not finished when it expires,
+// ErrTimeout is returned without waiting for them, so a hanging remote check cannot hold up
+// the caller. If ctx is canceled first, its error is returned instead.
+//
+// Validators that ignore their context keep running in the background until they return.
+//
+// Example:
+//
+//	err := validation.ValidateWithTimeout(r.Context(), 200*time.Millisecond, input.Domain, domainResolves(resolver))
+//	if errors.Is(err, validation.ErrTimeout) {
+//	    // answer 503 rather than 400
+//	}
+func ValidateWithTimeout[T any](ctx context.Context, d time.Duration, value T, validators ...ValidatorCtx[T]) error {
+	timeoutCtx, cancel := context.WithTimeout(ctx, d)
+	defer cancel()
+
+	result := make(chan error, 1)
+	go func() {
+		result <- ValidateCtx(timeoutCtx, value, validators...)
+	}()
+
+	select {
+	case err := <-result:
+		if errors.Is(err, context.DeadlineExceeded) && ctx.Err() == nil {
+			return ErrTimeout
+		}
+		return err
+	case <-timeoutCtx.Done():
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		return ErrTimeout
+	}
+}
+
+// WithContext adapts a Validator to a ValidatorCtx that ignores the context, so plain
+// validators can be combined with context-aware ones.
+//
+// Example:
+//
+//	validation.ValidateCtx(ctx, username, validation.WithContext(validation.MinLength(3)), usernameAvailable(db))
+func WithContext[T any](validator Validator[T]) ValidatorCtx[T] {
+	return func(_ context.Context, v T) error {
+		return validator(v)
+	}
+}
+
+// ValidatableCtx is an interface for types whose Validate method needs a context, for
+// instance to look up related records or to honor request deadlines.
+// It is recognized wherever Validatable is: ValidateNested, Walk and the integrations.
+type ValidatableCtx interface {
+	Validate(ctx context.Context) error
+}
+
+// ValidateNestedCtx is like ValidateNested, passing ctx to values implementing ValidatableCtx.
+//
+// Example:
+//
+//	func (o Order) Validate(ctx context.Context) error {
+//	    return errors.Join(
+//	        validation.ValidateCtx(ctx, o.CustomerID, customerExists(db)),
+//	        validation.NewFieldError("shipping", validation.ValidateNestedCtx(ctx, o.Shipping)),
+//	    )
+//	}
+func ValidateNestedCtx[T any](ctx context.Context, value T) error {
+	switch v := any(value).(type) {
+	case Validatable:
+		return v.Validate()
+	case ValidatableCtx:
+		return v.Validate(ctx)
+	}
+	return nil
+}
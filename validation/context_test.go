@@ -0,0 +1,150 @@
+package validation_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/quantumcycle/protego/validation"
+)
+
+type ctxKey struct{}
+
+func TestValidateCtx(t *testing.T) {
+	reserved := func(ctx context.Context, v string) error {
+		if v == ctx.Value(ctxKey{}) {
+			return validation.NewValidationError("already taken")
+		}
+		return nil
+	}
+	ctx := context.WithValue(context.Background(), ctxKey{}, "admin")
+
+	t.Run("passes when all validators pass", func(t *testing.T) {
+		g := NewWithT(t)
+		err := validation.ValidateCtx(ctx, "john", validation.WithContext(validation.MinLength(3)), reserved)
+		g.Expect(err).ToNot(HaveOccurred())
+	})
+
+	t.Run("passes the context to validators", func(t *testing.T) {
+		g := NewWithT(t)
+		err := validation.ValidateCtx(ctx, "admin", validation.WithContext(validation.MinLength(3)), reserved)
+		g.Expect(err).To(MatchError("already taken"))
+	})
+
+	t.Run("stops at the first failure", func(t *testing.T) {
+		g := NewWithT(t)
+		err := validation.ValidateCtx(ctx, "ad", validation.WithContext(validation.MinLength(3)), reserved)
+		g.Expect(err).To(MatchError("must be at least 3 characters"))
+	})
+
+	t.Run("returns the context error when done", func(t *testing.T) {
+		g := NewWithT(t)
+		canceled, cancel := context.WithCancel(ctx)
+		cancel()
+		called := false
+		err := validation.ValidateCtx(canceled, "john", func(context.Context, string) error {
+			called = true
+			return nil
+		})
+		g.Expect(errors.Is(err, context.Canceled)).To(BeTrue())
+		g.Expect(validation.IsValidationError(err)).To(BeFalse())
+		g.Expect(called).To(BeFalse())
+	})
+}
+
+type ctxAccount struct {
+	Username string `json:"username"`
+}
+
+func (a ctxAccount) Validate(ctx context.Context) error {
+	if a.Username == ctx.Value(ctxKey{}) {
+		return validation.NewFieldError("username", validation.NewValidationError("already taken"))
+	}
+	return nil
+}
+
+type ctxSignup struct {
+	Account ctxAccount `json:"account"`
+}
+
+func TestValidatableCtx(t *testing.T) {
+	ctx := context.WithValue(context.Background(), ctxKey{}, "admin")
+
+	t.Run("ValidateNestedCtx passes the context", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(validation.ValidateNestedCtx(ctx, ctxAccount{Username: "admin"})).To(MatchError("username: already taken"))
+		g.Expect(validation.ValidateNestedCtx(ctx, ctxAccount{Username: "john"})).To(Succeed())
+	})
+
+	t.Run("ValidateNestedCtx still handles Validatable", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(validation.ValidateNestedCtx(ctx, walkItem{Quantity: 0})).To(MatchError("quantity: must be positive"))
+	})
+
+	t.Run("ValidateNested uses a background context", func(t *testing.T) {
+		g := NewWithT(t)
+		called := false
+		validatable := ctxFunc(func(ctx context.Context) error {
+			called = true
+			g.Expect(ctx).To(Equal(context.Background()))
+			return nil
+		})
+		g.Expect(validation.ValidateNested(validatable)).To(Succeed())
+		g.Expect(called).To(BeTrue())
+	})
+
+	t.Run("WalkCtx passes the context", func(t *testing.T) {
+		g := NewWithT(t)
+		err := validation.WalkCtx(ctx, ctxSignup{Account: ctxAccount{Username: "admin"}}, nil)
+		g.Expect(err).To(MatchError("account: username: already taken"))
+	})
+}
+
+type ctxFunc func(ctx context.Context) error
+
+func (f ctxFunc) Validate(ctx context.Context) error {
+	return f(ctx)
+}
+
+func TestValidateWithTimeout(t *testing.T) {
+	hang := func(context.Context, string) error {
+		time.Sleep(time.Second)
+		return nil
+	}
+	waitForDeadline := func(ctx context.Context, _ string) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+
+	t.Run("returns the validation result within the budget", func(t *testing.T) {
+		g := NewWithT(t)
+		err := validation.ValidateWithTimeout(context.Background(), time.Second, "jo", validation.WithContext(validation.MinLength(3)))
+		g.Expect(err).To(MatchError("must be at least 3 characters"))
+	})
+
+	t.Run("returns ErrTimeout without waiting for hanging validators", func(t *testing.T) {
+		g := NewWithT(t)
+		start := time.Now()
+		err := validation.ValidateWithTimeout(context.Background(), 10*time.Millisecond, "john", hang)
+		g.Expect(err).To(MatchError(validation.ErrTimeout))
+		g.Expect(time.Since(start)).To(BeNumerically("<", 500*time.Millisecond))
+		g.Expect(validation.IsValidationError(err)).To(BeFalse())
+	})
+
+	t.Run("returns ErrTimeout when validators report the deadline", func(t *testing.T) {
+		g := NewWithT(t)
+		err := validation.ValidateWithTimeout(context.Background(), 10*time.Millisecond, "john", waitForDeadline)
+		g.Expect(err).To(MatchError(validation.ErrTimeout))
+	})
+
+	t.Run("returns the parent context error when canceled", func(t *testing.T) {
+		g := NewWithT(t)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		err := validation.ValidateWithTimeout(ctx, time.Second, "john", waitForDeadline)
+		g.Expect(errors.Is(err, context.Canceled)).To(BeTrue())
+	})
+}
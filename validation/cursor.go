@@ -0,0 +1,71 @@
+package validation
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+)
+
+// CursorCodec encodes pagination cursors as opaque strings signed with HMAC-SHA256, so that
+// clients cannot forge or alter them, and decodes them back. Cursors are URL-safe base64.
+type CursorCodec struct {
+	key []byte
+}
+
+// minCursorKeySize is the minimum size of the keys of NewCursorCodec, that of the
+// HMAC-SHA256 output.
+const minCursorKeySize = 32
+
+// NewCursorCodec returns a codec signing cursors with key, which must hold at least 32
+// random bytes and be shared by every instance of the service. It panics if key is
+// shorter, as cursors signed with such keys could be forged.
+//
+// Example:
+//
+//	cursors := validation.NewCursorCodec(secret)
+//	next := cursors.Encode([]byte(lastID))
+func NewCursorCodec(key []byte) *CursorCodec {
+	if len(key) < minCursorKeySize {
+		panic(fmt.Sprintf("validation: NewCursorCodec requires a key of at least %d bytes", minCursorKeySize))
+	}
+	return &CursorCodec{key: append([]byte(nil), key...)}
+}
+
+// Encode returns the signed cursor of payload.
+func (c *CursorCodec) Encode(payload []byte) string {
+	return base64.RawURLEncoding.EncodeToString(append(append([]byte(nil), payload...), c.sign(payload)...))
+}
+
+// Decode returns the payload of a cursor produced by Encode with the same key. Malformed and
+// tampered cursors are rejected with a validation error.
+func (c *CursorCodec) Decode(cursor string) ([]byte, error) {
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil || len(b) < sha256.Size {
+		return nil, NewValidationError("must be a valid cursor")
+	}
+	payload, mac := b[:len(b)-sha256.Size], b[len(b)-sha256.Size:]
+	if !hmac.Equal(mac, c.sign(payload)) {
+		return nil, NewValidationError("must be a valid cursor")
+	}
+	return payload, nil
+}
+
+func (c *CursorCodec) sign(payload []byte) []byte {
+	h := hmac.New(sha256.New, c.key)
+	h.Write(payload)
+	return h.Sum(nil)
+}
+
+// IsValidCursor validates that a string is a pagination cursor produced by codec and not
+// altered since.
+//
+// Example:
+//
+//	validation.Validate(query.Get("cursor"), validation.IsValidCursor(cursors))
+func IsValidCursor(codec *CursorCodec) Validator[string] {
+	return func(v string) error {
+		_, err := codec.Decode(v)
+		return err
+	}
+}
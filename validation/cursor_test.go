@@ -0,0 +1,54 @@
+package validation_test
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/quantumcycle/protego/validation"
+)
+
+func TestCursorCodec(t *testing.T) {
+	codec := validation.NewCursorCodec([]byte("0123456789abcdef0123456789abcdef"))
+
+	t.Run("round-trips payloads", func(t *testing.T) {
+		g := NewWithT(t)
+		cursor := codec.Encode([]byte(`{"id":42}`))
+		g.Expect(cursor).ToNot(ContainSubstring("42"))
+		payload, err := codec.Decode(cursor)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(string(payload)).To(Equal(`{"id":42}`))
+	})
+
+	t.Run("rejects tampered cursors", func(t *testing.T) {
+		g := NewWithT(t)
+		cursor := codec.Encode([]byte("page-2"))
+		tampered := strings.Replace(cursor, cursor[:1], string(cursor[0]^1), 1)
+		_, err := codec.Decode(tampered)
+		g.Expect(err).To(MatchError("must be a valid cursor"))
+		g.Expect(validation.IsValidationError(err)).To(BeTrue())
+	})
+
+	t.Run("rejects cursors signed with another key", func(t *testing.T) {
+		g := NewWithT(t)
+		other := validation.NewCursorCodec([]byte("another key, long enough to sign"))
+		_, err := codec.Decode(other.Encode([]byte("page-2")))
+		g.Expect(err).To(MatchError("must be a valid cursor"))
+	})
+
+	t.Run("panics with short keys", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(func() { validation.NewCursorCodec(nil) }).To(PanicWith("validation: NewCursorCodec requires a key of at least 32 bytes"))
+		g.Expect(func() { validation.NewCursorCodec([]byte("0123456789abcdef")) }).To(Panic())
+	})
+}
+
+func TestIsValidCursor(t *testing.T) {
+	g := NewWithT(t)
+	codec := validation.NewCursorCodec([]byte("0123456789abcdef0123456789abcdef"))
+	g.Expect(validation.Validate(codec.Encode(nil), validation.IsValidCursor(codec))).To(Succeed())
+	for _, v := range []string{"", "not base64!", "c2hvcnQ"} {
+		g.Expect(validation.Validate(v, validation.IsValidCursor(codec))).To(MatchError("must be a valid cursor"), v)
+	}
+}
@@ -1,7 +1,9 @@
 package validation
 
 import (
+	"errors"
 	"fmt"
+	"sort"
 	"time"
 )
 
@@ -52,6 +54,66 @@ func IsDateFormat(layout string) Validator[string] {
 	}
 }
 
+// IsCalendarDate validates that a string is a date existing in the calendar, written exactly
+// as layout formats it. Out-of-range days and months such as "2023-02-29" or "2024-13-01" are
+// rejected, as well as values that a lenient layout would accept but never produce, such as
+// "2024-01-05" for the layout "2006-1-2".
+//
+// Example:
+//
+//	validation.Validate(input.DeliveryDate, validation.IsCalendarDate("2/1/2006"))
+func IsCalendarDate(layout string) Validator[string] {
+	return func(v string) error {
+		t, err := time.Parse(layout, v)
+		if err != nil || t.Format(layout) != v {
+			return NewValidationError("must be a valid calendar date")
+		}
+		return nil
+	}
+}
+
+// IsLeapYearSafeDate is IsCalendarDate for dates that must exist every year, such as the
+// month and day of a yearly renewal: February 29 is rejected. Layouts without a year, such
+// as "01-02", would otherwise accept it, parsing it in the leap year 0.
+//
+// Example:
+//
+//	validation.Validate(input.RenewalDay, validation.IsLeapYearSafeDate("01-02"))
+func IsLeapYearSafeDate(layout string) Validator[string] {
+	isCalendarDate := IsCalendarDate(layout)
+	return func(v string) error {
+		if err := isCalendarDate(v); err != nil {
+			return err
+		}
+		if t, _ := time.Parse(layout, v); t.Month() == time.February && t.Day() == 29 {
+			return NewValidationError("must be a date that exists every year")
+		}
+		return nil
+	}
+}
+
+// DaysInMonth returns the number of days of a month, taking leap years into account.
+func DaysInMonth(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// IsValidCalendarDate validates a date given as separate year, month and day numbers, such as
+// the fields of a date picker, without the normalization of time.Date that turns February 30
+// into March 1. Errors are attributed to the "month" or "day" field.
+//
+// Example:
+//
+//	err := validation.IsValidCalendarDate(2023, 2, 29) // "day: must be between 1 and 28"
+func IsValidCalendarDate(year, month, day int) error {
+	if month < 1 || month > 12 {
+		return NewFieldError("month", NewValidationError("must be between 1 and 12"))
+	}
+	if days := DaysInMonth(year, time.Month(month)); day < 1 || day > days {
+		return NewFieldError("day", NewValidationError(fmt.Sprintf("must be between 1 and %d", days)))
+	}
+	return nil
+}
+
 // IsFutureDateFormat validates that a string represents a date in the future.
 // The date is parsed using the specified layout.
 //
@@ -231,3 +293,36 @@ func IsTimeAfter(after time.Time) Validator[time.Time] {
 		return nil
 	}
 }
+
+// NoOverlappingRanges validates that the time ranges of the elements of a slice, such as
+// bookings, price periods or promotions, do not overlap. Ranges are half-open: a range may
+// start when the previous one ends. Every overlapping pair is reported, by element index.
+//
+// Example:
+//
+//	validation.Validate(input.Periods, validation.NoOverlappingRanges(
+//	    func(p PricePeriod) time.Time { return p.From },
+//	    func(p PricePeriod) time.Time { return p.To },
+//	))
+func NoOverlappingRanges[T any](start func(T) time.Time, end func(T) time.Time) Validator[[]T] {
+	return func(values []T) error {
+		order := make([]int, len(values))
+		for i := range order {
+			order[i] = i
+		}
+		sort.SliceStable(order, func(a, b int) bool {
+			return start(values[order[a]]).Before(start(values[order[b]]))
+		})
+
+		var errs []error
+		for k, i := range order {
+			for _, j := range order[k+1:] {
+				if !start(values[j]).Before(end(values[i])) {
+					break // later ranges start even later
+				}
+				errs = append(errs, NewValidationError(fmt.Sprintf("items %d and %d overlap", min(i, j), max(i, j))))
+			}
+		}
+		return errors.Join(errs...)
+	}
+}
@@ -0,0 +1,150 @@
+package validation
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Normalizer transforms a string before it is compared against a denylist.
+type Normalizer func(string) string
+
+// FoldCase lower-cases s.
+func FoldCase(s string) string {
+	return strings.ToLower(s)
+}
+
+// FoldConfusables replaces characters that look like ASCII letters or digits (Cyrillic and
+// Greek homoglyphs, fullwidth forms) with their ASCII counterpart, so that "аdmin" written
+// with a Cyrillic а folds to "admin".
+func FoldConfusables(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= '！' && r <= '～' { // fullwidth ASCII
+			return r - '！' + '!'
+		}
+		if c, ok := confusables[r]; ok {
+			return c
+		}
+		return r
+	}, s)
+}
+
+// StripSeparators removes spaces and the separators - _ . from s, so that "ad-min" becomes "admin".
+func StripSeparators(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '-', '_', '.':
+			return -1
+		}
+		return r
+	}, s)
+}
+
+// Normalize chains normalizers, applying them in order.
+//
+// Example:
+//
+//	normalize := validation.Normalize(validation.FoldConfusables, validation.FoldCase)
+func Normalize(normalizers ...Normalizer) Normalizer {
+	return func(s string) string {
+		for _, n := range normalizers {
+			s = n(s)
+		}
+		return s
+	}
+}
+
+// NormalizeIdentifier folds confusable characters and case, and strips separators. It is the
+// normalization used by NotMatchingAny and a good default for reserved-word checks.
+var NormalizeIdentifier = Normalize(FoldConfusables, FoldCase, StripSeparators)
+
+// NotInNormalized is like NotIn for strings, but compares the value and the forbidden entries
+// after applying normalizer to both. A nil normalizer compares them unchanged.
+//
+// Example:
+//
+//	// rejects "admin", "Admin", "аdmin" (Cyrillic а) and "ad-min"
+//	validation.Validate(username, validation.NotInNormalized(validation.NormalizeIdentifier, "admin", "root"))
+func NotInNormalized(normalizer Normalizer, forbidden ...string) Validator[string] {
+	if normalizer == nil {
+		normalizer = func(s string) string { return s }
+	}
+	normalized := make(map[string]string, len(forbidden))
+	for _, f := range forbidden {
+		normalized[normalizer(f)] = f
+	}
+	return func(v string) error {
+		if f, ok := normalized[normalizer(v)]; ok {
+			return NewValidationError(fmt.Sprintf("cannot be %q", f))
+		}
+		return nil
+	}
+}
+
+// NotMatchingAny validates that a string matches none of the wildcard patterns, where * matches
+// any sequence of characters and ? a single character. The value and the patterns are compared
+// after NormalizeIdentifier.
+//
+// Example:
+//
+//	// rejects "admin", "SysAdmin", "admin_01" and "support-team"
+//	validation.Validate(username, validation.NotMatchingAny("*admin*", "support*"))
+func NotMatchingAny(patterns ...string) Validator[string] {
+	normalized := make([][]rune, len(patterns))
+	for i, p := range patterns {
+		normalized[i] = []rune(NormalizeIdentifier(p))
+	}
+	return func(v string) error {
+		value := []rune(NormalizeIdentifier(v))
+		for i, p := range normalized {
+			if wildcardMatch(p, value) {
+				return NewValidationError(fmt.Sprintf("cannot match %q", patterns[i]))
+			}
+		}
+		return nil
+	}
+}
+
+// wildcardMatch reports whether s matches pattern, where * matches any sequence of runes and
+// ? any single rune.
+func wildcardMatch(pattern, s []rune) bool {
+	p, i := 0, 0
+	star, mark := -1, 0
+	for i < len(s) {
+		switch {
+		case p < len(pattern) && (pattern[p] == '?' || pattern[p] == s[i]):
+			p++
+			i++
+		case p < len(pattern) && pattern[p] == '*':
+			star, mark = p, i
+			p++
+		case star >= 0:
+			// backtrack: let the last * absorb one more rune
+			mark++
+			p, i = star+1, mark
+		default:
+			return false
+		}
+	}
+	for p < len(pattern) && pattern[p] == '*' {
+		p++
+	}
+	return p == len(pattern)
+}
+
+// confusables maps common homoglyphs of ASCII letters and digits to the ASCII character.
+var confusables = map[rune]rune{
+	// Cyrillic
+	'а': 'a', 'в': 'b', 'е': 'e', 'ё': 'e', 'і': 'i', 'ї': 'i', 'ј': 'j', 'к': 'k', 'м': 'm',
+	'н': 'h', 'о': 'o', 'р': 'p', 'с': 'c', 'т': 't', 'у': 'y', 'х': 'x', 'ѕ': 's', 'ԁ': 'd',
+	'ԛ': 'q', 'ԝ': 'w', 'ո': 'n',
+	'А': 'A', 'В': 'B', 'Е': 'E', 'Ё': 'E', 'І': 'I', 'Ї': 'I', 'Ј': 'J', 'К': 'K', 'М': 'M',
+	'Н': 'H', 'О': 'O', 'Р': 'P', 'С': 'C', 'Т': 'T', 'У': 'Y', 'Х': 'X', 'Ѕ': 'S', 'Ԁ': 'D',
+	'Ԛ': 'Q', 'Ԝ': 'W',
+	// Greek
+	'α': 'a', 'ε': 'e', 'ι': 'i', 'κ': 'k', 'ν': 'v', 'ο': 'o', 'ρ': 'p', 'τ': 't', 'υ': 'u',
+	'χ': 'x',
+	'Α': 'A', 'Β': 'B', 'Ε': 'E', 'Ζ': 'Z', 'Η': 'H', 'Ι': 'I', 'Κ': 'K', 'Μ': 'M', 'Ν': 'N',
+	'Ο': 'O', 'Ρ': 'P', 'Τ': 'T', 'Υ': 'Y', 'Χ': 'X',
+	// Latin lookalikes
+	'ı': 'i', 'ℓ': 'l', 'ǀ': 'l',
+}
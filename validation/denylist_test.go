@@ -0,0 +1,72 @@
+package validation_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/quantumcycle/protego/validation"
+)
+
+func TestNormalizers(t *testing.T) {
+	t.Run("FoldConfusables folds homoglyphs and fullwidth forms", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(validation.FoldConfusables("аdmin")).To(Equal("admin")) // Cyrillic а
+		g.Expect(validation.FoldConfusables("ΑDMΙΝ")).To(Equal("ADMIN")) // Greek
+		g.Expect(validation.FoldConfusables("ａｄｍｉｎ")).To(Equal("admin")) // fullwidth
+		g.Expect(validation.FoldConfusables("café")).To(Equal("café"))
+	})
+
+	t.Run("StripSeparators removes separators", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(validation.StripSeparators("ad-m_i.n x")).To(Equal("adminx"))
+	})
+
+	t.Run("Normalize applies normalizers in order", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(validation.NormalizeIdentifier("Ad-Мin")).To(Equal("admin")) // Cyrillic М
+	})
+}
+
+func TestNotInNormalized(t *testing.T) {
+	t.Run("rejects normalized matches", func(t *testing.T) {
+		g := NewWithT(t)
+		v := validation.NotInNormalized(validation.NormalizeIdentifier, "admin", "root")
+		for _, name := range []string{"admin", "ADMIN", "аdmin", "ad-min", "R.o.o.t"} {
+			g.Expect(validation.Validate(name, v)).To(HaveOccurred(), name)
+		}
+		g.Expect(validation.Validate("аdmin", v)).To(MatchError(`cannot be "admin"`))
+	})
+
+	t.Run("passes other values", func(t *testing.T) {
+		g := NewWithT(t)
+		v := validation.NotInNormalized(validation.NormalizeIdentifier, "admin")
+		g.Expect(validation.Validate("administrator", v)).To(Succeed())
+	})
+
+	t.Run("compares values unchanged with a nil normalizer", func(t *testing.T) {
+		g := NewWithT(t)
+		v := validation.NotInNormalized(nil, "admin")
+		g.Expect(validation.Validate("Admin", v)).To(Succeed())
+		g.Expect(validation.Validate("admin", v)).To(MatchError(`cannot be "admin"`))
+	})
+}
+
+func TestNotMatchingAny(t *testing.T) {
+	v := validation.NotMatchingAny("*admin*", "support?", "root")
+
+	t.Run("rejects values matching a pattern", func(t *testing.T) {
+		g := NewWithT(t)
+		for _, name := range []string{"admin", "SysAdmin", "admin_01", "аdmin", "ad-min", "support1", "ROOT"} {
+			g.Expect(validation.Validate(name, v)).To(HaveOccurred(), name)
+		}
+		g.Expect(validation.Validate("SysAdmin", v)).To(MatchError(`cannot match "*admin*"`))
+	})
+
+	t.Run("passes values matching no pattern", func(t *testing.T) {
+		g := NewWithT(t)
+		for _, name := range []string{"alice", "support", "support12", "rooted"} {
+			g.Expect(validation.Validate(name, v)).To(Succeed(), name)
+		}
+	})
+}
@@ -0,0 +1,286 @@
+package validation
+
+import (
+	"reflect"
+	"slices"
+)
+
+// ChangeKind is the kind of a RuleChange.
+type ChangeKind string
+
+// Kinds of rule changes.
+const (
+	// RuleAdded is a key or rule present only in the new schema.
+	RuleAdded ChangeKind = "added"
+	// RuleRemoved is a key or rule present only in the old schema.
+	RuleRemoved ChangeKind = "removed"
+	// RuleTightened is a rule or key rejecting values the old one accepted, and no others.
+	RuleTightened ChangeKind = "tightened"
+	// RuleLoosened is a rule or key accepting values the old one rejected, and no others.
+	RuleLoosened ChangeKind = "loosened"
+	// RuleChanged is a rule whose parameters changed in a way that is neither.
+	RuleChanged ChangeKind = "changed"
+)
+
+// RuleChange is a difference between the rules of two schemas, as reported by DiffRules.
+type RuleChange struct {
+	// Key is the schema key of the change, empty for a change of the schema itself.
+	Key string `json:"key,omitempty"`
+	// Rule is the name of the changed rule, empty for a change of the key itself, such as
+	// its addition. It is "required" for a change of requiredness, "extra_keys" for a
+	// change of the acceptance of keys the schema does not define and "strictness" for a
+	// change of the strictness of the schema.
+	Rule string     `json:"rule,omitempty"`
+	Kind ChangeKind `json:"kind"`
+	// Old and New are the parameters of the rule in each schema.
+	Old map[string]any `json:"old,omitempty"`
+	New map[string]any `json:"new,omitempty"`
+	// Breaking reports whether payloads valid against the old schema may be rejected by
+	// the new one.
+	Breaking bool `json:"breaking"`
+}
+
+// RuleDiff lists the changes between the rules of two schemas, keys of the new schema
+// first, in their order, then removed keys.
+type RuleDiff struct {
+	Changes []RuleChange `json:"changes"`
+}
+
+// Breaking reports whether any change is breaking.
+func (d RuleDiff) Breaking() bool {
+	return slices.ContainsFunc(d.Changes, func(c RuleChange) bool { return c.Breaking })
+}
+
+// DiffRules compares the rules of two versions of a schema, for API owners to detect
+// breaking validation changes in CI before a release: added rules and required keys,
+// tightened bounds and fewer allowed values reject payloads the old schema accepted.
+//
+// Rule parameters are compared following the conventions of NewRule: a higher "min", a
+// lower "max" or fewer "values" tighten a rule, the opposite loosens it. Any other
+// parameter change is reported as RuleChanged, and considered breaking.
+//
+// Example:
+//
+//	diff := validation.DiffRules(v1.UserSchema, v2.UserSchema)
+//	if diff.Breaking() {
+//	    json.NewEncoder(os.Stdout).Encode(diff)
+//	    os.Exit(1)
+//	}
+func DiffRules(oldSchema, newSchema *Schema) RuleDiff {
+	diff := RuleDiff{Changes: []RuleChange{}}
+	if oldSchema.strictness != newSchema.strictness {
+		change := RuleChange{
+			Rule: "strictness",
+			Kind: RuleLoosened,
+			Old:  map[string]any{"level": oldSchema.strictness.String()},
+			New:  map[string]any{"level": newSchema.strictness.String()},
+		}
+		if newSchema.strictness > oldSchema.strictness {
+			change.Kind, change.Breaking = RuleTightened, true
+		}
+		diff.Changes = append(diff.Changes, change)
+	}
+	if oldSchema.acceptsExtra() != newSchema.acceptsExtra() {
+		change := RuleChange{Rule: "extra_keys", Kind: RuleLoosened}
+		if !newSchema.acceptsExtra() {
+			change.Kind, change.Breaking = RuleTightened, true
+		}
+		diff.Changes = append(diff.Changes, change)
+	}
+
+	for _, newKey := range newSchema.keys {
+		oldKey, ok := oldSchema.key(newKey.name)
+		if !ok {
+			// a new optional key only breaks payloads sending it when extra keys were accepted
+			breaking := newKey.required || (oldSchema.acceptsExtra() && len(newKey.rules) > 0)
+			diff.Changes = append(diff.Changes, RuleChange{Key: newKey.name, Kind: RuleAdded, Breaking: breaking})
+			continue
+		}
+		diff.Changes = append(diff.Changes, diffKey(oldKey, newKey)...)
+	}
+
+	for _, oldKey := range oldSchema.keys {
+		if _, ok := newSchema.key(oldKey.name); !ok {
+			diff.Changes = append(diff.Changes, RuleChange{Key: oldKey.name, Kind: RuleRemoved, Breaking: !newSchema.acceptsExtra()})
+		}
+	}
+	return diff
+}
+
+// keyNames returns the names of the keys of the schema, in order.
+func (s *Schema) keyNames() []string {
+	names := make([]string, len(s.keys))
+	for i, k := range s.keys {
+		names[i] = k.name
+	}
+	return names
+}
+
+func (s *Schema) key(name string) (SchemaKey, bool) {
+	for _, k := range s.keys {
+		if k.name == name {
+			return k, true
+		}
+	}
+	return SchemaKey{}, false
+}
+
+// diffKey compares the requiredness and the rules, matched by name, of two versions of a key.
+func diffKey(oldKey, newKey SchemaKey) []RuleChange {
+	var changes []RuleChange
+	if oldKey.required != newKey.required {
+		change := RuleChange{Key: newKey.name, Rule: "required", Kind: RuleLoosened}
+		if newKey.required {
+			change.Kind, change.Breaking = RuleTightened, true
+		}
+		changes = append(changes, change)
+	}
+	for _, newRule := range newKey.rules {
+		oldRule, ok := findRule(oldKey.rules, newRule.name)
+		if !ok {
+			changes = append(changes, RuleChange{Key: newKey.name, Rule: newRule.name, Kind: RuleAdded, New: newRule.params, Breaking: true})
+			continue
+		}
+		kind := compareParams(oldRule.params, newRule.params)
+		if kind == "" {
+			continue
+		}
+		changes = append(changes, RuleChange{
+			Key:      newKey.name,
+			Rule:     newRule.name,
+			Kind:     kind,
+			Old:      oldRule.params,
+			New:      newRule.params,
+			Breaking: kind != RuleLoosened,
+		})
+	}
+	for _, oldRule := range oldKey.rules {
+		if _, ok := findRule(newKey.rules, oldRule.name); !ok {
+			changes = append(changes, RuleChange{Key: newKey.name, Rule: oldRule.name, Kind: RuleRemoved, Old: oldRule.params})
+		}
+	}
+	return changes
+}
+
+func findRule(rules []Rule, name string) (Rule, bool) {
+	for _, r := range rules {
+		if r.name == name {
+			return r, true
+		}
+	}
+	return Rule{}, false
+}
+
+// compareParams tells whether the new parameters of a rule tighten or loosen the old ones,
+// following the conventions of NewRule, returning an empty kind when they are equivalent.
+func compareParams(oldParams, newParams map[string]any) ChangeKind {
+	tightened, loosened := false, false
+	for name := range mergeKeys(oldParams, newParams) {
+		oldValue, newValue := oldParams[name], newParams[name]
+		if reflect.DeepEqual(oldValue, newValue) {
+			continue
+		}
+		switch compareParam(name, oldValue, newValue) {
+		case "":
+			continue
+		case RuleTightened:
+			tightened = true
+		case RuleLoosened:
+			loosened = true
+		default:
+			return RuleChanged
+		}
+	}
+	switch {
+	case tightened && loosened:
+		return RuleChanged
+	case tightened:
+		return RuleTightened
+	case loosened:
+		return RuleLoosened
+	}
+	return ""
+}
+
+// compareParam compares the values of a bound or allowed values parameter, returning an
+// empty kind when they are equivalent.
+func compareParam(name string, oldValue, newValue any) ChangeKind {
+	switch name {
+	case "min", "max":
+		oldBound, okOld := toFloat(oldValue)
+		newBound, okNew := toFloat(newValue)
+		if !okOld || !okNew {
+			// a bound added is tightening, a bound removed loosening
+			if oldValue == nil {
+				return RuleTightened
+			}
+			if newValue == nil {
+				return RuleLoosened
+			}
+			return RuleChanged
+		}
+		switch {
+		case newBound == oldBound:
+			return ""
+		case (name == "min") == (newBound > oldBound):
+			return RuleTightened
+		}
+		return RuleLoosened
+	case "values":
+		fewer, more := isSubset(newValue, oldValue), isSubset(oldValue, newValue)
+		switch {
+		case fewer && more:
+			return "" // reordered
+		case fewer:
+			return RuleTightened
+		case more:
+			return RuleLoosened
+		}
+	}
+	return RuleChanged
+}
+
+func mergeKeys(a, b map[string]any) map[string]bool {
+	keys := make(map[string]bool, len(a)+len(b))
+	for k := range a {
+		keys[k] = true
+	}
+	for k := range b {
+		keys[k] = true
+	}
+	return keys
+}
+
+func toFloat(v any) (float64, bool) {
+	rv := reflect.ValueOf(v)
+	switch {
+	case rv.CanInt():
+		return float64(rv.Int()), true
+	case rv.CanUint():
+		return float64(rv.Uint()), true
+	case rv.CanFloat():
+		return rv.Float(), true
+	}
+	return 0, false
+}
+
+// isSubset reports whether every element of the slice sub is in the slice super.
+func isSubset(sub, super any) bool {
+	subValue, superValue := reflect.ValueOf(sub), reflect.ValueOf(super)
+	if subValue.Kind() != reflect.Slice || superValue.Kind() != reflect.Slice {
+		return false
+	}
+	for i := 0; i < subValue.Len(); i++ {
+		found := false
+		for j := 0; j < superValue.Len(); j++ {
+			if reflect.DeepEqual(subValue.Index(i).Interface(), superValue.Index(j).Interface()) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
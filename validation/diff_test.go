@@ -0,0 +1,122 @@
+package validation_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/quantumcycle/protego/validation"
+)
+
+func TestDiffRules(t *testing.T) {
+
+	t.Run("reports no changes for identical schemas", func(t *testing.T) {
+		g := NewWithT(t)
+		schema := validation.NewSchema(validation.Key("name", validation.MinLengthRule(3)).Required())
+		diff := validation.DiffRules(schema, schema)
+		g.Expect(diff.Changes).To(BeEmpty())
+		g.Expect(diff.Breaking()).To(BeFalse())
+	})
+
+	t.Run("reports added and removed keys", func(t *testing.T) {
+		g := NewWithT(t)
+		v1 := validation.NewSchema(validation.Key("name"), validation.Key("nickname"))
+		v2 := validation.NewSchema(validation.Key("name"), validation.Key("email").Required(), validation.Key("bio"))
+		g.Expect(validation.DiffRules(v1, v2).Changes).To(Equal([]validation.RuleChange{
+			{Key: "email", Kind: validation.RuleAdded, Breaking: true},
+			{Key: "bio", Kind: validation.RuleAdded},
+			{Key: "nickname", Kind: validation.RuleRemoved, Breaking: true},
+		}))
+	})
+
+	t.Run("considers extra keys", func(t *testing.T) {
+		g := NewWithT(t)
+		v1 := validation.NewSchema(validation.Key("name")).AllowExtra()
+		v2 := validation.NewSchema(validation.Key("bio", validation.MaxLengthRule(100))).AllowExtra()
+		g.Expect(validation.DiffRules(v1, v2).Changes).To(Equal([]validation.RuleChange{
+			{Key: "bio", Kind: validation.RuleAdded, Breaking: true},
+			{Key: "name", Kind: validation.RuleRemoved},
+		}))
+
+		strict := validation.NewSchema(validation.Key("name"))
+		g.Expect(validation.DiffRules(v1, strict).Changes).To(Equal([]validation.RuleChange{
+			{Rule: "extra_keys", Kind: validation.RuleTightened, Breaking: true},
+		}))
+		g.Expect(validation.DiffRules(strict, v1).Breaking()).To(BeFalse())
+	})
+
+	t.Run("reports requiredness changes", func(t *testing.T) {
+		g := NewWithT(t)
+		optional := validation.NewSchema(validation.Key("name"))
+		required := validation.NewSchema(validation.Key("name").Required())
+		g.Expect(validation.DiffRules(optional, required).Changes).To(Equal([]validation.RuleChange{
+			{Key: "name", Rule: "required", Kind: validation.RuleTightened, Breaking: true},
+		}))
+		g.Expect(validation.DiffRules(required, optional).Changes).To(Equal([]validation.RuleChange{
+			{Key: "name", Rule: "required", Kind: validation.RuleLoosened},
+		}))
+	})
+
+	t.Run("reports added and removed rules", func(t *testing.T) {
+		g := NewWithT(t)
+		v1 := validation.NewSchema(validation.Key("name", validation.MaxLengthRule(50)))
+		v2 := validation.NewSchema(validation.Key("name", validation.PatternRule("^[a-z]+$")))
+		g.Expect(validation.DiffRules(v1, v2).Changes).To(Equal([]validation.RuleChange{
+			{Key: "name", Rule: "pattern", Kind: validation.RuleAdded, New: map[string]any{"pattern": "^[a-z]+$"}, Breaking: true},
+			{Key: "name", Rule: "max_length", Kind: validation.RuleRemoved, Old: map[string]any{"max": 50}},
+		}))
+	})
+
+	t.Run("compares bounds", func(t *testing.T) {
+		g := NewWithT(t)
+		diff := func(oldRule, newRule validation.Rule) validation.RuleChange {
+			changes := validation.DiffRules(
+				validation.NewSchema(validation.Key("k", oldRule)),
+				validation.NewSchema(validation.Key("k", newRule)),
+			).Changes
+			g.Expect(changes).To(HaveLen(1))
+			return changes[0]
+		}
+		g.Expect(diff(validation.MinLengthRule(3), validation.MinLengthRule(5))).To(Equal(validation.RuleChange{
+			Key: "k", Rule: "min_length", Kind: validation.RuleTightened,
+			Old: map[string]any{"min": 3}, New: map[string]any{"min": 5}, Breaking: true,
+		}))
+		g.Expect(diff(validation.MaxLengthRule(50), validation.MaxLengthRule(100)).Kind).To(Equal(validation.RuleLoosened))
+		g.Expect(diff(validation.MaxLengthRule(50), validation.MaxLengthRule(100)).Breaking).To(BeFalse())
+		g.Expect(diff(validation.RangeRule(0, 100), validation.RangeRule(10, 200)).Kind).To(Equal(validation.RuleChanged))
+		g.Expect(diff(validation.RangeRule(0, 100), validation.RangeRule(10, 90)).Kind).To(Equal(validation.RuleTightened))
+		g.Expect(diff(validation.PatternRule("a"), validation.PatternRule("b")).Breaking).To(BeTrue())
+	})
+
+	t.Run("compares allowed values", func(t *testing.T) {
+		g := NewWithT(t)
+		kind := func(oldRule, newRule validation.Rule) []validation.ChangeKind {
+			var kinds []validation.ChangeKind
+			for _, c := range validation.DiffRules(
+				validation.NewSchema(validation.Key("role", oldRule)),
+				validation.NewSchema(validation.Key("role", newRule)),
+			).Changes {
+				kinds = append(kinds, c.Kind)
+			}
+			return kinds
+		}
+		g.Expect(kind(validation.InRule("admin", "member"), validation.InRule("admin"))).To(Equal([]validation.ChangeKind{validation.RuleTightened}))
+		g.Expect(kind(validation.InRule("admin"), validation.InRule("admin", "member"))).To(Equal([]validation.ChangeKind{validation.RuleLoosened}))
+		g.Expect(kind(validation.InRule("admin"), validation.InRule("member"))).To(Equal([]validation.ChangeKind{validation.RuleChanged}))
+		g.Expect(kind(validation.InRule("admin", "member"), validation.InRule("member", "admin"))).To(BeEmpty())
+	})
+
+	t.Run("marshals to JSON", func(t *testing.T) {
+		g := NewWithT(t)
+		diff := validation.DiffRules(
+			validation.NewSchema(validation.Key("name", validation.MinLengthRule(3))),
+			validation.NewSchema(validation.Key("name", validation.MinLengthRule(5))),
+		)
+		data, err := json.Marshal(diff)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(data).To(MatchJSON(`{"changes":[
+			{"key":"name","rule":"min_length","kind":"tightened","old":{"min":3},"new":{"min":5},"breaking":true}
+		]}`))
+	})
+}
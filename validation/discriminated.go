@@ -0,0 +1,51 @@
+package validation
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Discriminated validates decoded JSON objects of several kinds, like OpenAPI oneOf with a
+// discriminator: the value of the key named discriminator selects the schema of the object
+// among schemas. The discriminator is required, and must name one of the schemas. It does
+// not need to be defined by the schemas, which accept it even if they reject extra keys.
+// Values that are not a map[string]any are rejected.
+//
+// Example:
+//
+//	var paymentMethod = validation.Discriminated[map[string]any]("type", map[string]*validation.Schema{
+//	    "card": validation.NewSchema(validation.Key("number", validation.PatternRule(`^\d{16}$`)).Required()),
+//	    "iban": validation.NewSchema(validation.Key("iban", validation.MinLengthRule(15)).Required()),
+//	})
+//
+//	err := validation.Validate(payload, paymentMethod)
+func Discriminated[T any](discriminator string, schemas map[string]*Schema) Validator[T] {
+	kinds := make([]string, 0, len(schemas))
+	selected := make(map[string]*Schema, len(schemas))
+	for kind, schema := range schemas {
+		kinds = append(kinds, kind)
+		if _, ok := schema.key(discriminator); !ok {
+			schema = schema.withKey(Key(discriminator))
+		}
+		selected[kind] = schema
+	}
+	slices.Sort(kinds)
+	invalidKind := NewValidationError(fmt.Sprintf("key %q: must be one of %s", discriminator, quoteKeys(kinds)))
+
+	return func(v T) error {
+		m, ok := any(v).(map[string]any)
+		if !ok {
+			return NewValidationError("must be an object")
+		}
+		value, exists := m[discriminator]
+		if !exists {
+			return NewCodedError(CodeRequired, fmt.Sprintf("key %q is required", discriminator), nil)
+		}
+		kind, _ := value.(string)
+		schema, ok := selected[kind]
+		if !ok {
+			return invalidKind
+		}
+		return schema.Validate(m)
+	}
+}
@@ -0,0 +1,45 @@
+package validation_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/quantumcycle/protego/validation"
+)
+
+func TestDiscriminated(t *testing.T) {
+	paymentMethod := validation.Discriminated[map[string]any]("type", map[string]*validation.Schema{
+		"card": validation.NewSchema(validation.Key("number", validation.PatternRule(`^\d{16}$`)).Required()),
+		"iban": validation.NewSchema(
+			validation.Key("type", validation.InRule("iban")),
+			validation.Key("iban", validation.MinLengthRule(15)).Required(),
+		),
+	})
+
+	t.Run("validates objects against the selected schema", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(paymentMethod(map[string]any{"type": "card", "number": "4242424242424242"})).To(Succeed())
+		g.Expect(paymentMethod(map[string]any{"type": "iban", "iban": "FR7630006000011234567890189"})).To(Succeed())
+		g.Expect(paymentMethod(map[string]any{"type": "card", "iban": "FR7630006000011234567890189"})).To(MatchError(ContainSubstring(`key "number" is required`)))
+		g.Expect(paymentMethod(map[string]any{"type": "iban", "iban": "FR76"})).To(MatchError(ContainSubstring(`key "iban"`)))
+	})
+
+	t.Run("requires a known discriminator", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(paymentMethod(map[string]any{"number": "4242424242424242"})).To(MatchError(`key "type" is required`))
+		err := paymentMethod(map[string]any{"type": "cash"})
+		g.Expect(err).To(MatchError(`key "type": must be one of "card", "iban"`))
+		g.Expect(validation.IsValidationError(err)).To(BeTrue())
+		g.Expect(paymentMethod(map[string]any{"type": 1.0})).To(MatchError(`key "type": must be one of "card", "iban"`))
+	})
+
+	t.Run("validates any values", func(t *testing.T) {
+		g := NewWithT(t)
+		validate := validation.Discriminated[any]("type", map[string]*validation.Schema{
+			"point": validation.NewSchema(validation.Key("x").Required()),
+		})
+		g.Expect(validation.Validate[any](map[string]any{"type": "point", "x": 1.0}, validate)).To(Succeed())
+		g.Expect(validation.Validate[any]([]any{"point"}, validate)).To(MatchError("must be an object"))
+	})
+}
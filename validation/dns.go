@@ -0,0 +1,96 @@
+package validation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+)
+
+// DNSResolver looks up DNS records. *net.Resolver implements it; implement it to stub
+// lookups in tests.
+type DNSResolver interface {
+	LookupIP(ctx context.Context, network, host string) ([]net.IP, error)
+	LookupCNAME(ctx context.Context, host string) (string, error)
+	LookupMX(ctx context.Context, name string) ([]*net.MX, error)
+	LookupTXT(ctx context.Context, name string) ([]string, error)
+	LookupNS(ctx context.Context, name string) ([]*net.NS, error)
+}
+
+// HasDNSRecord validates that a domain name has at least one DNS record of recordType: "A",
+// "AAAA", "CNAME", "MX", "NS" or "TXT". It is meant for custom-domain onboarding, to check
+// that a customer has pointed their domain before it is activated. A nil resolver means
+// net.DefaultResolver.
+//
+// A domain without such a record, or that does not exist, is a validation error. Other
+// lookup failures, such as timeouts, are returned as is: they are system errors (see
+// IsSystemError), to be retried with WithRetry or answered with a 5xx status. It panics if
+// recordType is not supported.
+//
+// Example:
+//
+//	pointed := validation.HasDNSRecord("CNAME", nil)
+//	err := validation.ValidateWithTimeout(ctx, 2*time.Second, input.CustomDomain, pointed)
+func HasDNSRecord(recordType string, resolver DNSResolver) ValidatorCtx[string] {
+	if resolver == nil {
+		resolver = net.DefaultResolver
+	}
+	lookup := dnsLookup(strings.ToUpper(recordType), resolver)
+	msg := fmt.Sprintf("must have a DNS %s record", strings.ToUpper(recordType))
+	return func(ctx context.Context, v string) error {
+		found, err := lookup(ctx, v)
+		var dnsErr *net.DNSError
+		if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+			return NewValidationError(msg)
+		}
+		if err != nil {
+			return err
+		}
+		if !found {
+			return NewValidationError(msg)
+		}
+		return nil
+	}
+}
+
+// dnsLookup returns a function reporting whether a name has records of recordType.
+func dnsLookup(recordType string, resolver DNSResolver) func(ctx context.Context, name string) (bool, error) {
+	switch recordType {
+	case "A", "AAAA":
+		network := "ip4"
+		if recordType == "AAAA" {
+			network = "ip6"
+		}
+		return func(ctx context.Context, name string) (bool, error) {
+			ips, err := resolver.LookupIP(ctx, network, name)
+			return len(ips) > 0, err
+		}
+	case "CNAME":
+		return func(ctx context.Context, name string) (bool, error) {
+			canonical, err := resolver.LookupCNAME(ctx, name)
+			// the canonical name of a name without CNAME record is the name itself
+			return canonical != "" && normalizeDomain(canonical) != normalizeDomain(name), err
+		}
+	case "MX":
+		return func(ctx context.Context, name string) (bool, error) {
+			records, err := resolver.LookupMX(ctx, name)
+			return len(records) > 0, err
+		}
+	case "NS":
+		return func(ctx context.Context, name string) (bool, error) {
+			records, err := resolver.LookupNS(ctx, name)
+			return len(records) > 0, err
+		}
+	case "TXT":
+		return func(ctx context.Context, name string) (bool, error) {
+			records, err := resolver.LookupTXT(ctx, name)
+			return len(records) > 0, err
+		}
+	}
+	panic(fmt.Sprintf("validation: unsupported DNS record type %q", recordType))
+}
+
+func normalizeDomain(domain string) string {
+	return strings.ToLower(strings.TrimSuffix(domain, "."))
+}
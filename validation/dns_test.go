@@ -0,0 +1,105 @@
+package validation_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/quantumcycle/protego/validation"
+)
+
+type stubResolver struct {
+	records map[string][]string
+	err     error
+}
+
+func (r stubResolver) lookup(recordType, name string) ([]string, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	records, ok := r.records[recordType+" "+name]
+	if !ok {
+		return nil, &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+	}
+	return records, nil
+}
+
+func (r stubResolver) LookupIP(_ context.Context, network, host string) ([]net.IP, error) {
+	recordType := map[string]string{"ip4": "A", "ip6": "AAAA"}[network]
+	records, err := r.lookup(recordType, host)
+	var ips []net.IP
+	for _, record := range records {
+		ips = append(ips, net.ParseIP(record))
+	}
+	return ips, err
+}
+
+func (r stubResolver) LookupCNAME(_ context.Context, host string) (string, error) {
+	records, err := r.lookup("CNAME", host)
+	if len(records) == 0 {
+		return host + ".", err
+	}
+	return records[0], err
+}
+
+func (r stubResolver) LookupMX(_ context.Context, name string) ([]*net.MX, error) {
+	records, err := r.lookup("MX", name)
+	var mx []*net.MX
+	for _, record := range records {
+		mx = append(mx, &net.MX{Host: record})
+	}
+	return mx, err
+}
+
+func (r stubResolver) LookupTXT(_ context.Context, name string) ([]string, error) {
+	return r.lookup("TXT", name)
+}
+
+func (r stubResolver) LookupNS(_ context.Context, name string) ([]*net.NS, error) {
+	records, err := r.lookup("NS", name)
+	var ns []*net.NS
+	for _, record := range records {
+		ns = append(ns, &net.NS{Host: record})
+	}
+	return ns, err
+}
+
+func TestHasDNSRecord(t *testing.T) {
+	resolver := stubResolver{records: map[string][]string{
+		"A example.com":          {"93.184.215.14"},
+		"CNAME shop.example.com": {"custom.ourapp.io."},
+		"CNAME www.example.com":  nil,
+		"TXT example.com":        {"ourapp-verification=abc"},
+	}}
+
+	t.Run("accepts domains with a record of the type", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(validation.HasDNSRecord("A", resolver)(context.Background(), "example.com")).To(Succeed())
+		g.Expect(validation.HasDNSRecord("cname", resolver)(context.Background(), "shop.example.com")).To(Succeed())
+		g.Expect(validation.HasDNSRecord("TXT", resolver)(context.Background(), "example.com")).To(Succeed())
+	})
+
+	t.Run("rejects domains without a record of the type", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(validation.HasDNSRecord("AAAA", resolver)(context.Background(), "example.com")).To(MatchError("must have a DNS AAAA record"))
+		g.Expect(validation.HasDNSRecord("MX", resolver)(context.Background(), "example.com")).To(MatchError("must have a DNS MX record"))
+		g.Expect(validation.HasDNSRecord("CNAME", resolver)(context.Background(), "www.example.com")).To(MatchError("must have a DNS CNAME record"))
+	})
+
+	t.Run("returns lookup failures as system errors", func(t *testing.T) {
+		g := NewWithT(t)
+		failing := stubResolver{err: &net.DNSError{Err: "i/o timeout", Name: "example.com", IsTimeout: true}}
+		err := validation.HasDNSRecord("A", failing)(context.Background(), "example.com")
+		var dnsErr *net.DNSError
+		g.Expect(errors.As(err, &dnsErr)).To(BeTrue())
+		g.Expect(validation.IsSystemError(err)).To(BeTrue())
+	})
+
+	t.Run("panics on unsupported record types", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(func() { validation.HasDNSRecord("SRV", resolver) }).To(PanicWith(`validation: unsupported DNS record type "SRV"`))
+	})
+}
@@ -0,0 +1,180 @@
+package validation
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// MaxDepth validates that a decoded JSON document (nested map[string]any and []any values)
+// is not nested deeper than the specified maximum. Scalars have a depth of 0, and every
+// map or slice adds one level. Traversal stops as soon as the limit is exceeded, so
+// hostile documents are rejected without being walked in full.
+//
+// Example:
+//
+//	validation.Validate(comment, validation.MaxDepth[map[string]any](10))
+func MaxDepth[T any](maximum int) Validator[T] {
+	return func(v T) error {
+		if exceedsDepth(any(v), maximum) {
+			return NewValidationError(fmt.Sprintf("must not be nested deeper than %d levels", maximum))
+		}
+		return nil
+	}
+}
+
+// MaxChildren validates that no map or slice within a decoded JSON document has more than
+// the specified number of direct children. This limits the fan-out of recursive structures
+// such as comment threads or category trees.
+//
+// Example:
+//
+//	validation.Validate(categories, validation.MaxChildren[[]any](50))
+func MaxChildren[T any](maximum int) Validator[T] {
+	return func(v T) error {
+		if exceedsChildren(any(v), maximum) {
+			return NewValidationError(fmt.Sprintf("must not have more than %d children per node", maximum))
+		}
+		return nil
+	}
+}
+
+// exceedsDepth reports whether v is nested deeper than remaining levels.
+func exceedsDepth(v any, remaining int) bool {
+	switch node := v.(type) {
+	case map[string]any:
+		if remaining <= 0 {
+			return true
+		}
+		for _, child := range node {
+			if exceedsDepth(child, remaining-1) {
+				return true
+			}
+		}
+	case []any:
+		if remaining <= 0 {
+			return true
+		}
+		for _, child := range node {
+			if exceedsDepth(child, remaining-1) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// exceedsChildren reports whether any map or slice within v has more than maximum children.
+func exceedsChildren(v any, maximum int) bool {
+	switch node := v.(type) {
+	case map[string]any:
+		if len(node) > maximum {
+			return true
+		}
+		for _, child := range node {
+			if exceedsChildren(child, maximum) {
+				return true
+			}
+		}
+	case []any:
+		if len(node) > maximum {
+			return true
+		}
+		for _, child := range node {
+			if exceedsChildren(child, maximum) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// MaxTotalSize validates that the JSON encoding of a decoded document does not exceed the
+// specified number of bytes. The size is computed by walking the document rather than
+// marshalling it, and the walk stops as soon as the limit is reached. String escape
+// sequences are not counted, so the result is a lower bound of the encoded size.
+//
+// Example:
+//
+//	validation.Validate(payload, validation.MaxTotalSize[map[string]any](64*1024))
+func MaxTotalSize[T any](maximum int) Validator[T] {
+	return func(v T) error {
+		if encodedSize(any(v), maximum) > maximum {
+			return NewValidationError(fmt.Sprintf("must not exceed %d bytes", maximum))
+		}
+		return nil
+	}
+}
+
+// MaxFieldCount validates that a decoded JSON document contains at most the specified
+// number of keys, counted across all nested maps.
+//
+// Example:
+//
+//	validation.Validate(payload, validation.MaxFieldCount[map[string]any](500))
+func MaxFieldCount[T any](maximum int) Validator[T] {
+	return func(v T) error {
+		if fieldCount(any(v), maximum) > maximum {
+			return NewValidationError(fmt.Sprintf("must not contain more than %d fields", maximum))
+		}
+		return nil
+	}
+}
+
+// encodedSize returns the JSON-encoded size of v, stopping early once limit is exceeded.
+func encodedSize(v any, limit int) int {
+	switch node := v.(type) {
+	case map[string]any:
+		size := 1 + max(len(node), 1) // braces and separating commas
+		for key, child := range node {
+			size += len(key) + 3 // quotes and colon
+			if size += encodedSize(child, limit-size); size > limit {
+				return size
+			}
+		}
+		return size
+	case []any:
+		size := 1 + max(len(node), 1) // brackets and separating commas
+		for _, child := range node {
+			if size += encodedSize(child, limit-size); size > limit {
+				return size
+			}
+		}
+		return size
+	case string:
+		return len(node) + 2
+	case nil:
+		return len("null")
+	case bool:
+		if node {
+			return len("true")
+		}
+		return len("false")
+	case float64:
+		return len(strconv.FormatFloat(node, 'g', -1, 64))
+	default:
+		return len(fmt.Sprint(node))
+	}
+}
+
+// fieldCount returns the number of keys in v, stopping early once limit is exceeded.
+func fieldCount(v any, limit int) int {
+	count := 0
+	switch node := v.(type) {
+	case map[string]any:
+		count = len(node)
+		for _, child := range node {
+			if count > limit {
+				return count
+			}
+			count += fieldCount(child, limit-count)
+		}
+	case []any:
+		for _, child := range node {
+			if count > limit {
+				return count
+			}
+			count += fieldCount(child, limit-count)
+		}
+	}
+	return count
+}
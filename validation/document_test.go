@@ -0,0 +1,109 @@
+package validation_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/quantumcycle/protego/validation"
+)
+
+func TestMaxDepth(t *testing.T) {
+	doc := map[string]any{
+		"name": "root",
+		"children": []any{
+			map[string]any{"name": "child"},
+		},
+	}
+
+	t.Run("passes when depth equals max", func(t *testing.T) {
+		g := NewWithT(t)
+		err := validation.Validate(doc, validation.MaxDepth[map[string]any](3))
+		g.Expect(err).To(BeNil())
+	})
+
+	t.Run("fails when depth exceeds max", func(t *testing.T) {
+		g := NewWithT(t)
+		err := validation.Validate(doc, validation.MaxDepth[map[string]any](2))
+		g.Expect(err).To(MatchError("must not be nested deeper than 2 levels"))
+	})
+
+	t.Run("scalars have no depth", func(t *testing.T) {
+		g := NewWithT(t)
+		err := validation.Validate[any]("value", validation.MaxDepth[any](0))
+		g.Expect(err).To(BeNil())
+	})
+
+	t.Run("works on slices", func(t *testing.T) {
+		g := NewWithT(t)
+		err := validation.Validate([]any{[]any{[]any{}}}, validation.MaxDepth[[]any](2))
+		g.Expect(err).To(MatchError("must not be nested deeper than 2 levels"))
+	})
+}
+
+func TestMaxChildren(t *testing.T) {
+
+	t.Run("passes when fan-out within max", func(t *testing.T) {
+		g := NewWithT(t)
+		doc := map[string]any{"a": 1, "b": []any{1, 2}}
+		err := validation.Validate(doc, validation.MaxChildren[map[string]any](2))
+		g.Expect(err).To(BeNil())
+	})
+
+	t.Run("fails when root exceeds max", func(t *testing.T) {
+		g := NewWithT(t)
+		doc := map[string]any{"a": 1, "b": 2, "c": 3}
+		err := validation.Validate(doc, validation.MaxChildren[map[string]any](2))
+		g.Expect(err).To(MatchError("must not have more than 2 children per node"))
+	})
+
+	t.Run("fails when nested node exceeds max", func(t *testing.T) {
+		g := NewWithT(t)
+		doc := map[string]any{"replies": []any{1, 2, 3}}
+		err := validation.Validate(doc, validation.MaxChildren[map[string]any](2))
+		g.Expect(err).To(MatchError("must not have more than 2 children per node"))
+	})
+}
+
+func TestMaxTotalSize(t *testing.T) {
+	doc := map[string]any{"a": "bc", "n": []any{1.5, true, nil}}
+
+	t.Run("passes when size equals max", func(t *testing.T) {
+		g := NewWithT(t)
+		// {"a":"bc","n":[1.5,true,null]}
+		err := validation.Validate(doc, validation.MaxTotalSize[map[string]any](30))
+		g.Expect(err).To(BeNil())
+	})
+
+	t.Run("fails when size exceeds max", func(t *testing.T) {
+		g := NewWithT(t)
+		err := validation.Validate(doc, validation.MaxTotalSize[map[string]any](29))
+		g.Expect(err).To(MatchError("must not exceed 29 bytes"))
+	})
+
+	t.Run("counts empty containers", func(t *testing.T) {
+		g := NewWithT(t)
+		err := validation.Validate(map[string]any{"x": []any{}, "y": map[string]any{}}, validation.MaxTotalSize[map[string]any](15))
+		g.Expect(err).To(BeNil())
+	})
+}
+
+func TestMaxFieldCount(t *testing.T) {
+	doc := map[string]any{
+		"a": 1,
+		"b": map[string]any{"c": 2},
+		"d": []any{map[string]any{"e": 3, "f": 4}},
+	}
+
+	t.Run("passes when count equals max", func(t *testing.T) {
+		g := NewWithT(t)
+		err := validation.Validate(doc, validation.MaxFieldCount[map[string]any](6))
+		g.Expect(err).To(BeNil())
+	})
+
+	t.Run("fails when count exceeds max", func(t *testing.T) {
+		g := NewWithT(t)
+		err := validation.Validate(doc, validation.MaxFieldCount[map[string]any](5))
+		g.Expect(err).To(MatchError("must not contain more than 5 fields"))
+	})
+}
@@ -2,6 +2,7 @@ package validation_test
 
 import (
 	"errors"
+	"fmt"
 	"testing"
 
 	. "github.com/onsi/gomega"
@@ -156,3 +157,111 @@ func TestErrorMessagesPreserved(t *testing.T) {
 		g.Expect(err.Error()).To(Equal("must be between 0 and 120"))
 	})
 }
+
+func TestFieldError(t *testing.T) {
+	t.Run("NewFieldError prefixes the message with the field name", func(t *testing.T) {
+		g := NewWithT(t)
+		err := validation.NewFieldError("email", validation.NewValidationError("required"))
+		g.Expect(err).To(MatchError("email: required"))
+		g.Expect(validation.IsValidationError(err)).To(BeTrue())
+	})
+
+	t.Run("Field returns the field name", func(t *testing.T) {
+		g := NewWithT(t)
+		err := validation.NewFieldError("email", validation.NewValidationError("required"))
+		var fieldErr *validation.FieldError
+		g.Expect(errors.As(err, &fieldErr)).To(BeTrue())
+		g.Expect(fieldErr.Field()).To(Equal("email"))
+	})
+
+	t.Run("NewFieldError returns nil for nil error", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(validation.NewFieldError("email", nil)).To(BeNil())
+	})
+
+	t.Run("non-validation errors are not validation errors once attributed", func(t *testing.T) {
+		g := NewWithT(t)
+		err := validation.NewFieldError("email", errors.New("database unavailable"))
+		g.Expect(validation.IsValidationError(err)).To(BeFalse())
+	})
+
+	t.Run("ValidateStruct returns field errors", func(t *testing.T) {
+		g := NewWithT(t)
+		type Input struct {
+			Email string `json:"email"`
+		}
+		in := Input{}
+		err := validation.ValidateStruct(validation.Field(&in, &in.Email, validation.Required[string]()))
+		var fieldErr *validation.FieldError
+		g.Expect(errors.As(err, &fieldErr)).To(BeTrue())
+		g.Expect(fieldErr.Field()).To(Equal("email"))
+	})
+}
+
+func TestIsSystemError(t *testing.T) {
+	outage := errors.New("connection refused")
+
+	t.Run("returns false for nil and validation errors", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(validation.IsSystemError(nil)).To(BeFalse())
+		g.Expect(validation.IsSystemError(validation.NewValidationError("required"))).To(BeFalse())
+		g.Expect(validation.IsSystemError(validation.WrapError(outage))).To(BeFalse())
+		g.Expect(validation.IsSystemError(validation.NewFieldError("name", validation.NewValidationError("required")))).To(BeFalse())
+	})
+
+	t.Run("returns true for other errors, wrapped or not", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(validation.IsSystemError(outage)).To(BeTrue())
+		g.Expect(validation.IsSystemError(fmt.Errorf("lookup: %w", outage))).To(BeTrue())
+		g.Expect(validation.IsSystemError(validation.NewFieldError("email", outage))).To(BeTrue())
+	})
+
+	t.Run("returns true when joined with validation errors", func(t *testing.T) {
+		g := NewWithT(t)
+		err := errors.Join(validation.NewValidationError("required"), outage)
+		g.Expect(validation.IsSystemError(err)).To(BeTrue())
+		g.Expect(validation.IsValidationError(err)).To(BeTrue())
+	})
+}
+
+func TestCompositesPropagateSystemErrors(t *testing.T) {
+	outage := errors.New("connection refused")
+	unavailable := validation.Custom(func(string) error { return outage })
+
+	t.Run("Or returns the system error when no validator passes", func(t *testing.T) {
+		g := NewWithT(t)
+		err := validation.Validate("x", validation.Or(validation.MinLength(3), unavailable))
+		g.Expect(err).To(Equal(outage))
+		g.Expect(validation.Validate("xyz", validation.Or(unavailable, validation.MinLength(3)))).To(Succeed())
+	})
+
+	t.Run("And returns the system error", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(validation.Validate("xyz", validation.And(validation.MinLength(3), unavailable))).To(Equal(outage))
+	})
+
+	t.Run("Not does not treat a system error as a failure", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(validation.Validate("x", validation.Not(unavailable))).To(Equal(outage))
+	})
+
+	t.Run("WithMessage does not replace a system error", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(validation.Validate("x", validation.WithMessage(unavailable, "invalid"))).To(Equal(outage))
+	})
+
+	t.Run("Each stops at a system error without wrapping it", func(t *testing.T) {
+		g := NewWithT(t)
+		err := validation.Validate([]string{"", "x"}, validation.Each(validation.Or(validation.Required[string](), unavailable)))
+		g.Expect(err).To(MatchError("index 0: connection refused"))
+		g.Expect(validation.IsSystemError(err)).To(BeTrue())
+		g.Expect(validation.IsValidationError(err)).To(BeFalse())
+	})
+
+	t.Run("map validators do not wrap system errors", func(t *testing.T) {
+		g := NewWithT(t)
+		err := validation.ValidateStringMap(map[string]string{"email": "a@b.c"}, true, validation.MapKey("email", true, unavailable))
+		g.Expect(err).To(MatchError(`key "email": connection refused`))
+		g.Expect(validation.IsValidationError(err)).To(BeFalse())
+	})
+}
@@ -100,10 +100,10 @@ func Example_customValidator() {
 	IsPositiveEven := func() validation.Validator[int] {
 		return func(v int) error {
 			if v <= 0 {
-				return fmt.Errorf("must be positive")
+				return validation.NewValidationError("must be positive")
 			}
 			if v%2 != 0 {
-				return fmt.Errorf("must be even")
+				return validation.NewValidationError("must be even")
 			}
 			return nil
 		}
@@ -0,0 +1,140 @@
+package validation
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Outcome is the outcome of a rule in an Explanation.
+type Outcome string
+
+// Outcomes of rules.
+const (
+	// Passed is the outcome of a rule the value satisfies.
+	Passed Outcome = "passed"
+	// Failed is the outcome of a rule the value does not satisfy.
+	Failed Outcome = "failed"
+	// Skipped is the outcome of the rules of a key absent from the value.
+	Skipped Outcome = "skipped"
+)
+
+// RuleResult is the outcome of a rule of a schema key for a value, as reported by Explain.
+type RuleResult struct {
+	Key string `json:"key"`
+	// Rule is the name of the rule, "required" for the presence of a required key and
+	// "extra_keys" for a key the schema does not define, with a "suggestion" parameter
+	// when the key looks like a typo of one it defines.
+	Rule    string         `json:"rule"`
+	Params  map[string]any `json:"params,omitempty"`
+	Outcome Outcome        `json:"outcome"`
+	// Reason is why the rule failed or was skipped, empty when it passed.
+	Reason string `json:"reason,omitempty"`
+}
+
+// Explanation lists the outcome of every rule of a schema for a value.
+type Explanation struct {
+	Valid   bool         `json:"valid"`
+	Results []RuleResult `json:"results"`
+}
+
+// Failures returns the results of the failed rules.
+func (e Explanation) Failures() []RuleResult {
+	var failures []RuleResult
+	for _, r := range e.Results {
+		if r.Outcome == Failed {
+			failures = append(failures, r)
+		}
+	}
+	return failures
+}
+
+// String formats the explanation with one line per rule, for logs and support tooling:
+//
+//	name min_length {"min":3}: failed (must be at least 3 characters)
+func (e Explanation) String() string {
+	var b strings.Builder
+	for _, r := range e.Results {
+		b.WriteString(r.Key)
+		b.WriteByte(' ')
+		b.WriteString(r.Rule)
+		if params, err := json.Marshal(r.Params); err == nil && len(r.Params) > 0 {
+			b.WriteByte(' ')
+			b.Write(params)
+		}
+		b.WriteString(": ")
+		b.WriteString(string(r.Outcome))
+		if r.Reason != "" {
+			b.WriteString(" (")
+			b.WriteString(r.Reason)
+			b.WriteByte(')')
+		}
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// Explain evaluates every rule of schema against value and reports whether each passed or
+// failed and why, for support engineers debugging why a payload is rejected. Unlike
+// Validate, which stops at the first error of each key, all rules of all present keys are
+// evaluated, in the order of the schema, followed by the keys the schema does not define,
+// in sorted order. Rules are evaluated at the strictness of the schema: Pedantic schemas
+// report a "no_whitespace_edges" rule for every key. Messages of system errors are
+// reported as is: explanations are meant for debugging, not for clients.
+//
+// Example:
+//
+//	explanation := validation.Explain(orderSchema, payload)
+//	for _, failure := range explanation.Failures() {
+//	    fmt.Printf("%s: %s failed: %s\n", failure.Key, failure.Rule, failure.Reason)
+//	}
+func Explain(schema *Schema, value map[string]any) Explanation {
+	explanation := Explanation{Valid: true, Results: []RuleResult{}}
+	add := func(r RuleResult) {
+		if r.Outcome == Failed {
+			explanation.Valid = false
+		}
+		explanation.Results = append(explanation.Results, r)
+	}
+
+	for _, k := range schema.keys {
+		v, exists := value[k.name]
+		if k.required {
+			r := RuleResult{Key: k.name, Rule: "required", Outcome: Passed}
+			if !exists {
+				r.Outcome, r.Reason = Failed, "key is missing"
+			}
+			add(r)
+		}
+		v = schema.strictness.normalize(v)
+		for _, rule := range schema.strictness.rules(k) {
+			r := RuleResult{Key: k.name, Rule: rule.name, Params: rule.params, Outcome: Passed}
+			if !exists {
+				r.Outcome, r.Reason = Skipped, "key is absent"
+			} else if err := rule.validator(v); err != nil {
+				r.Outcome, r.Reason = Failed, err.Error()
+			}
+			add(r)
+		}
+	}
+
+	if !schema.acceptsExtra() {
+		var extra []string
+		for key := range value {
+			if _, ok := schema.key(key); !ok {
+				extra = append(extra, key)
+			}
+		}
+		slices.Sort(extra)
+		for _, key := range extra {
+			r := RuleResult{Key: key, Rule: "extra_keys", Outcome: Failed, Reason: "key not expected"}
+			if suggestion, ok := closestKey(key, schema.keyNames(), value); ok {
+				r.Params = map[string]any{"suggestion": suggestion}
+				r.Reason = fmt.Sprintf("key not expected, did you mean %q?", suggestion)
+			}
+			add(r)
+		}
+	}
+	return explanation
+}
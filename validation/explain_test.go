@@ -0,0 +1,81 @@
+package validation_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/quantumcycle/protego/validation"
+)
+
+func TestExplain(t *testing.T) {
+	schema := validation.NewSchema(
+		validation.Key("name", validation.MinLengthRule(3), validation.MaxLengthRule(5)).Required(),
+		validation.Key("role", validation.InRule("admin", "member")),
+	)
+
+	t.Run("reports the outcome of every rule", func(t *testing.T) {
+		g := NewWithT(t)
+		explanation := validation.Explain(schema, map[string]any{"name": "al", "role": "admin"})
+		g.Expect(explanation.Valid).To(BeFalse())
+		g.Expect(explanation.Results).To(Equal([]validation.RuleResult{
+			{Key: "name", Rule: "required", Outcome: validation.Passed},
+			{Key: "name", Rule: "min_length", Params: map[string]any{"min": 3}, Outcome: validation.Failed, Reason: "must be at least 3 characters"},
+			{Key: "name", Rule: "max_length", Params: map[string]any{"max": 5}, Outcome: validation.Passed},
+			{Key: "role", Rule: "in", Params: map[string]any{"values": []string{"admin", "member"}}, Outcome: validation.Passed},
+		}))
+	})
+
+	t.Run("evaluates rules after a failure", func(t *testing.T) {
+		g := NewWithT(t)
+		schema := validation.NewSchema(validation.Key("code", validation.MinLengthRule(8), validation.PatternRule("^[0-9]+$")))
+		failures := validation.Explain(schema, map[string]any{"code": "ab"}).Failures()
+		g.Expect(failures).To(HaveLen(2))
+		g.Expect(failures[1].Rule).To(Equal("pattern"))
+	})
+
+	t.Run("reports missing, absent and extra keys", func(t *testing.T) {
+		g := NewWithT(t)
+		explanation := validation.Explain(schema, map[string]any{"team": "core", "age": 3.0})
+		g.Expect(explanation.Results).To(Equal([]validation.RuleResult{
+			{Key: "name", Rule: "required", Outcome: validation.Failed, Reason: "key is missing"},
+			{Key: "name", Rule: "min_length", Params: map[string]any{"min": 3}, Outcome: validation.Skipped, Reason: "key is absent"},
+			{Key: "name", Rule: "max_length", Params: map[string]any{"max": 5}, Outcome: validation.Skipped, Reason: "key is absent"},
+			{Key: "role", Rule: "in", Params: map[string]any{"values": []string{"admin", "member"}}, Outcome: validation.Skipped, Reason: "key is absent"},
+			{Key: "age", Rule: "extra_keys", Outcome: validation.Failed, Reason: "key not expected"},
+			{Key: "team", Rule: "extra_keys", Outcome: validation.Failed, Reason: "key not expected"},
+		}))
+		g.Expect(validation.Explain(schema.AllowExtra(), map[string]any{"name": "alice", "team": "core"}).Valid).To(BeTrue())
+	})
+
+	t.Run("agrees with Validate", func(t *testing.T) {
+		g := NewWithT(t)
+		for _, payload := range []map[string]any{
+			{"name": "alice"},
+			{"name": "alexandra"},
+			{"name": "alice", "role": "owner"},
+			{"role": "admin"},
+			{"name": "alice", "extra": true},
+		} {
+			g.Expect(validation.Explain(schema, payload).Valid).To(Equal(schema.Validate(payload) == nil), "%v", payload)
+		}
+	})
+
+	t.Run("formats one line per rule", func(t *testing.T) {
+		g := NewWithT(t)
+		schema := validation.NewSchema(validation.Key("name", validation.MinLengthRule(3)).Required())
+		g.Expect(validation.Explain(schema, map[string]any{"name": "al"}).String()).To(Equal(
+			"name required: passed\n" +
+				`name min_length {"min":3}: failed (must be at least 3 characters)` + "\n",
+		))
+	})
+
+	t.Run("marshals to JSON", func(t *testing.T) {
+		g := NewWithT(t)
+		schema := validation.NewSchema(validation.Key("name", validation.MinLengthRule(3)))
+		data, err := json.Marshal(validation.Explain(schema, map[string]any{"name": "alice"}))
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(data).To(MatchJSON(`{"valid":true,"results":[{"key":"name","rule":"min_length","params":{"min":3},"outcome":"passed"}]}`))
+	})
+}
@@ -0,0 +1,65 @@
+package validation
+
+// ToFormErrors groups the messages of err by field, for server-rendered forms to display
+// errors next to their inputs. Joined errors are flattened and nested field names are
+// joined with dots ("address.city"), indexes being appended as is ("items[2].sku"). Errors
+// not attributed to a field are listed under the empty key. It returns nil if err is nil.
+//
+// Example:
+//
+//	if err := input.Validate(); err != nil {
+//	    tmpl.Execute(w, map[string]any{"Input": input, "Errors": validation.ToFormErrors(err)})
+//	    return
+//	}
+func ToFormErrors(err error) map[string][]string {
+	if err == nil {
+		return nil
+	}
+	out := make(map[string][]string)
+	collectFormErrors(out, err, "")
+	return out
+}
+
+func collectFormErrors(out map[string][]string, err error, field string) {
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		for _, e := range joined.Unwrap() {
+			collectFormErrors(out, e, field)
+		}
+		return
+	}
+	if fieldErr, ok := err.(*FieldError); ok {
+		collectFormErrors(out, fieldErr.Unwrap(), joinPath(field, fieldErr.Field()))
+		return
+	}
+	out[field] = append(out[field], err.Error())
+}
+
+// FormErrorFuncs returns template functions reading the result of ToFormErrors, to register
+// with the Funcs method of html/template or text/template:
+//
+//   - fieldErrors errors "name" returns the messages of the field
+//   - fieldError errors "name" returns the first message of the field, or ""
+//   - hasFieldError errors "name" reports whether the field has errors
+//
+// Example:
+//
+//	tmpl := template.Must(template.New("form").Funcs(validation.FormErrorFuncs()).Parse(`
+//	    <input name="email" value="{{.Input.Email}}">
+//	    {{with fieldError .Errors "email"}}<span class="error">{{.}}</span>{{end}}
+//	`))
+func FormErrorFuncs() map[string]any {
+	return map[string]any{
+		"fieldErrors": func(errs map[string][]string, field string) []string {
+			return errs[field]
+		},
+		"fieldError": func(errs map[string][]string, field string) string {
+			if msgs := errs[field]; len(msgs) > 0 {
+				return msgs[0]
+			}
+			return ""
+		},
+		"hasFieldError": func(errs map[string][]string, field string) bool {
+			return len(errs[field]) > 0
+		},
+	}
+}
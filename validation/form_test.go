@@ -0,0 +1,60 @@
+package validation_test
+
+import (
+	"errors"
+	"html/template"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/quantumcycle/protego/validation"
+)
+
+func TestToFormErrors(t *testing.T) {
+	t.Run("returns nil without error", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(validation.ToFormErrors(nil)).To(BeNil())
+	})
+
+	t.Run("groups messages by field", func(t *testing.T) {
+		g := NewWithT(t)
+		err := errors.Join(
+			validation.NewFieldError("email", validation.NewValidationError("required")),
+			validation.NewFieldError("email", validation.NewValidationError("must be a valid email")),
+			validation.NewFieldError("address", errors.Join(
+				validation.NewFieldError("city", validation.NewValidationError("required")),
+			)),
+			validation.NewValidationError("passwords do not match"),
+		)
+		g.Expect(validation.ToFormErrors(err)).To(Equal(map[string][]string{
+			"email":        {"required", "must be a valid email"},
+			"address.city": {"required"},
+			"":             {"passwords do not match"},
+		}))
+	})
+
+	t.Run("appends indexes without dots", func(t *testing.T) {
+		g := NewWithT(t)
+		err := validation.NewFieldError("items", validation.NewFieldError("[2]",
+			validation.NewFieldError("sku", validation.NewValidationError("required"))))
+		g.Expect(validation.ToFormErrors(err)).To(Equal(map[string][]string{"items[2].sku": {"required"}}))
+	})
+}
+
+func TestFormErrorFuncs(t *testing.T) {
+	g := NewWithT(t)
+	tmpl := template.Must(template.New("form").Funcs(validation.FormErrorFuncs()).Parse(
+		`{{if hasFieldError . "email"}}[{{fieldError . "email"}}]{{end}}` +
+			`{{range fieldErrors . "name"}}<li>{{.}}</li>{{end}}` +
+			`{{fieldError . "age"}}`,
+	))
+	errs := validation.ToFormErrors(errors.Join(
+		validation.NewFieldError("email", validation.NewValidationError("required")),
+		validation.NewFieldError("name", validation.NewValidationError("too short")),
+		validation.NewFieldError("name", validation.NewValidationError("must not contain <b>")),
+	))
+	var out strings.Builder
+	g.Expect(tmpl.Execute(&out, errs)).To(Succeed())
+	g.Expect(out.String()).To(Equal("[required]<li>too short</li><li>must not contain &lt;b&gt;</li>"))
+}
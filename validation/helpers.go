@@ -3,10 +3,13 @@ package validation
 import (
 	"errors"
 	"fmt"
+	"sort"
+	"strings"
 )
 
 // WithMessage wraps a validator and replaces its error message with a custom message.
 // This is useful when you want to provide more specific or user-friendly error messages.
+// System errors (see IsSystemError) are returned unchanged.
 //
 // Example:
 //
@@ -18,10 +21,11 @@ import (
 //	)
 func WithMessage[T any](validator Validator[T], message string) Validator[T] {
 	return func(v T) error {
-		if err := validator(v); err != nil {
-			return NewValidationError(message)
+		err := validator(v)
+		if err == nil || IsSystemError(err) {
+			return err
 		}
-		return nil
+		return NewValidationError(message)
 	}
 }
 
@@ -48,7 +52,9 @@ func And[T any](validators ...Validator[T]) Validator[T] {
 }
 
 // Or combines multiple validators - at least one must pass for validation to succeed.
-// If all validators fail, a combined error is returned.
+// If all validators fail, a combined error is returned. If any of them failed with a system
+// error (see IsSystemError), that error is returned instead, as the outcome is unknown.
+// Use OrPrimary or OrClosest to report a single, more readable error.
 //
 // Example:
 //
@@ -62,12 +68,19 @@ func And[T any](validators ...Validator[T]) Validator[T] {
 func Or[T any](validators ...Validator[T]) Validator[T] {
 	return func(v T) error {
 		var errs []error
+		var systemErr error
 		for _, validator := range validators {
-			if err := validator(v); err == nil {
+			err := validator(v)
+			if err == nil {
 				return nil // One passed, we're good
-			} else {
-				errs = append(errs, err)
 			}
+			if systemErr == nil && IsSystemError(err) {
+				systemErr = err
+			}
+			errs = append(errs, err)
+		}
+		if systemErr != nil {
+			return systemErr
 		}
 		if len(errs) == 1 {
 			return errs[0]
@@ -76,7 +89,119 @@ func Or[T any](validators ...Validator[T]) Validator[T] {
 	}
 }
 
+// OrPrimary is like Or, but when every validator fails it returns only the error of primary,
+// the alternative end users are expected to provide, instead of combining all the errors.
+//
+// Example:
+//
+//	// "must be a valid email address" rather than an email and a username error
+//	validation.Validate(login, validation.OrPrimary(playground.IsEmail, isUsername))
+func OrPrimary[T any](primary Validator[T], alternatives ...Validator[T]) Validator[T] {
+	return func(v T) error {
+		primaryErr := primary(v)
+		if primaryErr == nil {
+			return nil
+		}
+		if err := Or(alternatives...)(v); err == nil || IsSystemError(err) {
+			return err
+		}
+		return primaryErr
+	}
+}
+
+// OrClosest is like Or for branches made of several validators, applied in order. When every
+// branch fails, it returns only the error of the branch that passed the most validators
+// before failing: the alternative the value came closest to satisfying. Ties go to the
+// first branch.
+//
+// Example:
+//
+//	validation.Validate(contact, validation.OrClosest(
+//	    []validation.Validator[string]{validation.Contains("@"), playground.IsEmail},
+//	    []validation.Validator[string]{validation.StartsWith("+"), validation.MatchesPattern(`^\+\d{10,15}$`)},
+//	))
+//	// "john@example" fails with the email error only, "+33 6" with the phone pattern error only
+func OrClosest[T any](branches ...[]Validator[T]) Validator[T] {
+	return func(v T) error {
+		var closest error
+		furthest := -1
+		var systemErr error
+		for _, branch := range branches {
+			passed, err := validateSteps(v, branch)
+			if err == nil {
+				return nil
+			}
+			if systemErr == nil && IsSystemError(err) {
+				systemErr = err
+			}
+			if passed > furthest {
+				closest, furthest = err, passed
+			}
+		}
+		if systemErr != nil {
+			return systemErr
+		}
+		return closest
+	}
+}
+
+// OneOf is like Or for labeled alternatives. When every alternative fails, the error lists
+// their labels, in alphabetical order, instead of the individual errors. It panics if
+// alternatives is empty.
+// Like Or, it tries every alternative despite system errors (see IsSystemError), and returns
+// the first one unchanged only when no alternative passes.
+//
+// Example:
+//
+//	validation.Validate(contact, validation.OneOf(map[string]validation.Validator[string]{
+//	    "a valid email":         playground.IsEmail,
+//	    "an E.164 phone number": playground.IsE164,
+//	}))
+//	// "must be a valid email or an E.164 phone number"
+func OneOf[T any](alternatives map[string]Validator[T]) Validator[T] {
+	labels := make([]string, 0, len(alternatives))
+	for label := range alternatives {
+		labels = append(labels, label)
+	}
+	if len(labels) == 0 {
+		panic("validation: OneOf requires at least one alternative")
+	}
+	sort.Strings(labels)
+	message := "must be " + labels[0]
+	if n := len(labels); n > 1 {
+		message = "must be " + strings.Join(labels[:n-1], ", ") + " or " + labels[n-1]
+	}
+
+	return func(v T) error {
+		var systemErr error
+		for _, label := range labels {
+			err := alternatives[label](v)
+			if err == nil {
+				return nil
+			}
+			if systemErr == nil && IsSystemError(err) {
+				systemErr = err
+			}
+		}
+		if systemErr != nil {
+			return systemErr
+		}
+		return NewValidationError(message)
+	}
+}
+
+// validateSteps applies validators in order and returns how many passed before the first error.
+func validateSteps[T any](v T, validators []Validator[T]) (int, error) {
+	for i, validator := range validators {
+		if err := validator(v); err != nil {
+			return i, err
+		}
+	}
+	return len(validators), nil
+}
+
 // Not inverts a validator - it passes if the validator fails, and vice versa.
+// System errors (see IsSystemError) are returned unchanged rather than counted as a failure.
 //
 // Example:
 //
@@ -85,7 +210,11 @@ func Or[T any](validators ...Validator[T]) Validator[T] {
 //	)
 func Not[T any](validator Validator[T]) Validator[T] {
 	return func(v T) error {
-		if err := validator(v); err != nil {
+		err := validator(v)
+		if IsSystemError(err) {
+			return err
+		}
+		if err != nil {
 			return nil // Validator failed, so Not passes
 		}
 		return NewValidationError("validation should have failed but passed")
@@ -129,13 +258,15 @@ func Unless[T any](condition bool, validator Validator[T]) Validator[T] {
 
 // Custom creates a custom validator from a function.
 // This is useful for inline validators or wrapping complex validation logic.
+// Invalid values must be reported with NewValidationError or WrapError: any other error is
+// a system error (see IsSystemError).
 //
 // Example:
 //
 //	validation.Validate(username,
 //	    validation.Custom(func(v string) error {
 //	        if containsProfanity(v) {
-//	            return fmt.Errorf("contains inappropriate content")
+//	            return validation.NewValidationError("contains inappropriate content")
 //	        }
 //	        return nil
 //	    }),
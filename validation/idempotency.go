@@ -0,0 +1,114 @@
+package validation
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Idempotency key formats accepted by IsIdempotencyKey.
+const (
+	IdempotencyKeyUUID = "uuid"
+	IdempotencyKeyULID = "ulid"
+)
+
+// IdempotencyKeyOptions configures IsIdempotencyKey.
+type IdempotencyKeyOptions struct {
+	// Format restricts keys to IdempotencyKeyUUID or IdempotencyKeyULID. Empty accepts both.
+	Format string
+	// MaxAge rejects time-encoded keys (ULIDs, UUID versions 1 and 7) created longer ago.
+	// Zero disables the check. Keys without timestamp are never considered stale.
+	MaxAge time.Duration
+}
+
+// IsIdempotencyKey validates an idempotency key, such as the Idempotency-Key header of
+// payment APIs: a UUID or a ULID and, for time-encoded keys, not older than MaxAge, so that
+// retries of an expired request are not silently accepted as new ones.
+//
+// Example:
+//
+//	validation.Validate(r.Header.Get("Idempotency-Key"), validation.IsIdempotencyKey(validation.IdempotencyKeyOptions{
+//	    MaxAge: 24 * time.Hour,
+//	}))
+func IsIdempotencyKey(opts IdempotencyKeyOptions) Validator[string] {
+	return func(v string) error {
+		var created time.Time
+		var ok bool
+		switch {
+		case opts.Format != IdempotencyKeyULID && isUUID(v):
+			created, ok = uuidTime(v), true
+		case opts.Format != IdempotencyKeyUUID && isULID(v):
+			created, ok = ulidTime(v), true
+		}
+		if !ok {
+			return NewValidationError(fmt.Sprintf("must be a valid idempotency key (%s)", idempotencyFormats(opts.Format)))
+		}
+		if opts.MaxAge > 0 && !created.IsZero() && time.Since(created) > opts.MaxAge {
+			return NewValidationError(fmt.Sprintf("must not be older than %s", formatDuration(opts.MaxAge)))
+		}
+		return nil
+	}
+}
+
+func idempotencyFormats(format string) string {
+	switch format {
+	case IdempotencyKeyUUID:
+		return "UUID"
+	case IdempotencyKeyULID:
+		return "ULID"
+	default:
+		return "UUID or ULID"
+	}
+}
+
+// uuidTime returns the creation time of a version 1 or 7 UUID in canonical form, or the
+// zero time for other versions.
+func uuidTime(v string) time.Time {
+	b, _ := hex.DecodeString(strings.ReplaceAll(v, "-", ""))
+	switch b[6] >> 4 {
+	case 1:
+		// 60-bit count of 100ns intervals since 1582-10-15
+		ticks := int64(b[6]&0x0f)<<56 | int64(b[7])<<48 | int64(b[4])<<40 | int64(b[5])<<32 |
+			int64(b[0])<<24 | int64(b[1])<<16 | int64(b[2])<<8 | int64(b[3])
+		const gregorianToUnix = 122192928000000000
+		return time.Unix(0, (ticks-gregorianToUnix)*100)
+	case 7:
+		ms := int64(b[0])<<40 | int64(b[1])<<32 | int64(b[2])<<24 | int64(b[3])<<16 | int64(b[4])<<8 | int64(b[5])
+		return time.UnixMilli(ms)
+	default:
+		return time.Time{}
+	}
+}
+
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// isULID reports whether v is a ULID: 26 characters of Crockford's base32, case-insensitive,
+// the first one at most 7 as the encoded value is 128 bits.
+func isULID(v string) bool {
+	if len(v) != 26 || v[0] > '7' {
+		return false
+	}
+	for i := 0; i < len(v); i++ {
+		if !strings.ContainsRune(crockford, rune(upper(v[i]))) {
+			return false
+		}
+	}
+	return true
+}
+
+// ulidTime returns the creation time encoded in the first 10 characters of a ULID.
+func ulidTime(v string) time.Time {
+	var ms int64
+	for i := 0; i < 10; i++ {
+		ms = ms<<5 | int64(strings.IndexByte(crockford, upper(v[i])))
+	}
+	return time.UnixMilli(ms)
+}
+
+func upper(c byte) byte {
+	if c >= 'a' && c <= 'z' {
+		return c - 'a' + 'A'
+	}
+	return c
+}
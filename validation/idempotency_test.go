@@ -0,0 +1,66 @@
+package validation_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/quantumcycle/protego/validation"
+)
+
+// uuidV7 returns a version 7 UUID created at t.
+func uuidV7(t time.Time) string {
+	ms := t.UnixMilli()
+	return fmt.Sprintf("%08x-%04x-7abc-8def-0123456789ab", ms>>16, ms&0xffff)
+}
+
+// ulid returns a ULID created at t.
+func ulid(t time.Time) string {
+	const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+	ms := t.UnixMilli()
+	var prefix [10]byte
+	for i := 9; i >= 0; i-- {
+		prefix[i] = crockford[ms&31]
+		ms >>= 5
+	}
+	return string(prefix[:]) + "3NDEBB9S8G1V7ZMS"
+}
+
+func TestIsIdempotencyKey(t *testing.T) {
+	now := time.Now()
+
+	t.Run("accepts UUIDs and ULIDs", func(t *testing.T) {
+		g := NewWithT(t)
+		v := validation.IsIdempotencyKey(validation.IdempotencyKeyOptions{})
+		for _, key := range []string{"f47ac10b-58cc-4372-a567-0e02b2c3d479", "01ARZ3NDEKTSV4RRFFQ69G5FAV", strings.ToLower("01ARZ3NDEKTSV4RRFFQ69G5FAV")} {
+			g.Expect(validation.Validate(key, v)).To(Succeed(), key)
+		}
+		for _, key := range []string{"", "order-42", "01ARZ3NDEKTSV4RRFFQ69G5FA", "81ARZ3NDEKTSV4RRFFQ69G5FAV", "01ARZ3NDEKTSV4RRFFQ69G5FAU"} {
+			g.Expect(validation.Validate(key, v)).To(MatchError("must be a valid idempotency key (UUID or ULID)"), key)
+		}
+	})
+
+	t.Run("restricts the format", func(t *testing.T) {
+		g := NewWithT(t)
+		uuidOnly := validation.IsIdempotencyKey(validation.IdempotencyKeyOptions{Format: validation.IdempotencyKeyUUID})
+		g.Expect(validation.Validate("01ARZ3NDEKTSV4RRFFQ69G5FAV", uuidOnly)).To(MatchError("must be a valid idempotency key (UUID)"))
+		ulidOnly := validation.IsIdempotencyKey(validation.IdempotencyKeyOptions{Format: validation.IdempotencyKeyULID})
+		g.Expect(validation.Validate("f47ac10b-58cc-4372-a567-0e02b2c3d479", ulidOnly)).To(MatchError("must be a valid idempotency key (ULID)"))
+	})
+
+	t.Run("rejects stale time-encoded keys", func(t *testing.T) {
+		g := NewWithT(t)
+		v := validation.IsIdempotencyKey(validation.IdempotencyKeyOptions{MaxAge: 24 * time.Hour})
+		g.Expect(validation.Validate(uuidV7(now.Add(-time.Hour)), v)).To(Succeed())
+		g.Expect(validation.Validate(ulid(now.Add(-time.Hour)), v)).To(Succeed())
+		g.Expect(validation.Validate(uuidV7(now.Add(-25*time.Hour)), v)).To(MatchError("must not be older than 24 hours"))
+		g.Expect(validation.Validate(ulid(now.Add(-25*time.Hour)), v)).To(MatchError("must not be older than 24 hours"))
+		// version 1, created in 1998
+		g.Expect(validation.Validate("6ba7b810-9dad-11d1-80b4-00c04fd430c8", v)).To(MatchError("must not be older than 24 hours"))
+		// version 4 keys carry no timestamp
+		g.Expect(validation.Validate("f47ac10b-58cc-4372-a567-0e02b2c3d479", v)).To(Succeed())
+	})
+}
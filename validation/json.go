@@ -0,0 +1,206 @@
+package validation
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Codes of the JSON form of errors (see ErrorObject).
+const (
+	// CodeInvalid is the code of validation Errors created without one.
+	CodeInvalid = "invalid"
+	// CodeInternal is the code of system errors (see IsSystemError).
+	CodeInternal = "internal"
+)
+
+// Codes of the errors of the core validators, with the parameters they set.
+const (
+	// CodeRequired is the code of Required, RequiredIf and missing required map keys.
+	CodeRequired = "required"
+	// CodeMinLength is the code of MinLength, with the "min" parameter.
+	CodeMinLength = "min_length"
+	// CodeMaxLength is the code of MaxLength, with the "max" parameter.
+	CodeMaxLength = "max_length"
+	// CodeLength is the code of Length, with the "min" and "max" parameters.
+	CodeLength = "length"
+	// CodeMin is the code of Min, with the "min" parameter.
+	CodeMin = "min"
+	// CodeMax is the code of Max, with the "max" parameter.
+	CodeMax = "max"
+	// CodeRange is the code of Range, with the "min" and "max" parameters.
+	CodeRange = "range"
+	// CodeGreaterThan is the code of GreaterThan, with the "threshold" parameter.
+	CodeGreaterThan = "greater_than"
+	// CodeLessThan is the code of LessThan, with the "threshold" parameter.
+	CodeLessThan = "less_than"
+	// CodeIn is the code of In, with the "values" parameter.
+	CodeIn = "in"
+	// CodePattern is the code of MatchesPattern, with the "pattern" parameter.
+	CodePattern = "pattern"
+)
+
+// ErrorObject is the JSON form of a single error, as written by the MarshalJSON methods of
+// Error, FieldError and Report.
+type ErrorObject struct {
+	Code    string         `json:"code"`
+	Message string         `json:"message"`
+	Field   string         `json:"field,omitempty"`
+	Params  map[string]any `json:"params,omitempty"`
+}
+
+// ErrorObjects flattens err into one ErrorObject per error, for handlers to return errors
+// verbatim, including the result of errors.Join, which has no JSON form of its own. Field
+// paths are joined like ToFormErrors does ("items[2].sku") and messages are not prefixed
+// with them. System errors (see IsSystemError) are reported with CodeInternal and a generic
+// message, so their details are not exposed. It returns nil if err is nil.
+//
+// Example:
+//
+//	if err := input.Validate(); err != nil {
+//	    w.WriteHeader(http.StatusUnprocessableEntity)
+//	    json.NewEncoder(w).Encode(map[string]any{"errors": validation.ErrorObjects(err)})
+//	}
+func ErrorObjects(err error) []ErrorObject {
+	if err == nil {
+		return nil
+	}
+	var out []ErrorObject
+	collectErrorObjects(&out, err, "")
+	return out
+}
+
+func collectErrorObjects(out *[]ErrorObject, err error, field string) {
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		for _, e := range joined.Unwrap() {
+			collectErrorObjects(out, e, field)
+		}
+		return
+	}
+	if fieldErr, ok := err.(*FieldError); ok {
+		collectErrorObjects(out, fieldErr.Unwrap(), joinPath(field, fieldErr.Field()))
+		return
+	}
+	if IsSystemError(err) {
+		*out = append(*out, ErrorObject{Code: CodeInternal, Message: "internal error", Field: field})
+		return
+	}
+	obj := ErrorObject{Code: CodeInvalid, Message: err.Error(), Field: field}
+	if valErr, ok := err.(*Error); ok {
+		obj.Code, obj.Params = valErr.Code(), valErr.Params()
+	}
+	*out = append(*out, obj)
+}
+
+// MarshalJSON writes the error as an ErrorObject:
+//
+//	{"code":"too_short","message":"must be at least 3 characters","params":{"min":3}}
+func (e *Error) MarshalJSON() ([]byte, error) {
+	return json.Marshal(ErrorObjects(e)[0])
+}
+
+// MarshalJSON writes the error as an ErrorObject with its field path, or as an array of
+// ErrorObjects when it is attributed several joined errors:
+//
+//	{"code":"invalid","message":"required","field":"address.city"}
+func (e *FieldError) MarshalJSON() ([]byte, error) {
+	objects := ErrorObjects(e)
+	if len(objects) == 1 {
+		return json.Marshal(objects[0])
+	}
+	return json.Marshal(objects)
+}
+
+// MarshalJSON writes the recorded errors as an array of ErrorObjects (see ErrorObjects).
+func (r *Report) MarshalJSON() ([]byte, error) {
+	objects := ErrorObjects(r.Err())
+	if objects == nil {
+		objects = []ErrorObject{}
+	}
+	return json.Marshal(objects)
+}
+
+// JSONAPIError is an error object of the JSON:API specification, as returned by
+// ToJSONAPIErrors. Status and Title are left to the HTTP layer, which knows the status of
+// the response.
+type JSONAPIError struct {
+	Status string         `json:"status,omitempty"`
+	Code   string         `json:"code"`
+	Title  string         `json:"title,omitempty"`
+	Detail string         `json:"detail"`
+	Source *JSONAPISource `json:"source,omitempty"`
+	Meta   map[string]any `json:"meta,omitempty"`
+}
+
+// JSONAPISource locates the member of the request document an error is attributed to.
+type JSONAPISource struct {
+	Pointer string `json:"pointer"`
+}
+
+// ToJSONAPIErrors converts err to JSON:API error objects, for endpoints following the
+// JSON:API specification. Field paths become JSON pointers to the attributes of the
+// primary data ("address.city" is "/data/attributes/address/city", "items[2].sku" is
+// "/data/attributes/items/2/sku"), codes and messages are those of ErrorObjects, and rule
+// parameters are written under meta. It returns nil if err is nil.
+//
+// Example:
+//
+//	w.Header().Set("Content-Type", "application/vnd.api+json")
+//	w.WriteHeader(http.StatusUnprocessableEntity)
+//	json.NewEncoder(w).Encode(map[string]any{"errors": validation.ToJSONAPIErrors(err)})
+func ToJSONAPIErrors(err error) []JSONAPIError {
+	objects := ErrorObjects(err)
+	if objects == nil {
+		return nil
+	}
+	errs := make([]JSONAPIError, len(objects))
+	for i, obj := range objects {
+		errs[i] = JSONAPIError{Code: obj.Code, Detail: obj.Message}
+		if obj.Field != "" {
+			errs[i].Source = &JSONAPISource{Pointer: jsonPointer("/data/attributes", obj.Field)}
+		}
+		if obj.Params != nil {
+			errs[i].Meta = map[string]any{"params": obj.Params}
+		}
+	}
+	return errs
+}
+
+// jsonPointer appends the names and indexes of a field path to the JSON pointer base,
+// escaping them as RFC 6901 requires.
+func jsonPointer(base, field string) string {
+	var b strings.Builder
+	b.WriteString(base)
+	for _, segment := range PathSegments(field) {
+		b.WriteByte('/')
+		b.WriteString(pointerEscaper.Replace(fmt.Sprint(segment)))
+	}
+	return b.String()
+}
+
+var pointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+// PathSegments splits a field path such as "items[2].sku", as found in ErrorObject.Field,
+// into its names and indexes, the latter as ints when they are numbers: ["items", 2, "sku"].
+// It serves error formats locating fields with paths, such as GraphQL.
+func PathSegments(field string) []any {
+	var segments []any
+	for _, part := range strings.Split(field, ".") {
+		name, rest, _ := strings.Cut(part, "[")
+		if name != "" {
+			segments = append(segments, name)
+		}
+		for rest != "" {
+			var index string
+			index, rest, _ = strings.Cut(rest, "]")
+			rest = strings.TrimPrefix(rest, "[")
+			if n, err := strconv.Atoi(index); err == nil {
+				segments = append(segments, n)
+			} else {
+				segments = append(segments, index)
+			}
+		}
+	}
+	return segments
+}
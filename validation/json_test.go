@@ -0,0 +1,174 @@
+package validation_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/quantumcycle/protego/validation"
+)
+
+func TestErrorJSON(t *testing.T) {
+
+	t.Run("marshals errors with their code and params", func(t *testing.T) {
+		g := NewWithT(t)
+		err := validation.NewCodedError("too_short", "must be at least 3 characters", map[string]any{"min": 3})
+		data, marshalErr := json.Marshal(err)
+		g.Expect(marshalErr).ToNot(HaveOccurred())
+		g.Expect(data).To(MatchJSON(`{"code":"too_short","message":"must be at least 3 characters","params":{"min":3}}`))
+	})
+
+	t.Run("defaults the code to invalid", func(t *testing.T) {
+		g := NewWithT(t)
+		data, err := json.Marshal(validation.NewValidationError("required"))
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(data).To(MatchJSON(`{"code":"invalid","message":"required"}`))
+	})
+
+	t.Run("marshals field errors with their path", func(t *testing.T) {
+		g := NewWithT(t)
+		err := validation.NewFieldError("address", validation.NewFieldError("city", validation.NewValidationError("required")))
+		data, marshalErr := json.Marshal(err)
+		g.Expect(marshalErr).ToNot(HaveOccurred())
+		g.Expect(data).To(MatchJSON(`{"code":"invalid","message":"required","field":"address.city"}`))
+	})
+
+	t.Run("marshals field errors joining several errors as an array", func(t *testing.T) {
+		g := NewWithT(t)
+		err := validation.NewFieldError("items", errors.Join(
+			validation.NewFieldError("[0]", validation.NewValidationError("required")),
+			validation.NewFieldError("[2]", validation.NewCodedError("out_of_range", "must be between 1 and 10", map[string]any{"min": 1, "max": 10})),
+		))
+		data, marshalErr := json.Marshal(err)
+		g.Expect(marshalErr).ToNot(HaveOccurred())
+		g.Expect(data).To(MatchJSON(`[
+			{"code":"invalid","message":"required","field":"items[0]"},
+			{"code":"out_of_range","message":"must be between 1 and 10","field":"items[2]","params":{"min":1,"max":10}}
+		]`))
+	})
+
+	t.Run("marshals reports as arrays", func(t *testing.T) {
+		g := NewWithT(t)
+		report := validation.NewReport()
+		defer report.Release()
+		data, err := json.Marshal(report)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(data).To(MatchJSON(`[]`))
+
+		report.Add(validation.NewFieldError("email", validation.NewValidationError("required")))
+		data, err = json.Marshal(report)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(data).To(MatchJSON(`[{"code":"invalid","message":"required","field":"email"}]`))
+	})
+
+	t.Run("keeps codes through Sensitive and included values", func(t *testing.T) {
+		g := NewWithT(t)
+		validation.SetIncludeValues(true)
+		defer validation.SetIncludeValues(false)
+		tooShort := func(string) error {
+			return validation.NewCodedError("too_short", "must be at least 12 characters", nil)
+		}
+		err := validation.Validate("secret", validation.Sensitive(tooShort))
+		g.Expect(validation.ErrorObjects(err)).To(Equal([]validation.ErrorObject{{Code: "too_short", Message: "must be at least 12 characters"}}))
+		err = validation.Validate("abc", tooShort)
+		g.Expect(validation.ErrorObjects(err)).To(Equal([]validation.ErrorObject{{Code: "too_short", Message: `must be at least 12 characters, got "abc"`}}))
+	})
+
+	t.Run("reports the codes and params of core validators", func(t *testing.T) {
+		g := NewWithT(t)
+		err := errors.Join(
+			validation.NewFieldError("name", validation.Validate("", validation.Required[string]())),
+			validation.NewFieldError("bio", validation.Validate("ab", validation.MinLength(3))),
+			validation.NewFieldError("title", validation.Validate("abcdef", validation.MaxLength(5))),
+			validation.NewFieldError("code", validation.Validate("ab", validation.Length(3, 5))),
+			validation.NewFieldError("age", validation.Validate(12, validation.Min(18))),
+			validation.NewFieldError("discount", validation.Validate(1.5, validation.Max(1.0))),
+			validation.NewFieldError("rating", validation.Validate(6, validation.Range(1, 5))),
+			validation.NewFieldError("count", validation.Validate(0, validation.GreaterThan(0))),
+			validation.NewFieldError("percent", validation.Validate(100, validation.LessThan(100))),
+			validation.NewFieldError("status", validation.Validate("DONE", validation.In(false, "ACTIVE", "PENDING"))),
+			validation.NewFieldError("mode", validation.Validate("x", validation.In(true, "single"))),
+			validation.NewFieldError("sku", validation.Validate("abc", validation.MatchesPattern(`^[A-Z]+$`))),
+			validation.ValidateStringMap(map[string]string{}, false, validation.MapKey[string]("host", true)),
+		)
+		g.Expect(validation.ErrorObjects(err)).To(Equal([]validation.ErrorObject{
+			{Code: validation.CodeRequired, Message: "required", Field: "name"},
+			{Code: validation.CodeMinLength, Message: "must be at least 3 characters", Field: "bio", Params: map[string]any{"min": 3}},
+			{Code: validation.CodeMaxLength, Message: "must be at most 5 characters", Field: "title", Params: map[string]any{"max": 5}},
+			{Code: validation.CodeLength, Message: "must be between 3 and 5 characters", Field: "code", Params: map[string]any{"min": 3, "max": 5}},
+			{Code: validation.CodeMin, Message: "must be at least 18", Field: "age", Params: map[string]any{"min": 18}},
+			{Code: validation.CodeMax, Message: "must be at most 1", Field: "discount", Params: map[string]any{"max": 1.0}},
+			{Code: validation.CodeRange, Message: "must be between 1 and 5", Field: "rating", Params: map[string]any{"min": 1, "max": 5}},
+			{Code: validation.CodeGreaterThan, Message: "must be greater than 0", Field: "count", Params: map[string]any{"threshold": 0}},
+			{Code: validation.CodeLessThan, Message: "must be less than 100", Field: "percent", Params: map[string]any{"threshold": 100}},
+			{Code: validation.CodeIn, Message: "must be one of: [ACTIVE PENDING]", Field: "status", Params: map[string]any{"values": []string{"ACTIVE", "PENDING"}}},
+			{Code: validation.CodeIn, Message: "must be one of: [single]", Field: "mode", Params: map[string]any{"values": []string{"single"}}},
+			{Code: validation.CodePattern, Message: `must match pattern "^[A-Z]+$"`, Field: "sku", Params: map[string]any{"pattern": "^[A-Z]+$"}},
+			{Code: validation.CodeRequired, Message: `key "host" is required`},
+		}))
+
+		data, marshalErr := json.Marshal(validation.Validate("ab", validation.MinLength(3)))
+		g.Expect(marshalErr).ToNot(HaveOccurred())
+		g.Expect(data).To(MatchJSON(`{"code":"min_length","message":"must be at least 3 characters","params":{"min":3}}`))
+	})
+
+	t.Run("hides the message of system errors", func(t *testing.T) {
+		g := NewWithT(t)
+		err := errors.Join(
+			validation.NewFieldError("email", errors.New("dial tcp: connection refused")),
+			validation.NewFieldError("name", validation.NewValidationError("required")),
+		)
+		g.Expect(validation.ErrorObjects(err)).To(Equal([]validation.ErrorObject{
+			{Code: validation.CodeInternal, Message: "internal error", Field: "email"},
+			{Code: validation.CodeInvalid, Message: "required", Field: "name"},
+		}))
+		g.Expect(validation.ErrorObjects(nil)).To(BeNil())
+	})
+}
+
+func TestToJSONAPIErrors(t *testing.T) {
+
+	t.Run("maps field paths to source pointers", func(t *testing.T) {
+		g := NewWithT(t)
+		err := errors.Join(
+			validation.NewFieldError("email", validation.NewValidationError("required")),
+			validation.NewFieldError("items", validation.NewFieldError("[2]", validation.NewFieldError("sku",
+				validation.NewCodedError("too_short", "must be at least 8 characters", map[string]any{"min": 8})))),
+			validation.NewFieldError("links", validation.NewFieldError("a/b~c", validation.NewValidationError("must be a valid URL"))),
+			validation.NewValidationError("invalid payload"),
+		)
+		g.Expect(validation.ToJSONAPIErrors(err)).To(Equal([]validation.JSONAPIError{
+			{Code: "invalid", Detail: "required", Source: &validation.JSONAPISource{Pointer: "/data/attributes/email"}},
+			{
+				Code:   "too_short",
+				Detail: "must be at least 8 characters",
+				Source: &validation.JSONAPISource{Pointer: "/data/attributes/items/2/sku"},
+				Meta:   map[string]any{"params": map[string]any{"min": 8}},
+			},
+			{Code: "invalid", Detail: "must be a valid URL", Source: &validation.JSONAPISource{Pointer: "/data/attributes/links/a~1b~0c"}},
+			{Code: "invalid", Detail: "invalid payload"},
+		}))
+	})
+
+	t.Run("marshals without status and title", func(t *testing.T) {
+		g := NewWithT(t)
+		data, err := json.Marshal(validation.ToJSONAPIErrors(validation.NewFieldError("email", validation.NewValidationError("required"))))
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(data).To(MatchJSON(`[{"code":"invalid","detail":"required","source":{"pointer":"/data/attributes/email"}}]`))
+	})
+
+	t.Run("returns nil for nil", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(validation.ToJSONAPIErrors(nil)).To(BeNil())
+	})
+}
+
+func TestPathSegments(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(validation.PathSegments("items[2].sku")).To(Equal([]any{"items", 2, "sku"}))
+	g.Expect(validation.PathSegments("matrix[0][1]")).To(Equal([]any{"matrix", 0, 1}))
+	g.Expect(validation.PathSegments("headers[X-Id]")).To(Equal([]any{"headers", "X-Id"}))
+	g.Expect(validation.PathSegments("")).To(BeNil())
+}
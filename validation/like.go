@@ -0,0 +1,54 @@
+package validation
+
+import (
+	"fmt"
+	"strings"
+)
+
+// IsSafeLikePattern validates a user-supplied search pattern before it is passed as a
+// parameter to an SQL LIKE clause with ESCAPE '\'. The wildcards % and _ are allowed, at
+// most maxWildcards of them, to bound the cost of the match; a maxWildcards of zero or less
+// allows none. A backslash must escape %, _ or itself, and a pattern made only of wildcards, which
+// would match every row, is rejected.
+//
+// Example:
+//
+//	validation.Validate(query.Get("q"), validation.MaxLength(64), validation.IsSafeLikePattern(2))
+//	// SELECT ... WHERE name LIKE $1 ESCAPE '\'
+func IsSafeLikePattern(maxWildcards int) Validator[string] {
+	return func(v string) error {
+		wildcards, literals := 0, 0
+		for i := 0; i < len(v); i++ {
+			switch v[i] {
+			case '\\':
+				if i+1 == len(v) || !strings.ContainsRune(`%_\`, rune(v[i+1])) {
+					return NewValidationError(`must only use \ to escape %, _ or \`)
+				}
+				i++
+				literals++
+			case '%', '_':
+				wildcards++
+			default:
+				literals++
+			}
+		}
+		if wildcards > max(maxWildcards, 0) {
+			if maxWildcards <= 0 {
+				return NewValidationError("must not contain wildcards")
+			}
+			return NewValidationError(fmt.Sprintf("must contain at most %d wildcards", maxWildcards))
+		}
+		if wildcards > 0 && literals == 0 {
+			return NewValidationError("must contain characters other than wildcards")
+		}
+		return nil
+	}
+}
+
+// EscapeLike escapes the wildcards and backslashes of s so that it matches literally in an
+// SQL LIKE clause with ESCAPE '\', such as for a "contains" search: "%" + EscapeLike(s) + "%".
+func EscapeLike(s string) string {
+	return likeEscaper.Replace(s)
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
@@ -0,0 +1,46 @@
+package validation_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/quantumcycle/protego/validation"
+)
+
+func TestIsSafeLikePattern(t *testing.T) {
+	t.Run("passes patterns within the wildcard limit", func(t *testing.T) {
+		g := NewWithT(t)
+		for _, v := range []string{"john", "john%", "%doe%", "j_hn", `100\%`, `a\_b%`, `back\\slash`} {
+			g.Expect(validation.Validate(v, validation.IsSafeLikePattern(2))).To(Succeed(), v)
+		}
+	})
+
+	t.Run("rejects too many wildcards", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(validation.Validate("%a%b%", validation.IsSafeLikePattern(2))).To(MatchError("must contain at most 2 wildcards"))
+		g.Expect(validation.Validate("a%", validation.IsSafeLikePattern(0))).To(MatchError("must not contain wildcards"))
+		g.Expect(validation.Validate("a%", validation.IsSafeLikePattern(-1))).To(MatchError("must not contain wildcards"))
+		g.Expect(validation.Validate(`a\%`, validation.IsSafeLikePattern(0))).To(Succeed())
+	})
+
+	t.Run("rejects invalid escapes", func(t *testing.T) {
+		g := NewWithT(t)
+		for _, v := range []string{`a\`, `a\b`, `\n`} {
+			g.Expect(validation.Validate(v, validation.IsSafeLikePattern(2))).To(MatchError(`must only use \ to escape %, _ or \`), v)
+		}
+	})
+
+	t.Run("rejects patterns made only of wildcards", func(t *testing.T) {
+		g := NewWithT(t)
+		for _, v := range []string{"%", "%%", "_%"} {
+			g.Expect(validation.Validate(v, validation.IsSafeLikePattern(3))).To(MatchError("must contain characters other than wildcards"), v)
+		}
+	})
+}
+
+func TestEscapeLike(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(validation.EscapeLike(`50%_off\`)).To(Equal(`50\%\_off\\`))
+	g.Expect(validation.Validate(validation.EscapeLike("100%"), validation.IsSafeLikePattern(0))).To(Succeed())
+}
@@ -0,0 +1,138 @@
+package validation
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// numberFormat holds the decimal separator and the accepted digit group separators of a locale.
+type numberFormat struct {
+	decimal rune
+	groups  []rune
+}
+
+var (
+	dotDecimal   = numberFormat{decimal: '.', groups: []rune{','}}
+	commaDecimal = numberFormat{decimal: ',', groups: []rune{'.'}}
+	// Locales grouping digits with spaces write them as regular, no-break (U+00A0) or narrow
+	// no-break (U+202F) spaces depending on the software that produced the number.
+	spaceGrouped = numberFormat{decimal: ',', groups: []rune{' ', '\u00a0', '\u202f'}}
+	swissFormat  = numberFormat{decimal: '.', groups: []rune{'\'', '’'}}
+)
+
+// numberFormats maps languages, or language-region pairs where a region differs from its
+// language, to their number format.
+var numberFormats = map[string]numberFormat{
+	"en": dotDecimal, "ja": dotDecimal, "ko": dotDecimal, "zh": dotDecimal, "th": dotDecimal,
+	"he": dotDecimal, "ms": dotDecimal, "fil": dotDecimal,
+	"es-mx": dotDecimal, "es-us": dotDecimal, "fr-ch": swissFormat, "de-ch": swissFormat,
+	"it-ch": swissFormat, "de-li": swissFormat,
+
+	"de": commaDecimal, "es": commaDecimal, "it": commaDecimal, "nl": commaDecimal,
+	"pt": commaDecimal, "id": commaDecimal, "tr": commaDecimal, "da": commaDecimal,
+	"el": commaDecimal, "ro": commaDecimal, "hr": commaDecimal, "sl": commaDecimal,
+	"sr": commaDecimal,
+
+	"fr": spaceGrouped, "ru": spaceGrouped, "uk": spaceGrouped, "pl": spaceGrouped,
+	"cs": spaceGrouped, "sk": spaceGrouped, "sv": spaceGrouped, "fi": spaceGrouped,
+	"nb": spaceGrouped, "no": spaceGrouped, "hu": spaceGrouped, "bg": spaceGrouped,
+	"lt": spaceGrouped, "lv": spaceGrouped, "et": spaceGrouped,
+}
+
+// lookupNumberFormat returns the number format of a BCP 47 locale such as "de-CH" or "fr",
+// falling back to the language when the region is unknown.
+func lookupNumberFormat(locale string) (numberFormat, bool) {
+	tag := strings.ToLower(strings.ReplaceAll(locale, "_", "-"))
+	if f, ok := numberFormats[tag]; ok {
+		return f, true
+	}
+	language, _, _ := strings.Cut(tag, "-")
+	f, ok := numberFormats[language]
+	return f, ok
+}
+
+// ParseLocalizedNumber parses a number written with the decimal and digit group separators
+// of a BCP 47 locale, such as "1.234,56" in "de" or "1,234.56" in "en". Digit groups are
+// optional but must have 3 digits when present. Invalid numbers are validation errors; an
+// unsupported locale is a system error (see IsSystemError).
+//
+// Example:
+//
+//	amount, err := validation.ParseLocalizedNumber(cell, "fr-FR") // "1 234,56" is 1234.56
+func ParseLocalizedNumber(s, locale string) (float64, error) {
+	format, ok := lookupNumberFormat(locale)
+	if !ok {
+		return 0, fmt.Errorf("validation: unsupported locale %q", locale)
+	}
+	normalized, ok := format.normalize(s)
+	if !ok {
+		return 0, NewValidationError(fmt.Sprintf("must be a valid number for locale %q", locale))
+	}
+	v, err := strconv.ParseFloat(normalized, 64)
+	if err != nil {
+		return 0, NewValidationError(fmt.Sprintf("must be a valid number for locale %q", locale))
+	}
+	return v, nil
+}
+
+// IsLocalizedNumber validates that a string is a number written in the format of a BCP 47
+// locale (see ParseLocalizedNumber). Use coerce.LocalizedFloat to also get the parsed value.
+//
+// Example:
+//
+//	validation.Validate(row["amount"], validation.IsLocalizedNumber("de-DE"))
+func IsLocalizedNumber(locale string) Validator[string] {
+	return func(v string) error {
+		_, err := ParseLocalizedNumber(v, locale)
+		return err
+	}
+}
+
+// normalize rewrites a localized number in the syntax of strconv.ParseFloat, checking the
+// placement of the separators.
+func (f numberFormat) normalize(s string) (string, bool) {
+	sign := ""
+	if strings.HasPrefix(s, "-") || strings.HasPrefix(s, "+") {
+		sign, s = s[:1], s[1:]
+	}
+	integer, fraction, hasFraction := strings.Cut(s, string(f.decimal))
+	if hasFraction && !allDigits(fraction) {
+		return "", false
+	}
+	groups := []string{integer}
+	if i := strings.IndexFunc(integer, f.isGroupSeparator); i >= 0 {
+		// all groups must use the same separator, mixed ones fail the digit check
+		sep, _ := utf8.DecodeRuneInString(integer[i:])
+		groups = strings.Split(integer, string(sep))
+		if len(groups[0]) > 3 {
+			return "", false
+		}
+	}
+	for i, g := range groups {
+		if !allDigits(g) || (i > 0 && len(g) != 3) {
+			return "", false
+		}
+	}
+	normalized := sign + strings.Join(groups, "")
+	if hasFraction {
+		normalized += "." + fraction
+	}
+	return normalized, true
+}
+
+func (f numberFormat) isGroupSeparator(r rune) bool {
+	return slices.Contains(f.groups, r)
+}
+
+// allDigits reports whether s is a non-empty sequence of ASCII digits.
+func allDigits(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
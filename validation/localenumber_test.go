@@ -0,0 +1,57 @@
+package validation_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/quantumcycle/protego/validation"
+)
+
+func TestParseLocalizedNumber(t *testing.T) {
+	t.Run("parses numbers per locale", func(t *testing.T) {
+		g := NewWithT(t)
+		cases := []struct {
+			s, locale string
+			want      float64
+		}{
+			{"1,234.56", "en-US", 1234.56},
+			{"1.234,56", "de-DE", 1234.56},
+			{"1.234", "de", 1234},
+			{"1.234", "en", 1.234},
+			{"1 234,56", "fr-FR", 1234.56},
+			{"1 234 567,5", "fr", 1234567.5},
+			{"1'234.56", "de-CH", 1234.56},
+			{"1’234.56", "fr_CH", 1234.56},
+			{"-1234,5", "es", -1234.5},
+			{"+0,5", "it", 0.5},
+			{"1,234.5", "es-MX", 1234.5},
+		}
+		for _, c := range cases {
+			v, err := validation.ParseLocalizedNumber(c.s, c.locale)
+			g.Expect(err).ToNot(HaveOccurred(), c.s)
+			g.Expect(v).To(Equal(c.want), c.s)
+		}
+	})
+
+	t.Run("rejects misplaced separators", func(t *testing.T) {
+		g := NewWithT(t)
+		for _, s := range []string{"1,234.56", "1.23,4", "12.34.567", "1234.567", "1..234", ",5", "1,", "1.234 567", "", "-", "1e3"} {
+			_, err := validation.ParseLocalizedNumber(s, "de")
+			g.Expect(err).To(MatchError(`must be a valid number for locale "de"`), s)
+		}
+	})
+
+	t.Run("reports unsupported locales as system errors", func(t *testing.T) {
+		g := NewWithT(t)
+		_, err := validation.ParseLocalizedNumber("1", "xx")
+		g.Expect(err).To(MatchError(`validation: unsupported locale "xx"`))
+		g.Expect(validation.IsSystemError(err)).To(BeTrue())
+	})
+}
+
+func TestIsLocalizedNumber(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(validation.Validate("1.234,56", validation.IsLocalizedNumber("pt-BR"))).To(Succeed())
+	g.Expect(validation.Validate("1,234.56", validation.IsLocalizedNumber("pt-BR"))).To(MatchError(`must be a valid number for locale "pt-BR"`))
+}
@@ -0,0 +1,445 @@
+package validation
+
+import (
+	"fmt"
+	"html"
+	"net/url"
+	"strings"
+)
+
+// MarkdownElement is a Markdown construct allowed by IsSafeMarkdown.
+type MarkdownElement struct {
+	name  string
+	hosts []string
+}
+
+// Markdown constructs allowed by IsSafeMarkdown. Links and images are allowed with
+// MarkdownLinks and MarkdownImages, which restrict the hosts they can target.
+var (
+	// MarkdownEmphasis allows emphasis and strong emphasis, such as *this* or **this**.
+	MarkdownEmphasis = MarkdownElement{name: "emphasis"}
+	// MarkdownHeadings allows ATX and setext headings, such as "# Title".
+	MarkdownHeadings = MarkdownElement{name: "headings"}
+	// MarkdownLists allows bullet and ordered lists.
+	MarkdownLists = MarkdownElement{name: "lists"}
+	// MarkdownBlockquotes allows block quotes, lines starting with ">".
+	MarkdownBlockquotes = MarkdownElement{name: "block quotes"}
+	// MarkdownCode allows code spans and fenced and indented code blocks.
+	MarkdownCode = MarkdownElement{name: "code"}
+)
+
+const (
+	markdownLinks  = "links"
+	markdownImages = "images"
+)
+
+// MarkdownLinks allows links, including autolinks and link reference definitions, to
+// relative URLs and to http and https URLs whose host is one of hosts or a subdomain of
+// one: "example.com" allows "docs.example.com".
+func MarkdownLinks(hosts ...string) MarkdownElement {
+	return MarkdownElement{name: markdownLinks, hosts: hosts}
+}
+
+// MarkdownImages allows images whose source is a relative URL or an http or https URL
+// whose host is one of hosts or a subdomain of one.
+func MarkdownImages(hosts ...string) MarkdownElement {
+	return MarkdownElement{name: markdownImages, hosts: hosts}
+}
+
+// IsSafeMarkdown validates that a string, such as a comment or a description, only uses the
+// allowed Markdown constructs: plain text and paragraphs are always allowed, raw HTML never
+// is, as renderers passing it through are open to cross-site scripting. Links and images
+// are checked against the hosts they allow, so that user content cannot link to phishing
+// sites or embed tracking pixels.
+//
+// The string is scanned for the block and inline constructs of CommonMark, without being
+// rendered. Bare URLs are text: renderers that turn them into links should not be used
+// with untrusted content.
+//
+// Example:
+//
+//	validation.Validate(comment.Body, validation.IsSafeMarkdown(
+//	    validation.MarkdownEmphasis,
+//	    validation.MarkdownCode,
+//	    validation.MarkdownLinks("example.com", "github.com"),
+//	))
+func IsSafeMarkdown(allowedElements ...MarkdownElement) Validator[string] {
+	allowed := make(map[string]MarkdownElement, len(allowedElements))
+	for _, e := range allowedElements {
+		allowed[e.name] = e
+	}
+	return func(v string) error {
+		s := markdownScanner{allowed: allowed}
+		return s.scan(v)
+	}
+}
+
+// markdownScanner finds the constructs of a Markdown document, line by line.
+type markdownScanner struct {
+	allowed map[string]MarkdownElement
+
+	fence        string // closing fence of the current fenced code block
+	indentedCode bool   // whether the previous line was in an indented code block
+	prevBlank    bool   // whether the previous line was blank
+	inList       bool   // whether indented lines continue a list item
+	brackets     []bool // open link text brackets, true for images
+}
+
+func (s *markdownScanner) scan(doc string) error {
+	s.prevBlank = true
+	for _, line := range strings.Split(doc, "\n") {
+		if err := s.scanLine(strings.TrimSuffix(line, "\r")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *markdownScanner) scanLine(line string) error {
+	if s.fence != "" {
+		if trimmed := strings.TrimSpace(line); strings.HasPrefix(trimmed, s.fence) && strings.Trim(trimmed, s.fence[:1]) == "" {
+			s.fence = ""
+		}
+		return nil
+	}
+
+	if strings.TrimSpace(line) == "" {
+		s.prevBlank, s.brackets = true, s.brackets[:0]
+		return nil
+	}
+	indent := len(line) - len(strings.TrimLeft(line, " \t"))
+	if strings.HasPrefix(line, "\t") || indent >= 4 {
+		if s.indentedCode || (s.prevBlank && !s.inList) {
+			s.prevBlank, s.indentedCode = false, true
+			return s.require(MarkdownCode.name)
+		}
+	} else {
+		s.inList = false
+	}
+	s.indentedCode = false
+	prevBlank := s.prevBlank
+	s.prevBlank = false
+	rest := strings.TrimLeft(line, " \t")
+
+	// block quote and list markers, which can nest, precede the content of the line
+	for {
+		switch {
+		case strings.HasPrefix(rest, ">"):
+			if err := s.require(MarkdownBlockquotes.name); err != nil {
+				return err
+			}
+			rest = strings.TrimLeft(rest[1:], " \t")
+			continue
+		case isThematicBreak(rest):
+			return nil
+		}
+		if marker := listMarker(rest); marker > 0 {
+			if err := s.require(MarkdownLists.name); err != nil {
+				return err
+			}
+			s.inList = true
+			rest = strings.TrimLeft(rest[marker:], " \t")
+			continue
+		}
+		break
+	}
+
+	switch {
+	case strings.HasPrefix(rest, "```") || strings.HasPrefix(rest, "~~~"):
+		s.fence = rest[:3]
+		return s.require(MarkdownCode.name)
+	case isATXHeading(rest):
+		if err := s.require(MarkdownHeadings.name); err != nil {
+			return err
+		}
+		rest = strings.TrimLeft(rest, "#")
+	case !prevBlank && strings.HasPrefix(rest, "=") && strings.TrimRight(rest, "= \t") == "":
+		return s.require(MarkdownHeadings.name)
+	case strings.HasPrefix(rest, "["):
+		if label, destination, ok := referenceDefinition(rest); ok {
+			if err := s.checkDestination(markdownLinks, destination); err != nil {
+				return err
+			}
+			rest = label
+		}
+	}
+	return s.scanInline(rest)
+}
+
+// scanInline finds the inline constructs of the content of a line.
+func (s *markdownScanner) scanInline(text string) error {
+	for i := 0; i < len(text); i++ {
+		switch c := text[i]; c {
+		case '\\':
+			i++
+		case '`':
+			run := runLength(text[i:], '`')
+			if end := strings.Index(text[i+run:], strings.Repeat("`", run)); end >= 0 {
+				if err := s.require(MarkdownCode.name); err != nil {
+					return err
+				}
+				i += run + end + run - 1
+				continue
+			}
+			i += run - 1
+		case '<':
+			if end := strings.IndexByte(text[i:], '>'); end > 0 {
+				inner := text[i+1 : i+end]
+				if isAutolink(inner) {
+					if err := s.checkDestination(markdownLinks, inner); err != nil {
+						return err
+					}
+					i += end
+					continue
+				}
+			}
+			if isHTMLStart(text[i+1:]) {
+				return NewValidationError("must not contain raw HTML")
+			}
+		case '!':
+			if i+1 < len(text) && text[i+1] == '[' {
+				s.brackets = append(s.brackets, true)
+				i++
+			}
+		case '[':
+			s.brackets = append(s.brackets, false)
+		case ']':
+			if len(s.brackets) == 0 {
+				continue
+			}
+			image := s.brackets[len(s.brackets)-1]
+			s.brackets = s.brackets[:len(s.brackets)-1]
+			element := markdownLinks
+			if image {
+				element = markdownImages
+			}
+			next := byte(0)
+			if i+1 < len(text) {
+				next = text[i+1]
+			}
+			switch {
+			case next == '(':
+				destination, end := linkDestination(text[i+2:])
+				if err := s.checkDestination(element, destination); err != nil {
+					return err
+				}
+				i += 2 + end
+			case next == '[' || image:
+				// the destination of a reference is checked with its definition
+				if err := s.require(element); err != nil {
+					return err
+				}
+			}
+		case '*', '_':
+			run := runLength(text[i:], c)
+			if isEmphasis(text, i, run) {
+				if err := s.require(MarkdownEmphasis.name); err != nil {
+					return err
+				}
+			}
+			i += run - 1
+		}
+	}
+	return nil
+}
+
+// require returns an error unless the element is allowed.
+func (s *markdownScanner) require(element string) error {
+	if _, ok := s.allowed[element]; !ok {
+		return NewValidationError(fmt.Sprintf("must not contain %s", element))
+	}
+	return nil
+}
+
+// checkDestination returns an error unless the element is allowed and destination is a
+// relative URL or targets one of its hosts. The destination is checked as renderers
+// resolve it, once its backslash escapes and entity references are decoded, so that
+// "javascript&#x3A;alert(1)" is not taken for a relative URL.
+func (s *markdownScanner) checkDestination(element, destination string) error {
+	if err := s.require(element); err != nil {
+		return err
+	}
+	u, err := url.Parse(unescapeDestination(destination))
+	if err != nil {
+		return NewValidationError("must not contain invalid URLs")
+	}
+	if u.Scheme == "" && u.Host == "" {
+		return nil
+	}
+	if u.Scheme != "" && u.Scheme != "http" && u.Scheme != "https" {
+		return NewValidationError(fmt.Sprintf("must not contain %s: URLs", strings.ToLower(u.Scheme)))
+	}
+	host := strings.ToLower(u.Hostname())
+	for _, allowed := range s.allowed[element].hosts {
+		allowed = strings.ToLower(allowed)
+		if host == allowed || strings.HasSuffix(host, "."+allowed) {
+			return nil
+		}
+	}
+	if element == markdownImages {
+		return NewValidationError(fmt.Sprintf("must not contain images from %q", host))
+	}
+	return NewValidationError(fmt.Sprintf("must not contain links to %q", host))
+}
+
+// unescapeDestination decodes the backslash escapes of ASCII punctuation and the entity
+// references of a link destination, such as "https\://" and "https&#58;//".
+func unescapeDestination(destination string) string {
+	var b strings.Builder
+	start := 0
+	for i := 0; i+1 < len(destination); i++ {
+		if destination[i] == '\\' && isASCIIPunct(destination[i+1]) {
+			b.WriteString(html.UnescapeString(destination[start:i]))
+			b.WriteByte(destination[i+1])
+			i++
+			start = i + 1
+		}
+	}
+	b.WriteString(html.UnescapeString(destination[start:]))
+	return b.String()
+}
+
+func isASCIIPunct(c byte) bool {
+	return c >= '!' && c <= '/' || c >= ':' && c <= '@' || c >= '[' && c <= '`' || c >= '{' && c <= '~'
+}
+
+// listMarker returns the length of the bullet or ordered list marker starting line, or 0.
+func listMarker(line string) int {
+	if len(line) >= 2 && strings.IndexByte("-*+", line[0]) >= 0 && (line[1] == ' ' || line[1] == '\t') {
+		return 1
+	}
+	digits := 0
+	for digits < len(line) && digits < 9 && line[digits] >= '0' && line[digits] <= '9' {
+		digits++
+	}
+	if digits > 0 && digits+1 < len(line) && (line[digits] == '.' || line[digits] == ')') && (line[digits+1] == ' ' || line[digits+1] == '\t') {
+		return digits + 1
+	}
+	return 0
+}
+
+// isThematicBreak reports whether line is a horizontal rule, such as "---" or "* * *".
+func isThematicBreak(line string) bool {
+	if line == "" || strings.IndexByte("-*_", line[0]) < 0 {
+		return false
+	}
+	count := 0
+	for _, c := range line {
+		switch {
+		case byte(c) == line[0]:
+			count++
+		case c != ' ' && c != '\t':
+			return false
+		}
+	}
+	return count >= 3
+}
+
+func isATXHeading(line string) bool {
+	level := runLength(line, '#')
+	return level >= 1 && level <= 6 && (level == len(line) || line[level] == ' ' || line[level] == '\t')
+}
+
+// referenceDefinition parses a link reference definition, such as "[docs]: https://example.com".
+func referenceDefinition(line string) (label, destination string, ok bool) {
+	end := strings.Index(line, "]:")
+	if end < 0 {
+		return "", "", false
+	}
+	destination, _ = linkDestination(strings.TrimLeft(line[end+2:], " \t"))
+	return line[1:end], destination, destination != ""
+}
+
+// linkDestination parses the destination of an inline link or image, text following its
+// opening parenthesis, returning it and the index of the closing parenthesis.
+func linkDestination(text string) (destination string, end int) {
+	start := len(text) - len(strings.TrimLeft(text, " \t"))
+	i := start
+	if closing := strings.IndexByte(text[start:], '>'); start < len(text) && text[start] == '<' && closing > 0 {
+		destination, i = text[start+1:start+closing], start+closing+1
+	} else {
+		depth := 0
+		for ; i < len(text) && text[i] != ' ' && text[i] != '\t'; i++ {
+			if text[i] == '(' {
+				depth++
+			} else if text[i] == ')' {
+				if depth == 0 {
+					break
+				}
+				depth--
+			}
+		}
+		destination = text[start:i]
+	}
+	if closing := strings.IndexByte(text[i:], ')'); closing >= 0 {
+		return destination, i + closing
+	}
+	return destination, len(text)
+}
+
+// isAutolink reports whether the text between angle brackets is an absolute URL or an
+// email address, rendered as a link.
+func isAutolink(inner string) bool {
+	if strings.ContainsAny(inner, " \t<") {
+		return false
+	}
+	scheme, _, found := strings.Cut(inner, ":")
+	if found && len(scheme) >= 2 && len(scheme) <= 32 && isASCIILetter(scheme[0]) {
+		return true
+	}
+	return strings.Contains(inner, "@")
+}
+
+// isHTMLStart reports whether text, following "<", starts an HTML tag, comment, processing
+// instruction or declaration.
+func isHTMLStart(text string) bool {
+	if text == "" {
+		return false
+	}
+	switch text[0] {
+	case '/':
+		return len(text) > 1 && isASCIILetter(text[1])
+	case '!', '?':
+		return true
+	}
+	return isASCIILetter(text[0])
+}
+
+// isEmphasis reports whether the run of delimiters at text[i:i+run] opens emphasis closed
+// later on the line: it must be followed by a non-space character, and an underscore must
+// not be inside a word, as in snake_case.
+func isEmphasis(text string, i, run int) bool {
+	c := text[i]
+	end := i + run
+	if end >= len(text) || text[end] == ' ' || text[end] == '\t' {
+		return false
+	}
+	if c == '_' && i > 0 && isWordByte(text[i-1]) {
+		return false
+	}
+	for j := end + 1; j < len(text); j++ {
+		if text[j] == c && text[j-1] != ' ' && text[j-1] != '\t' && text[j-1] != '\\' {
+			if c == '_' && j+1 < len(text) && isWordByte(text[j+1]) {
+				continue
+			}
+			return true
+		}
+	}
+	return false
+}
+
+func runLength(text string, c byte) int {
+	n := 0
+	for n < len(text) && text[n] == c {
+		n++
+	}
+	return n
+}
+
+func isASCIILetter(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+func isWordByte(c byte) bool {
+	return isASCIILetter(c) || c >= '0' && c <= '9' || c >= 0x80
+}
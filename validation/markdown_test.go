@@ -0,0 +1,100 @@
+package validation_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/quantumcycle/protego/validation"
+)
+
+func TestIsSafeMarkdown(t *testing.T) {
+	validator := validation.IsSafeMarkdown(
+		validation.MarkdownEmphasis,
+		validation.MarkdownLists,
+		validation.MarkdownCode,
+		validation.MarkdownLinks("example.com"),
+	)
+
+	t.Run("accepts allowed constructs", func(t *testing.T) {
+		g := NewWithT(t)
+		doc := "Thanks, this is **great**!\n\n" +
+			"- see [the docs](https://docs.example.com/guide \"Guide\")\n" +
+			"- or [the FAQ](/faq)\n" +
+			"- run `make <target>`\n\n" +
+			"```html\n<script>alert(1)</script>\n```\n\n" +
+			"    <b>indented code</b>\n"
+		g.Expect(validator(doc)).To(Succeed())
+	})
+
+	t.Run("accepts plain text that looks like markup", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(validation.IsSafeMarkdown()("2 * 3 = 6, a < b, snake_case_name, [not a link] and \\<b>")).To(Succeed())
+		g.Expect(validation.IsSafeMarkdown()("first\n\n---\n\nsecond")).To(Succeed())
+	})
+
+	t.Run("rejects raw HTML", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(validator("hello <img src=x onerror=alert(1)>")).To(MatchError("must not contain raw HTML"))
+		g.Expect(validator("</div>")).To(MatchError("must not contain raw HTML"))
+		g.Expect(validator("<!-- hidden -->")).To(MatchError("must not contain raw HTML"))
+	})
+
+	t.Run("rejects constructs not allowed", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(validator("# Title")).To(MatchError("must not contain headings"))
+		g.Expect(validator("Title\n=====")).To(MatchError("must not contain headings"))
+		g.Expect(validator("> quoted")).To(MatchError("must not contain block quotes"))
+		g.Expect(validation.IsSafeMarkdown()("1. first")).To(MatchError("must not contain lists"))
+		g.Expect(validation.IsSafeMarkdown()("some *emphasis*")).To(MatchError("must not contain emphasis"))
+		g.Expect(validation.IsSafeMarkdown()("intro\n\n    code")).To(MatchError("must not contain code"))
+	})
+
+	t.Run("rejects images unless allowed", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(validator("![pixel](https://example.com/p.gif)")).To(MatchError("must not contain images"))
+		g.Expect(validator("![pixel][ref]")).To(MatchError("must not contain images"))
+
+		images := validation.IsSafeMarkdown(validation.MarkdownImages("cdn.example.com"))
+		g.Expect(images("![logo](https://cdn.example.com/logo.png)")).To(Succeed())
+		g.Expect(images("![pixel](https://tracker.test/p.gif)")).To(MatchError(`must not contain images from "tracker.test"`))
+	})
+
+	t.Run("rejects links to hosts not allowed", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(validator("[click](https://evil.test/login)")).To(MatchError(`must not contain links to "evil.test"`))
+		g.Expect(validator("[click](//evil.test)")).To(MatchError(`must not contain links to "evil.test"`))
+		g.Expect(validator("[click](https://example.com.evil.test)")).To(MatchError(`must not contain links to "example.com.evil.test"`))
+		g.Expect(validator("<https://evil.test>")).To(MatchError(`must not contain links to "evil.test"`))
+		g.Expect(validator("[click][1]\n\n[1]: https://evil.test")).To(MatchError(`must not contain links to "evil.test"`))
+	})
+
+	t.Run("rejects links with other schemes", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(validator("[click](javascript:alert(1))")).To(MatchError("must not contain javascript: URLs"))
+		g.Expect(validator("[click](<JavaScript:alert(1)>)")).To(MatchError("must not contain javascript: URLs"))
+	})
+
+	t.Run("decodes escapes and entity references of destinations", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(validator("[x](https&#58;//evil.test)")).To(MatchError(`must not contain links to "evil.test"`))
+		g.Expect(validator("[x](https\\://evil.test)")).To(MatchError(`must not contain links to "evil.test"`))
+		g.Expect(validator("[x](javascript&#x3A;alert(1))")).To(MatchError("must not contain javascript: URLs"))
+		g.Expect(validator("[y][x]\n\n[x]: https&#58;//evil.test")).To(MatchError(`must not contain links to "evil.test"`))
+
+		images := validation.IsSafeMarkdown(validation.MarkdownImages("cdn.example.com"))
+		g.Expect(images("![x](https&#58;//tracker.evil.test/p.gif)")).To(MatchError(`must not contain images from "tracker.evil.test"`))
+		g.Expect(validator("[docs](/search?q=a&amp;b=c)")).To(Succeed())
+	})
+
+	t.Run("rejects links unless allowed", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(validation.IsSafeMarkdown()("[docs](/docs)")).To(MatchError("must not contain links"))
+	})
+
+	t.Run("finds links within other constructs", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(validator("- **[click](https://evil.test)**")).To(MatchError(`must not contain links to "evil.test"`))
+		g.Expect(validator("[multi\nline](https://evil.test)")).To(MatchError(`must not contain links to "evil.test"`))
+	})
+}
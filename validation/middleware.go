@@ -0,0 +1,26 @@
+package validation
+
+// Middleware decorates a validator, for cross-cutting concerns such as logging, metrics
+// or panic recovery.
+type Middleware[T any] func(next Validator[T]) Validator[T]
+
+// Wrap applies middlewares to a validator. The first middleware is the outermost one:
+// it runs first and sees the result of all the others.
+//
+// Example:
+//
+//	logged := func(next validation.Validator[string]) validation.Validator[string] {
+//	    return func(v string) error {
+//	        start := time.Now()
+//	        err := next(v)
+//	        slog.Debug("validated", "duration", time.Since(start), "error", err)
+//	        return err
+//	    }
+//	}
+//	validation.Validate(username, validation.Wrap(usernameAvailable, logged))
+func Wrap[T any](validator Validator[T], middlewares ...Middleware[T]) Validator[T] {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		validator = middlewares[i](validator)
+	}
+	return validator
+}
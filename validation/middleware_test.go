@@ -0,0 +1,44 @@
+package validation_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/quantumcycle/protego/validation"
+)
+
+func TestWrap(t *testing.T) {
+	record := func(calls *[]string, name string) validation.Middleware[string] {
+		return func(next validation.Validator[string]) validation.Validator[string] {
+			return func(v string) error {
+				*calls = append(*calls, name+" before")
+				err := next(v)
+				*calls = append(*calls, name+" after")
+				return err
+			}
+		}
+	}
+
+	t.Run("applies middlewares outermost first", func(t *testing.T) {
+		g := NewWithT(t)
+		var calls []string
+		validator := validation.Wrap(validation.MinLength(3), record(&calls, "outer"), record(&calls, "inner"))
+		err := validation.Validate("ab", validator)
+		g.Expect(err).To(MatchError("must be at least 3 characters"))
+		g.Expect(calls).To(Equal([]string{"outer before", "inner before", "inner after", "outer after"}))
+	})
+
+	t.Run("lets middlewares change the result", func(t *testing.T) {
+		g := NewWithT(t)
+		ignore := func(next validation.Validator[string]) validation.Validator[string] {
+			return func(string) error { return nil }
+		}
+		g.Expect(validation.Validate("ab", validation.Wrap(validation.MinLength(3), ignore))).To(Succeed())
+	})
+
+	t.Run("returns the validator unchanged without middlewares", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(validation.Validate("ab", validation.Wrap(validation.MinLength(3)))).To(HaveOccurred())
+	})
+}
@@ -0,0 +1,61 @@
+package validation_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/quantumcycle/protego/validation"
+)
+
+func TestIsCurrencyCode(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(validation.Validate("EUR", validation.IsCurrencyCode())).To(Succeed())
+	for _, v := range []string{"eur", "XXX", "EU", ""} {
+		g.Expect(validation.Validate(v, validation.IsCurrencyCode())).To(MatchError("must be a valid ISO 4217 currency code"), v)
+	}
+}
+
+func TestCurrencyMinorUnits(t *testing.T) {
+	g := NewWithT(t)
+	for currency, expected := range map[string]int{"USD": 2, "JPY": 0, "KWD": 3, "CLF": 4} {
+		units, ok := validation.CurrencyMinorUnits(currency)
+		g.Expect(ok).To(BeTrue())
+		g.Expect(units).To(Equal(expected), currency)
+	}
+	_, ok := validation.CurrencyMinorUnits("ABC")
+	g.Expect(ok).To(BeFalse())
+}
+
+func TestMoney(t *testing.T) {
+
+	t.Run("passes with amounts matching the currency", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(validation.Money("12.50", "USD")).To(Succeed())
+		g.Expect(validation.Money("12", "USD")).To(Succeed())
+		g.Expect(validation.Money("1500", "JPY")).To(Succeed())
+		g.Expect(validation.Money("0.125", "KWD")).To(Succeed())
+	})
+
+	t.Run("fails with too many decimal places", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(validation.Money("12.505", "USD")).To(MatchError("amount: must have at most 2 decimal places for USD"))
+		g.Expect(validation.Money("1500.5", "JPY")).To(MatchError("amount: must not have decimal places for JPY"))
+	})
+
+	t.Run("fails with non-positive or malformed amounts", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(validation.Money("0.00", "USD")).To(MatchError("amount: must be positive"))
+		g.Expect(validation.Money("-5", "USD")).To(MatchError("amount: must be positive"))
+		for _, amount := range []string{"", "12.", ".5", "1,000", "1e3", "12.3.4"} {
+			g.Expect(validation.Money(amount, "USD")).To(MatchError("amount: must be a valid decimal amount"), amount)
+		}
+	})
+
+	t.Run("fails with unknown currencies", func(t *testing.T) {
+		g := NewWithT(t)
+		err := validation.Money("12.50", "usd")
+		g.Expect(err).To(MatchError("currency: must be a valid ISO 4217 currency code"))
+		g.Expect(validation.IsValidationError(err)).To(BeTrue())
+	})
+}
@@ -0,0 +1,97 @@
+package validation
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// HumanNameOptions configures IsHumanName.
+type HumanNameOptions struct {
+	// MinLength and MaxLength bound the length of the name in characters (inclusive). A zero
+	// MaxLength means no maximum.
+	MinLength, MaxLength int
+	// MaxWords is the maximum number of words separated by spaces. Zero means no maximum.
+	MaxWords int
+	// AllowPeriods accepts periods after letters, for initials and abbreviations such as
+	// "J. R. R. Tolkien" or "Martin Luther King Jr.".
+	AllowPeriods bool
+}
+
+// IsHumanName validates personal names in any script: letters and combining marks, such as
+// the vowel signs of Devanagari or the harakat of Arabic, words separated by single spaces,
+// hyphens, apostrophes and middle dots, and the zero-width joiners of Persian or Indic
+// names. ASCII patterns reject legitimate names such as "José", "Nguyễn", "محمد", "李小龍"
+// or "O’Brien-Smith"; this validator does not. Names must start with a letter, and
+// separators cannot follow each other.
+//
+// Example:
+//
+//	validation.Validate(input.FullName, validation.IsHumanName(validation.HumanNameOptions{MinLength: 1, MaxLength: 100, MaxWords: 6}))
+func IsHumanName(opts HumanNameOptions) Validator[string] {
+	return func(v string) error {
+		length := utf8.RuneCountInString(v)
+		if length < opts.MinLength {
+			return NewValidationError(fmt.Sprintf("must be at least %d characters", opts.MinLength))
+		}
+		if opts.MaxLength > 0 && length > opts.MaxLength {
+			return NewValidationError(fmt.Sprintf("must be at most %d characters", opts.MaxLength))
+		}
+		if v != "" && !isHumanName(v, opts.AllowPeriods) {
+			return NewValidationError("must be a valid name")
+		}
+		if opts.MaxWords > 0 && len(strings.Fields(v)) > opts.MaxWords {
+			return NewValidationError(fmt.Sprintf("must not have more than %d words", opts.MaxWords))
+		}
+		return nil
+	}
+}
+
+func isHumanName(v string, allowPeriods bool) bool {
+	afterLetter, afterPeriod := false, false
+	for _, r := range v {
+		switch {
+		case unicode.IsLetter(r):
+			if afterPeriod {
+				return false // "J.R." needs a space
+			}
+			afterLetter = true
+			continue
+		case unicode.IsMark(r) || r == '\u200c' || r == '\u200d':
+			// marks and joiners only attach to letters
+			if !afterLetter {
+				return false
+			}
+			continue
+		case r == '.' && allowPeriods:
+			if !afterLetter {
+				return false
+			}
+			afterLetter, afterPeriod = false, true
+			continue
+		case r == ' ':
+			if !afterLetter && !afterPeriod {
+				return false
+			}
+		case isNameSeparator(r):
+			if !afterLetter {
+				return false
+			}
+		default:
+			return false
+		}
+		afterLetter, afterPeriod = false, false
+	}
+	return afterLetter || afterPeriod
+}
+
+// isNameSeparator reports whether r joins the parts of a name: hyphens, apostrophes and the
+// middle dots of Catalan names and Japanese transliterations.
+func isNameSeparator(r rune) bool {
+	switch r {
+	case '-', '\u2010', '\'', '’', 'ʼ', '·', '・':
+		return true
+	}
+	return false
+}
@@ -0,0 +1,53 @@
+package validation_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/quantumcycle/protego/validation"
+)
+
+func TestIsHumanName(t *testing.T) {
+	isName := validation.IsHumanName(validation.HumanNameOptions{MinLength: 1, MaxLength: 50})
+
+	t.Run("accepts names in any script", func(t *testing.T) {
+		g := NewWithT(t)
+		for _, name := range []string{
+			"Ada Lovelace", "José María", "Nguyễn Thị Minh Khai", "O’Brien-Smith", "D'Angelo",
+			"محمد بن سلمان", "مُحَمَّد", "李小龍", "やまだ・たろう", "Ελευθέριος", "Сергей Прокофьев",
+			"देवनागरी", "Paul·la", "مهرنوش\u200cسادات", "élodie",
+		} {
+			g.Expect(isName(name)).To(Succeed(), name)
+		}
+	})
+
+	t.Run("rejects other characters and misplaced separators", func(t *testing.T) {
+		g := NewWithT(t)
+		for _, name := range []string{
+			"R2D2", "alice@example.com", "<script>", "Ada  Lovelace", " Ada", "Ada ", "-Ada", "Ada-",
+			"Ada--Lovelace", "O' Brien", "\u0301Ada", "J. R. R. Tolkien", "Ada 😀",
+		} {
+			g.Expect(isName(name)).To(MatchError("must be a valid name"), name)
+		}
+	})
+
+	t.Run("accepts periods when allowed", func(t *testing.T) {
+		g := NewWithT(t)
+		withPeriods := validation.IsHumanName(validation.HumanNameOptions{AllowPeriods: true})
+		g.Expect(withPeriods("J. R. R. Tolkien")).To(Succeed())
+		g.Expect(withPeriods("Martin Luther King Jr.")).To(Succeed())
+		g.Expect(withPeriods("J.R.")).To(MatchError("must be a valid name"))
+		g.Expect(withPeriods(". Ada")).To(MatchError("must be a valid name"))
+	})
+
+	t.Run("bounds length and words", func(t *testing.T) {
+		g := NewWithT(t)
+		bounded := validation.IsHumanName(validation.HumanNameOptions{MinLength: 2, MaxLength: 10, MaxWords: 2})
+		g.Expect(bounded("李")).To(MatchError("must be at least 2 characters"))
+		g.Expect(bounded("李小龍")).To(Succeed())
+		g.Expect(bounded("Bartholomew Simpson")).To(MatchError("must be at most 10 characters"))
+		g.Expect(bounded("Ana Bo Cy")).To(MatchError("must not have more than 2 words"))
+		g.Expect(isName("")).To(MatchError("must be at least 1 characters"))
+	})
+}
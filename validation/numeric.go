@@ -2,6 +2,7 @@ package validation
 
 import (
 	"fmt"
+	"math"
 
 	"golang.org/x/exp/constraints"
 )
@@ -15,7 +16,7 @@ import (
 func Min[T constraints.Ordered](minimum T) Validator[T] {
 	return func(v T) error {
 		if v < minimum {
-			return NewValidationError(fmt.Sprintf("must be at least %v", minimum))
+			return NewCodedError(CodeMin, fmt.Sprintf("must be at least %v", minimum), map[string]any{"min": minimum})
 		}
 		return nil
 	}
@@ -30,7 +31,7 @@ func Min[T constraints.Ordered](minimum T) Validator[T] {
 func Max[T constraints.Ordered](maximum T) Validator[T] {
 	return func(v T) error {
 		if v > maximum {
-			return NewValidationError(fmt.Sprintf("must be at most %v", maximum))
+			return NewCodedError(CodeMax, fmt.Sprintf("must be at most %v", maximum), map[string]any{"max": maximum})
 		}
 		return nil
 	}
@@ -45,7 +46,8 @@ func Max[T constraints.Ordered](maximum T) Validator[T] {
 func Range[T constraints.Ordered](minimum, maximum T) Validator[T] {
 	return func(v T) error {
 		if v < minimum || v > maximum {
-			return NewValidationError(fmt.Sprintf("must be between %v and %v", minimum, maximum))
+			return NewCodedError(CodeRange, fmt.Sprintf("must be between %v and %v", minimum, maximum),
+				map[string]any{"min": minimum, "max": maximum})
 		}
 		return nil
 	}
@@ -59,7 +61,7 @@ func Range[T constraints.Ordered](minimum, maximum T) Validator[T] {
 func GreaterThan[T constraints.Ordered](threshold T) Validator[T] {
 	return func(v T) error {
 		if v <= threshold {
-			return NewValidationError(fmt.Sprintf("must be greater than %v", threshold))
+			return NewCodedError(CodeGreaterThan, fmt.Sprintf("must be greater than %v", threshold), map[string]any{"threshold": threshold})
 		}
 		return nil
 	}
@@ -73,7 +75,7 @@ func GreaterThan[T constraints.Ordered](threshold T) Validator[T] {
 func LessThan[T constraints.Ordered](threshold T) Validator[T] {
 	return func(v T) error {
 		if v >= threshold {
-			return NewValidationError(fmt.Sprintf("must be less than %v", threshold))
+			return NewCodedError(CodeLessThan, fmt.Sprintf("must be less than %v", threshold), map[string]any{"threshold": threshold})
 		}
 		return nil
 	}
@@ -138,3 +140,46 @@ func MultipleOf[T constraints.Integer](divisor T) Validator[T] {
 		return nil
 	}
 }
+
+// NoNaN validates that a floating-point value is not NaN. NaN compares false with every
+// number, so it would otherwise pass Min, Max and Range.
+//
+// Example:
+//
+//	validation.Validate(ratio, validation.NoNaN[float64](), validation.Range(0.0, 1.0))
+func NoNaN[T constraints.Float]() Validator[T] {
+	return func(v T) error {
+		if math.IsNaN(float64(v)) {
+			return NewValidationError("must be a number")
+		}
+		return nil
+	}
+}
+
+// NoInf validates that a floating-point value is not positive or negative infinity.
+//
+// Example:
+//
+//	validation.Validate(amount, validation.NoInf[float64]())
+func NoInf[T constraints.Float]() Validator[T] {
+	return func(v T) error {
+		if math.IsInf(float64(v), 0) {
+			return NewValidationError("must be finite")
+		}
+		return nil
+	}
+}
+
+// FiniteFloat validates that a floating-point value is neither NaN nor infinite.
+//
+// Example:
+//
+//	validation.Validate(price, validation.FiniteFloat[float64](), validation.Min(0.0))
+func FiniteFloat[T constraints.Float]() Validator[T] {
+	return func(v T) error {
+		if math.IsNaN(float64(v)) || math.IsInf(float64(v), 0) {
+			return NewValidationError("must be a finite number")
+		}
+		return nil
+	}
+}
@@ -88,3 +88,22 @@ func OptionalWith[T any](validators ...Validator[T]) Validator[*T] {
 		return Validate(*v, validators...)
 	}
 }
+
+// WithDefault sets *value to defaultValue when it holds the zero value, then applies the
+// validators to the result. Defaults are not exempt from validation.
+//
+// Because it updates the value, call it from a pointer receiver or before validation:
+//
+//	func (input *ListOrdersInput) Validate() error {
+//	    return errors.Join(
+//	        validation.WithDefault(&input.PageSize, 20, validation.Range(1, 100)),
+//	        validation.WithDefault(&input.Sort, "created_at", validation.In(false, "created_at", "total")),
+//	    )
+//	}
+func WithDefault[T comparable](value *T, defaultValue T, validators ...Validator[T]) error {
+	var zero T
+	if *value == zero {
+		*value = defaultValue
+	}
+	return Validate(*value, validators...)
+}
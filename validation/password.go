@@ -0,0 +1,169 @@
+package validation
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// PasswordRequirement identifies a requirement of a PasswordPolicy. It is the code of the
+// errors of PasswordPolicy.Validator (see Error.Code).
+type PasswordRequirement string
+
+// Requirements of a PasswordPolicy.
+const (
+	PasswordMinLength       PasswordRequirement = "min_length"
+	PasswordMaxLength       PasswordRequirement = "max_length"
+	PasswordLowercase       PasswordRequirement = "lowercase"
+	PasswordUppercase       PasswordRequirement = "uppercase"
+	PasswordDigit           PasswordRequirement = "digit"
+	PasswordSymbol          PasswordRequirement = "symbol"
+	PasswordBannedSubstring PasswordRequirement = "banned_substring"
+	PasswordCommon          PasswordRequirement = "common"
+	PasswordHistory         PasswordRequirement = "history"
+)
+
+// PasswordPolicy is a set of password requirements, evaluated all at once so that sign-up
+// and password change forms can render them as a checklist. Zero fields are requirements
+// not enforced.
+//
+// Example:
+//
+//	policy := validation.PasswordPolicy{
+//	    MinLength:        12,
+//	    RequireDigit:     true,
+//	    RequireSymbol:    true,
+//	    BannedSubstrings: []string{user.Username, emailLocalPart},
+//	    RejectCommon:     true,
+//	    History:          users.PasswordPreviouslyUsed(user.ID),
+//	}
+type PasswordPolicy struct {
+	// MinLength and MaxLength bound the length of passwords in characters (inclusive).
+	MinLength, MaxLength int
+	// RequireLowercase, RequireUppercase, RequireDigit and RequireSymbol require a character
+	// of each class, in any script: "é" is a lowercase letter, "€" a symbol.
+	RequireLowercase, RequireUppercase, RequireDigit, RequireSymbol bool
+	// BannedSubstrings are strings passwords must not contain, ignoring case, typically the
+	// username and the local part of the email address of the user. Strings shorter than 3
+	// characters are ignored, as they would reject most passwords.
+	BannedSubstrings []string
+	// RejectCommon rejects frequently leaked passwords, like NotCommonPassword.
+	RejectCommon bool
+	// History reports whether the user used the password before, such as by comparing it
+	// with their previous password hashes. Its errors are returned as is by Evaluate and the
+	// validator: they are system errors (see IsSystemError).
+	History func(ctx context.Context, password string) (reused bool, err error)
+}
+
+// PasswordCheck is the outcome of a requirement of a PasswordPolicy.
+type PasswordCheck struct {
+	Requirement PasswordRequirement `json:"requirement"`
+	// Message describes the requirement, such as "must be at least 12 characters", for
+	// checklists and for the errors of the requirement.
+	Message string `json:"message"`
+	Passed  bool   `json:"passed"`
+}
+
+// PasswordResult lists the outcome of every requirement of a PasswordPolicy for a
+// password, in the order of the fields of PasswordPolicy.
+type PasswordResult struct {
+	Valid  bool            `json:"valid"`
+	Checks []PasswordCheck `json:"checks"`
+}
+
+// Failures returns the checks of the requirements the password does not meet.
+func (r PasswordResult) Failures() []PasswordCheck {
+	var failures []PasswordCheck
+	for _, c := range r.Checks {
+		if !c.Passed {
+			failures = append(failures, c)
+		}
+	}
+	return failures
+}
+
+// Evaluate checks password against every requirement of the policy, returning the outcome
+// of each one. The error is only set when History fails.
+func (p PasswordPolicy) Evaluate(ctx context.Context, password string) (PasswordResult, error) {
+	result := PasswordResult{Valid: true, Checks: []PasswordCheck{}}
+	check := func(requirement PasswordRequirement, msg string, passed bool) {
+		result.Checks = append(result.Checks, PasswordCheck{Requirement: requirement, Message: msg, Passed: passed})
+		result.Valid = result.Valid && passed
+	}
+
+	length := utf8.RuneCountInString(password)
+	if p.MinLength > 0 {
+		check(PasswordMinLength, fmt.Sprintf("must be at least %d characters", p.MinLength), length >= p.MinLength)
+	}
+	if p.MaxLength > 0 {
+		check(PasswordMaxLength, fmt.Sprintf("must be at most %d characters", p.MaxLength), length <= p.MaxLength)
+	}
+	for _, class := range []struct {
+		required    bool
+		requirement PasswordRequirement
+		msg         string
+		is          func(rune) bool
+	}{
+		{p.RequireLowercase, PasswordLowercase, "must contain a lowercase letter", unicode.IsLower},
+		{p.RequireUppercase, PasswordUppercase, "must contain an uppercase letter", unicode.IsUpper},
+		{p.RequireDigit, PasswordDigit, "must contain a digit", unicode.IsDigit},
+		{p.RequireSymbol, PasswordSymbol, "must contain a symbol", isPasswordSymbol},
+	} {
+		if class.required {
+			check(class.requirement, class.msg, strings.IndexFunc(password, class.is) >= 0)
+		}
+	}
+	if len(p.BannedSubstrings) > 0 {
+		check(PasswordBannedSubstring, "must not contain your personal information", !containsBanned(password, p.BannedSubstrings))
+	}
+	if p.RejectCommon {
+		_, common := commonPasswords[strings.ToLower(password)]
+		check(PasswordCommon, "must not be a commonly used password", !common)
+	}
+	if p.History != nil {
+		reused, err := p.History(ctx, password)
+		if err != nil {
+			return PasswordResult{}, err
+		}
+		check(PasswordHistory, "must not be a password you used before", !reused)
+	}
+	return result, nil
+}
+
+// Validator returns a validator enforcing the policy. Its errors never include the
+// password; each failed requirement is reported, coded with its PasswordRequirement.
+//
+// Example:
+//
+//	err := validation.ValidateCtx(ctx, input.NewPassword, policy.Validator())
+func (p PasswordPolicy) Validator() ValidatorCtx[string] {
+	return func(ctx context.Context, v string) error {
+		result, err := p.Evaluate(ctx, v)
+		if err != nil {
+			return err
+		}
+		report := NewReport()
+		for _, c := range result.Failures() {
+			report.Add(&Error{msg: c.Message, code: string(c.Requirement), sensitive: true})
+		}
+		return collect(report)
+	}
+}
+
+func isPasswordSymbol(r rune) bool {
+	return unicode.IsPunct(r) || unicode.IsSymbol(r)
+}
+
+// containsBanned reports whether password contains one of banned, ignoring case and
+// strings shorter than 3 characters.
+func containsBanned(password string, banned []string) bool {
+	folded := strings.ToLower(password)
+	for _, s := range banned {
+		if utf8.RuneCountInString(s) >= 3 && strings.Contains(folded, strings.ToLower(s)) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,117 @@
+package validation_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/quantumcycle/protego/validation"
+)
+
+func TestPasswordPolicy(t *testing.T) {
+	history := func(_ context.Context, password string) (bool, error) {
+		if password == "outage" {
+			return false, errors.New("database unavailable")
+		}
+		return password == "Previous-passw0rd", nil
+	}
+	policy := validation.PasswordPolicy{
+		MinLength:        12,
+		MaxLength:        64,
+		RequireLowercase: true,
+		RequireUppercase: true,
+		RequireDigit:     true,
+		RequireSymbol:    true,
+		BannedSubstrings: []string{"JaneDoe", "jd", ""},
+		RejectCommon:     true,
+		History:          history,
+	}
+	ctx := context.Background()
+
+	t.Run("lists every requirement", func(t *testing.T) {
+		g := NewWithT(t)
+		result, err := policy.Evaluate(ctx, "Correct-h0rse-battery")
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(result.Valid).To(BeTrue())
+		g.Expect(result.Failures()).To(BeEmpty())
+		g.Expect(result.Checks).To(Equal([]validation.PasswordCheck{
+			{Requirement: validation.PasswordMinLength, Message: "must be at least 12 characters", Passed: true},
+			{Requirement: validation.PasswordMaxLength, Message: "must be at most 64 characters", Passed: true},
+			{Requirement: validation.PasswordLowercase, Message: "must contain a lowercase letter", Passed: true},
+			{Requirement: validation.PasswordUppercase, Message: "must contain an uppercase letter", Passed: true},
+			{Requirement: validation.PasswordDigit, Message: "must contain a digit", Passed: true},
+			{Requirement: validation.PasswordSymbol, Message: "must contain a symbol", Passed: true},
+			{Requirement: validation.PasswordBannedSubstring, Message: "must not contain your personal information", Passed: true},
+			{Requirement: validation.PasswordCommon, Message: "must not be a commonly used password", Passed: true},
+			{Requirement: validation.PasswordHistory, Message: "must not be a password you used before", Passed: true},
+		}))
+	})
+
+	t.Run("reports the requirements that failed", func(t *testing.T) {
+		g := NewWithT(t)
+		result, err := policy.Evaluate(ctx, "janedoe2024")
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(result.Valid).To(BeFalse())
+		var failed []validation.PasswordRequirement
+		for _, c := range result.Failures() {
+			failed = append(failed, c.Requirement)
+		}
+		g.Expect(failed).To(Equal([]validation.PasswordRequirement{
+			validation.PasswordMinLength, validation.PasswordUppercase, validation.PasswordSymbol, validation.PasswordBannedSubstring,
+		}))
+	})
+
+	t.Run("checks classes in any script", func(t *testing.T) {
+		g := NewWithT(t)
+		result, err := validation.PasswordPolicy{RequireLowercase: true, RequireUppercase: true, RequireDigit: true, RequireSymbol: true}.Evaluate(ctx, "ÉTÉ été ٣ €")
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(result.Valid).To(BeTrue())
+	})
+
+	t.Run("rejects common and reused passwords", func(t *testing.T) {
+		g := NewWithT(t)
+		result, err := validation.PasswordPolicy{RejectCommon: true, History: history}.Evaluate(ctx, "Password")
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(result.Failures()).To(HaveExactElements(HaveField("Requirement", validation.PasswordCommon)))
+
+		result, err = validation.PasswordPolicy{RejectCommon: true, History: history}.Evaluate(ctx, "Previous-passw0rd")
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(result.Failures()).To(HaveExactElements(HaveField("Requirement", validation.PasswordHistory)))
+	})
+
+	t.Run("returns history errors", func(t *testing.T) {
+		g := NewWithT(t)
+		_, err := policy.Evaluate(ctx, "outage")
+		g.Expect(err).To(MatchError("database unavailable"))
+
+		err = policy.Validator()(ctx, "outage")
+		g.Expect(validation.IsSystemError(err)).To(BeTrue())
+	})
+
+	t.Run("marshals to JSON for clients", func(t *testing.T) {
+		g := NewWithT(t)
+		result, _ := validation.PasswordPolicy{MinLength: 8}.Evaluate(ctx, "short")
+		data, err := json.Marshal(result)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(data).To(MatchJSON(`{"valid":false,"checks":[{"requirement":"min_length","message":"must be at least 8 characters","passed":false}]}`))
+	})
+
+	t.Run("validator reports coded errors without the password", func(t *testing.T) {
+		g := NewWithT(t)
+		validation.SetIncludeValues(true)
+		defer validation.SetIncludeValues(false)
+
+		err := validation.ValidateCtx(ctx, "janedoe-2024-PASSWORD", policy.Validator())
+		g.Expect(err).To(MatchError("must not contain your personal information"))
+		var valErr *validation.Error
+		g.Expect(errors.As(err, &valErr)).To(BeTrue())
+		g.Expect(valErr.Code()).To(Equal(string(validation.PasswordBannedSubstring)))
+
+		err = policy.Validator()(ctx, "short")
+		g.Expect(err).To(MatchError("must be at least 12 characters\nmust contain an uppercase letter\nmust contain a digit\nmust contain a symbol"))
+		g.Expect(policy.Validator()(ctx, "Correct-h0rse-battery")).To(Succeed())
+	})
+}
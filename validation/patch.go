@@ -0,0 +1,79 @@
+package validation
+
+import (
+	"fmt"
+	"slices"
+)
+
+// ValidatePatch validates a JSON Merge Patch (RFC 7386) of documents of the schema, for PATCH
+// endpoints. Unlike Validate, it distinguishes the three states of a key:
+//
+//   - absent, the key is left unchanged: required keys may be absent;
+//   - null, the key is removed: only Nullable keys may be null, and their rules are skipped;
+//   - any other value, the key is set: it is validated with the rules of the key, and
+//     rejected if the key is Immutable.
+//
+// Keys the schema does not define are rejected unless AllowExtra was called. Like Validate,
+// every invalid key is reported at once, at the strictness of the schema.
+//
+// Example:
+//
+//	var userSchema = validation.NewSchema(
+//	    validation.Key("id").Required().Immutable(),
+//	    validation.Key("name", validation.MinLengthRule(3)).Required(),
+//	    validation.Key("nickname", validation.MaxLengthRule(20)).Nullable(),
+//	)
+//
+//	err := userSchema.ValidatePatch(patch) // {"nickname": null} removes the nickname
+func (s *Schema) ValidatePatch(patch map[string]any) error {
+	if s.budget != (Budget{}) {
+		if err := s.checkBudget(patch); err != nil {
+			return err
+		}
+	}
+
+	report := NewReport()
+	for _, k := range s.keys {
+		value, exists := patch[k.name]
+		switch {
+		case !exists:
+			continue
+		case k.immutable:
+			report.Add(NewValidationError(fmt.Sprintf("key %q cannot be changed", k.name)))
+			continue
+		case value == nil:
+			if !k.nullable {
+				report.Add(NewValidationError(fmt.Sprintf("key %q cannot be null", k.name)))
+			}
+			continue
+		}
+		if s.strictness == Lenient {
+			value = s.strictness.normalize(value)
+			patch[k.name] = value
+		}
+		for _, r := range s.strictness.rules(k) {
+			if err := r.validator(value); err != nil {
+				if IsSystemError(err) {
+					report.Release()
+					return keyError(k.name, err)
+				}
+				report.Add(keyError(k.name, err))
+				break
+			}
+		}
+	}
+
+	if !s.acceptsExtra() {
+		var extra []string
+		for key := range patch {
+			if _, ok := s.key(key); !ok {
+				extra = append(extra, key)
+			}
+		}
+		slices.Sort(extra)
+		for _, key := range extra {
+			report.Add(unexpectedKey(key, s.keyNames(), patch))
+		}
+	}
+	return collect(report)
+}
@@ -0,0 +1,69 @@
+package validation_test
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/quantumcycle/protego/validation"
+)
+
+func TestValidatePatch(t *testing.T) {
+	schema := validation.NewSchema(
+		validation.Key("id").Required().Immutable(),
+		validation.Key("name", validation.MinLengthRule(3)).Required(),
+		validation.Key("nickname", validation.MaxLengthRule(5)).Nullable(),
+	)
+
+	t.Run("accepts absent keys, even required", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(schema.ValidatePatch(map[string]any{})).To(Succeed())
+		g.Expect(schema.ValidatePatch(map[string]any{"nickname": "bo"})).To(Succeed())
+	})
+
+	t.Run("validates set keys", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(schema.ValidatePatch(map[string]any{"name": "alice"})).To(Succeed())
+		g.Expect(schema.ValidatePatch(map[string]any{"name": "al"})).To(MatchError(`key "name": must be at least 3 characters`))
+	})
+
+	t.Run("accepts null only for nullable keys", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(schema.ValidatePatch(map[string]any{"nickname": nil})).To(Succeed())
+		err := schema.ValidatePatch(map[string]any{"name": nil})
+		g.Expect(err).To(MatchError(`key "name" cannot be null`))
+		g.Expect(validation.IsValidationError(err)).To(BeTrue())
+	})
+
+	t.Run("rejects changes of immutable keys", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(schema.ValidatePatch(map[string]any{"id": "u-2"})).To(MatchError(`key "id" cannot be changed`))
+		g.Expect(schema.ValidatePatch(map[string]any{"id": nil})).To(MatchError(`key "id" cannot be changed`))
+	})
+
+	t.Run("reports every invalid key", func(t *testing.T) {
+		g := NewWithT(t)
+		err := schema.ValidatePatch(map[string]any{"id": "u-2", "name": nil, "nickname": "robert", "role": "admin"})
+		g.Expect(err).To(MatchError("key \"id\" cannot be changed\n" +
+			"key \"name\" cannot be null\n" +
+			"key \"nickname\": must be at most 5 characters\n" +
+			"key \"role\" not expected"))
+		g.Expect(schema.AllowExtra().ValidatePatch(map[string]any{"role": "admin"})).To(Succeed())
+	})
+
+	t.Run("returns system errors", func(t *testing.T) {
+		g := NewWithT(t)
+		outage := errors.New("connection refused")
+		schema := validation.NewSchema(validation.Key("email", validation.NewRule("unique", nil, func(any) error { return outage })))
+		err := schema.ValidatePatch(map[string]any{"email": "a@b.c"})
+		g.Expect(err).To(MatchError(`key "email": connection refused`))
+		g.Expect(validation.IsSystemError(err)).To(BeTrue())
+	})
+
+	t.Run("skips the rules of null values of nullable keys on Validate", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(schema.Validate(map[string]any{"id": "u-1", "name": "alice", "nickname": nil})).To(Succeed())
+		g.Expect(schema.Validate(map[string]any{"id": "u-1", "name": nil})).To(HaveOccurred())
+	})
+}
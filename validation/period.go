@@ -0,0 +1,150 @@
+package validation
+
+import (
+	"strconv"
+	"time"
+)
+
+// Period validators accept the calendar period notations commonly used by analytics and
+// reporting APIs: ISO weeks ("2024-W05"), months ("2024-07") and quarters ("2024-Q3").
+
+// IsISOWeek validates that a string is an ISO 8601 week in YYYY-Www format, such as
+// "2024-W05". Week 53 is only accepted for years that have 53 ISO weeks.
+//
+// Example:
+//
+//	validation.Validate(query.Get("week"), validation.IsISOWeek())
+func IsISOWeek() Validator[string] {
+	return func(v string) error {
+		if _, ok := parseISOWeek(v); !ok {
+			return NewValidationError("must be an ISO week (YYYY-Www)")
+		}
+		return nil
+	}
+}
+
+// IsYearMonth validates that a string is a month in YYYY-MM format, such as "2024-07".
+//
+// Example:
+//
+//	validation.Validate(query.Get("month"), validation.IsYearMonth())
+func IsYearMonth() Validator[string] {
+	return func(v string) error {
+		if _, ok := parseYearMonth(v); !ok {
+			return NewValidationError("must be a month (YYYY-MM)")
+		}
+		return nil
+	}
+}
+
+// IsQuarter validates that a string is a quarter in YYYY-Qn format, such as "2024-Q3".
+//
+// Example:
+//
+//	validation.Validate(query.Get("quarter"), validation.IsQuarter())
+func IsQuarter() Validator[string] {
+	return func(v string) error {
+		if _, ok := parseQuarter(v); !ok {
+			return NewValidationError("must be a quarter (YYYY-Qn)")
+		}
+		return nil
+	}
+}
+
+// PeriodsOrdered is PairOrdered for period strings in any of the formats accepted by
+// IsISOWeek, IsYearMonth and IsQuarter, compared by their first day. Periods of different
+// kinds can be mixed, e.g. "2024-Q1" is before "2024-05". An invalid period is reported on
+// its own field, "from" or "to".
+//
+// Example:
+//
+//	err := validation.PeriodsOrdered(query.Get("from"), query.Get("to"), true)
+func PeriodsOrdered(from, to string, inclusive bool) error {
+	start, ok := parsePeriod(from)
+	if !ok {
+		return NewFieldError("from", NewValidationError("must be a valid period"))
+	}
+	end, ok := parsePeriod(to)
+	if !ok {
+		return NewFieldError("to", NewValidationError("must be a valid period"))
+	}
+	return pairOrdered(start.Before(end), start.Equal(end), inclusive)
+}
+
+// parsePeriod returns the first day of a week, month or quarter period.
+func parsePeriod(s string) (time.Time, bool) {
+	if t, ok := parseISOWeek(s); ok {
+		return t, true
+	}
+	if t, ok := parseYearMonth(s); ok {
+		return t, true
+	}
+	return parseQuarter(s)
+}
+
+// parseISOWeek returns the Monday starting an ISO week.
+func parseISOWeek(s string) (time.Time, bool) {
+	year, ok := periodYear(s)
+	if !ok || len(s) != 8 || s[4:6] != "-W" {
+		return time.Time{}, false
+	}
+	week, ok := periodNumber(s[6:], 1, 53)
+	if !ok {
+		return time.Time{}, false
+	}
+	// January 4th is always in week 1, and December 28th in the last week of the year.
+	if _, last := time.Date(year, 12, 28, 0, 0, 0, 0, time.UTC).ISOWeek(); week > last {
+		return time.Time{}, false
+	}
+	jan4 := time.Date(year, 1, 4, 0, 0, 0, 0, time.UTC)
+	monday := jan4.AddDate(0, 0, -(int(jan4.Weekday())+6)%7)
+	return monday.AddDate(0, 0, 7*(week-1)), true
+}
+
+// parseYearMonth returns the first day of a YYYY-MM month.
+func parseYearMonth(s string) (time.Time, bool) {
+	year, ok := periodYear(s)
+	if !ok || len(s) != 7 || s[4] != '-' {
+		return time.Time{}, false
+	}
+	month, ok := periodNumber(s[5:], 1, 12)
+	if !ok {
+		return time.Time{}, false
+	}
+	return time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC), true
+}
+
+// parseQuarter returns the first day of a YYYY-Qn quarter.
+func parseQuarter(s string) (time.Time, bool) {
+	year, ok := periodYear(s)
+	if !ok || len(s) != 7 || s[4:6] != "-Q" {
+		return time.Time{}, false
+	}
+	quarter, ok := periodNumber(s[6:], 1, 4)
+	if !ok {
+		return time.Time{}, false
+	}
+	return time.Date(year, time.Month(3*quarter-2), 1, 0, 0, 0, 0, time.UTC), true
+}
+
+// periodYear parses the 4-digit year starting a period.
+func periodYear(s string) (int, bool) {
+	if len(s) < 4 {
+		return 0, false
+	}
+	return periodNumber(s[:4], 0, 9999)
+}
+
+// periodNumber parses a number made only of digits, within [lo, hi].
+func periodNumber(s string, lo, hi int) (int, bool) {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return 0, false
+		}
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil || n < lo || n > hi {
+		return 0, false
+	}
+	return n, true
+}
@@ -0,0 +1,69 @@
+package validation_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/quantumcycle/protego/validation"
+)
+
+func TestIsISOWeek(t *testing.T) {
+	t.Run("passes valid weeks", func(t *testing.T) {
+		g := NewWithT(t)
+		for _, v := range []string{"2024-W01", "2024-W05", "2020-W53", "2026-W53"} {
+			g.Expect(validation.Validate(v, validation.IsISOWeek())).To(Succeed(), v)
+		}
+	})
+
+	t.Run("rejects invalid weeks", func(t *testing.T) {
+		g := NewWithT(t)
+		for _, v := range []string{"2024-W00", "2024-W53", "2024-W5", "2024W05", "2024-w05", "24-W05", "2024-W+5"} {
+			g.Expect(validation.Validate(v, validation.IsISOWeek())).To(MatchError("must be an ISO week (YYYY-Www)"), v)
+		}
+	})
+}
+
+func TestIsYearMonth(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(validation.Validate("2024-07", validation.IsYearMonth())).To(Succeed())
+	g.Expect(validation.Validate("2024-12", validation.IsYearMonth())).To(Succeed())
+	for _, v := range []string{"2024-13", "2024-00", "2024-7", "2024/07", "2024-07-01"} {
+		g.Expect(validation.Validate(v, validation.IsYearMonth())).To(MatchError("must be a month (YYYY-MM)"), v)
+	}
+}
+
+func TestIsQuarter(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(validation.Validate("2024-Q1", validation.IsQuarter())).To(Succeed())
+	g.Expect(validation.Validate("2024-Q4", validation.IsQuarter())).To(Succeed())
+	for _, v := range []string{"2024-Q0", "2024-Q5", "2024-q3", "2024Q3", "2024-Q03"} {
+		g.Expect(validation.Validate(v, validation.IsQuarter())).To(MatchError("must be a quarter (YYYY-Qn)"), v)
+	}
+}
+
+func TestPeriodsOrdered(t *testing.T) {
+	t.Run("compares periods of the same kind", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(validation.PeriodsOrdered("2024-W05", "2024-W06", false)).To(Succeed())
+		g.Expect(validation.PeriodsOrdered("2023-12", "2024-01", false)).To(Succeed())
+		g.Expect(validation.PeriodsOrdered("2024-Q3", "2024-Q3", true)).To(Succeed())
+		g.Expect(validation.PeriodsOrdered("2024-Q3", "2024-Q3", false)).To(MatchError("from: must be before to"))
+		g.Expect(validation.PeriodsOrdered("2024-Q3", "2024-Q2", true)).To(MatchError("from: must not be after to"))
+	})
+
+	t.Run("compares periods of different kinds by their first day", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(validation.PeriodsOrdered("2024-Q1", "2024-05", false)).To(Succeed())
+		g.Expect(validation.PeriodsOrdered("2024-Q2", "2024-04", true)).To(Succeed())
+		// 2025-W01 starts on Monday 2024-12-30
+		g.Expect(validation.PeriodsOrdered("2024-12", "2025-W01", false)).To(Succeed())
+		g.Expect(validation.PeriodsOrdered("2025-W01", "2025-01", false)).To(Succeed())
+	})
+
+	t.Run("reports invalid periods on their field", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(validation.PeriodsOrdered("2024-Q5", "2024-Q1", false)).To(MatchError("from: must be a valid period"))
+		g.Expect(validation.PeriodsOrdered("2024-Q1", "last year", false)).To(MatchError("to: must be a valid period"))
+	})
+}
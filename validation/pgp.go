@@ -0,0 +1,116 @@
+package validation
+
+import (
+	"encoding/base64"
+	"strings"
+)
+
+// IsArmoredPGPKey validates that a string is a single ASCII-armored OpenPGP public key
+// block (RFC 4880), such as a key uploaded for encrypted security reports. The armor
+// framing, the base64 payload and its CRC-24 checksum, when present, are verified, and the
+// payload must start with a public key packet. The key itself is not parsed, so keys with
+// unsupported algorithms are accepted.
+//
+// Example:
+//
+//	validation.Validate(input.PGPKey, validation.IsArmoredPGPKey())
+func IsArmoredPGPKey() Validator[string] {
+	return armoredPGP("PGP PUBLIC KEY BLOCK", pgpPublicKeyPacket, "must be an ASCII-armored PGP public key")
+}
+
+// IsArmoredSignature validates that a string is a single ASCII-armored OpenPGP detached
+// signature (RFC 4880), verified like IsArmoredPGPKey. The signature is not verified
+// against any key.
+//
+// Example:
+//
+//	validation.Validate(release.Signature, validation.IsArmoredSignature())
+func IsArmoredSignature() Validator[string] {
+	return armoredPGP("PGP SIGNATURE", pgpSignaturePacket, "must be an ASCII-armored PGP signature")
+}
+
+// OpenPGP packet tags (RFC 4880, section 4.3).
+const (
+	pgpSignaturePacket = 2
+	pgpPublicKeyPacket = 6
+)
+
+func armoredPGP(blockType string, packetTag byte, msg string) Validator[string] {
+	return func(v string) error {
+		payload, checksum, ok := decodeArmor(v, blockType)
+		if !ok || len(payload) == 0 || pgpPacketTag(payload[0]) != packetTag {
+			return NewValidationError(msg)
+		}
+		if checksum >= 0 && crc24(payload) != uint32(checksum) {
+			return NewValidationError("must have a valid armor checksum")
+		}
+		return nil
+	}
+}
+
+// decodeArmor decodes an armored block of blockType, returning its payload and checksum, or
+// -1 when it has none.
+func decodeArmor(v, blockType string) (payload []byte, checksum int, ok bool) {
+	lines := strings.Split(strings.TrimSpace(strings.ReplaceAll(v, "\r\n", "\n")), "\n")
+	if len(lines) < 3 || lines[0] != "-----BEGIN "+blockType+"-----" || lines[len(lines)-1] != "-----END "+blockType+"-----" {
+		return nil, 0, false
+	}
+	lines = lines[1 : len(lines)-1]
+
+	// armor headers, such as "Version: ...", end with a blank line
+	for len(lines) > 0 && strings.TrimSpace(lines[0]) != "" {
+		if _, _, found := strings.Cut(lines[0], ": "); !found {
+			return nil, 0, false
+		}
+		lines = lines[1:]
+	}
+	if len(lines) == 0 {
+		return nil, 0, false
+	}
+	lines = lines[1:]
+
+	checksum = -1
+	if n := len(lines); n > 0 && strings.HasPrefix(lines[n-1], "=") {
+		sum, err := base64.StdEncoding.DecodeString(strings.TrimSpace(lines[n-1][1:]))
+		if err != nil || len(sum) != 3 {
+			return nil, 0, false
+		}
+		checksum = int(sum[0])<<16 | int(sum[1])<<8 | int(sum[2])
+		lines = lines[:n-1]
+	}
+	var body strings.Builder
+	for _, line := range lines {
+		body.WriteString(strings.TrimSpace(line))
+	}
+	payload, err := base64.StdEncoding.DecodeString(body.String())
+	if err != nil {
+		return nil, 0, false
+	}
+	return payload, checksum, true
+}
+
+// pgpPacketTag returns the tag of a packet from its first byte, in the old or new format.
+func pgpPacketTag(b byte) byte {
+	switch {
+	case b&0x80 == 0:
+		return 0 // not a packet
+	case b&0x40 != 0:
+		return b & 0x3f
+	}
+	return (b >> 2) & 0x0f
+}
+
+// crc24 computes the checksum of armored payloads (RFC 4880, section 6.1).
+func crc24(data []byte) uint32 {
+	crc := uint32(0xb704ce)
+	for _, b := range data {
+		crc ^= uint32(b) << 16
+		for i := 0; i < 8; i++ {
+			crc <<= 1
+			if crc&0x1000000 != 0 {
+				crc ^= 0x1864cfb
+			}
+		}
+	}
+	return crc & 0xffffff
+}
@@ -0,0 +1,95 @@
+package validation_test
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/quantumcycle/protego/validation"
+)
+
+// armor encodes payload as an armored block of blockType, with its checksum.
+func armor(blockType string, payload []byte) string {
+	crc := uint32(0xb704ce)
+	for _, b := range payload {
+		crc ^= uint32(b) << 16
+		for i := 0; i < 8; i++ {
+			crc <<= 1
+			if crc&0x1000000 != 0 {
+				crc ^= 0x1864cfb
+			}
+		}
+	}
+	sum := []byte{byte(crc >> 16), byte(crc >> 8), byte(crc)}
+	encoded := base64.StdEncoding.EncodeToString(payload)
+	var lines []string
+	for len(encoded) > 64 {
+		lines, encoded = append(lines, encoded[:64]), encoded[64:]
+	}
+	lines = append(lines, encoded)
+	return "-----BEGIN " + blockType + "-----\nComment: test\n\n" +
+		strings.Join(lines, "\n") + "\n=" + base64.StdEncoding.EncodeToString(sum) + "\n" +
+		"-----END " + blockType + "-----\n"
+}
+
+func TestArmoredPGP(t *testing.T) {
+	// new format public key packet and old format signature packet, with dummy bodies
+	keyPayload := append([]byte{0xc6, 0x50}, make([]byte, 0x50)...)
+	signaturePayload := append([]byte{0x88, 0x20}, make([]byte, 0x20)...)
+	key := armor("PGP PUBLIC KEY BLOCK", keyPayload)
+	signature := armor("PGP SIGNATURE", signaturePayload)
+
+	t.Run("accepts armored public keys", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(validation.IsArmoredPGPKey()(key)).To(Succeed())
+		g.Expect(validation.IsArmoredPGPKey()(strings.ReplaceAll(key, "\n", "\r\n"))).To(Succeed())
+	})
+
+	t.Run("accepts armored signatures", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(validation.IsArmoredSignature()(signature)).To(Succeed())
+	})
+
+	t.Run("accepts blocks without checksum", func(t *testing.T) {
+		g := NewWithT(t)
+		lines := strings.Split(key, "\n")
+		withoutChecksum := strings.Join(append(lines[:len(lines)-3:len(lines)-3], lines[len(lines)-2:]...), "\n")
+		g.Expect(validation.IsArmoredPGPKey()(withoutChecksum)).To(Succeed())
+	})
+
+	t.Run("rejects blocks of another type", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(validation.IsArmoredPGPKey()(signature)).To(MatchError("must be an ASCII-armored PGP public key"))
+		g.Expect(validation.IsArmoredSignature()(key)).To(MatchError("must be an ASCII-armored PGP signature"))
+		g.Expect(validation.IsArmoredPGPKey()(armor("PGP PRIVATE KEY BLOCK", keyPayload))).To(MatchError("must be an ASCII-armored PGP public key"))
+	})
+
+	t.Run("rejects payloads not starting with the expected packet", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(validation.IsArmoredPGPKey()(armor("PGP PUBLIC KEY BLOCK", signaturePayload))).To(MatchError("must be an ASCII-armored PGP public key"))
+		g.Expect(validation.IsArmoredPGPKey()(armor("PGP PUBLIC KEY BLOCK", []byte("plain text")))).To(MatchError("must be an ASCII-armored PGP public key"))
+	})
+
+	t.Run("rejects broken framing and payloads", func(t *testing.T) {
+		g := NewWithT(t)
+		for _, v := range []string{
+			"",
+			"-----BEGIN PGP PUBLIC KEY BLOCK-----\n-----END PGP PUBLIC KEY BLOCK-----",
+			strings.Replace(key, "-----END PGP PUBLIC KEY BLOCK-----", "-----END PGP SIGNATURE-----", 1),
+			strings.Replace(key, "Comment: test\n\n", "Comment: test\n", 1),
+			strings.Replace(key, "Comment: test", "not a header", 1),
+			strings.Replace(key, "\n\n", "\n\n!!", 1),
+		} {
+			g.Expect(validation.IsArmoredPGPKey()(v)).To(MatchError("must be an ASCII-armored PGP public key"))
+		}
+	})
+
+	t.Run("rejects corrupted payloads", func(t *testing.T) {
+		g := NewWithT(t)
+		corrupted := strings.Replace(key, "\n\nxlA", "\n\nxlB", 1)
+		g.Expect(corrupted).ToNot(Equal(key))
+		g.Expect(validation.IsArmoredPGPKey()(corrupted)).To(MatchError("must have a valid armor checksum"))
+	})
+}
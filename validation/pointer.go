@@ -0,0 +1,153 @@
+package validation
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// PointerRule validates the values of a document targeted by a JSON Pointer, created with
+// AtPointer.
+type PointerRule struct {
+	tokens     []string
+	required   bool
+	validators []Validator[any]
+}
+
+// AtPointer creates a rule validating the values of a decoded JSON document targeted by an
+// RFC 6901 JSON Pointer, such as "/customer/email", for deep documents where nesting MapKey
+// rules would be tedious. A "*" token is a wildcard matching every element of an array or
+// member of an object: "/items/*/price" targets the price of every item. A literal "*" key
+// cannot be targeted. The rule does not apply to values that are absent, unless Required is
+// called. It panics if pointer is not a valid JSON Pointer.
+//
+// Example:
+//
+//	validation.AtPointer("/items/*/price", validation.FloatValidator(validation.Positive[float64]())).Required()
+func AtPointer(pointer string, validators ...Validator[any]) PointerRule {
+	if pointer != "" && !strings.HasPrefix(pointer, "/") {
+		panic(fmt.Sprintf("validation: invalid JSON Pointer %q", pointer))
+	}
+	var tokens []string
+	if pointer != "" {
+		tokens = strings.Split(pointer[1:], "/")
+		for i, token := range tokens {
+			tokens[i] = pointerUnescaper.Replace(token)
+		}
+	}
+	return PointerRule{tokens: tokens, validators: validators}
+}
+
+var pointerUnescaper = strings.NewReplacer("~1", "/", "~0", "~")
+
+// Required makes the targeted values required: for a pointer with wildcards, in every array
+// element or object member the wildcards match.
+func (r PointerRule) Required() PointerRule {
+	r.required = true
+	return r
+}
+
+// ValidatePointers validates a decoded JSON document, made of map[string]any, []any and
+// scalar values, with rules targeting its values by JSON Pointer. Every rule is applied and
+// the errors are joined, each attributed to the path of its value, such as "items[2].price".
+// Like Each, a system error (see IsSystemError) stops validation and is returned as is.
+//
+// Example:
+//
+//	err := validation.ValidatePointers(order,
+//	    validation.AtPointer("/customer/email", validation.StringValidator(validation.Contains("@"))).Required(),
+//	    validation.AtPointer("/items/*/quantity", validation.IntValidator(validation.Range(1, 100))).Required(),
+//	)
+func ValidatePointers(document any, rules ...PointerRule) error {
+	report := NewReport()
+	for _, rule := range rules {
+		if err := rule.apply(report, document, rule.tokens, ""); err != nil {
+			report.Release()
+			return err
+		}
+	}
+	return collect(report)
+}
+
+// ValidatePointersJSON is ValidatePointers for an encoded JSON document. A document that is
+// not valid JSON is a validation error.
+func ValidatePointersJSON(data []byte, rules ...PointerRule) error {
+	var document any
+	if err := json.Unmarshal(data, &document); err != nil {
+		return WrapError(fmt.Errorf("must be valid JSON: %w", err))
+	}
+	return ValidatePointers(document, rules...)
+}
+
+// apply validates the values targeted by tokens within value, at path, recording validation
+// errors in report and returning system errors.
+func (r PointerRule) apply(report *Report, value any, tokens []string, path string) error {
+	if len(tokens) == 0 {
+		for _, validator := range r.validators {
+			if err := validator(value); err != nil {
+				if IsSystemError(err) {
+					return fieldError(path, err)
+				}
+				report.Add(fieldError(path, err))
+				break
+			}
+		}
+		return nil
+	}
+
+	token, rest := tokens[0], tokens[1:]
+	switch node := value.(type) {
+	case map[string]any:
+		if token == "*" {
+			keys := make([]string, 0, len(node))
+			for key := range node {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+			for _, key := range keys {
+				if err := r.apply(report, node[key], rest, joinPath(path, key)); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		if child, exists := node[token]; exists {
+			return r.apply(report, child, rest, joinPath(path, token))
+		}
+	case []any:
+		if token == "*" {
+			for i, child := range node {
+				if err := r.apply(report, child, rest, joinPath(path, "["+strconv.Itoa(i)+"]")); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		if i, err := strconv.Atoi(token); err == nil && i >= 0 && i < len(node) && token == strconv.Itoa(i) {
+			return r.apply(report, node[i], rest, joinPath(path, "["+token+"]"))
+		}
+	}
+
+	// values under a wildcard of a missing value are none, not missing
+	if r.required && !slices.Contains(tokens, "*") {
+		for i, token := range tokens {
+			if _, isArray := value.([]any); isArray && i == 0 {
+				token = "[" + token + "]"
+			}
+			path = joinPath(path, token)
+		}
+		report.Add(NewFieldError(path, NewCodedError(CodeRequired, "required", nil)))
+	}
+	return nil
+}
+
+// fieldError attributes err to path, unless path is the root.
+func fieldError(path string, err error) error {
+	if path == "" {
+		return err
+	}
+	return NewFieldError(path, err)
+}
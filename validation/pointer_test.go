@@ -0,0 +1,81 @@
+package validation_test
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/quantumcycle/protego/validation"
+)
+
+func TestValidatePointers(t *testing.T) {
+	order := map[string]any{
+		"customer": map[string]any{"email": "a@b.c"},
+		"items": []any{
+			map[string]any{"sku": "a", "price": 10.0},
+			map[string]any{"sku": "b", "price": -1.0},
+			map[string]any{"sku": "c"},
+		},
+		"a/b": map[string]any{"~c": "x"},
+	}
+	positive := validation.FloatValidator(validation.Positive[float64]())
+
+	t.Run("validates the targeted values", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(validation.ValidatePointers(order,
+			validation.AtPointer("/customer/email", validation.StringValidator(validation.Contains("@"))).Required(),
+			validation.AtPointer("/items/0/price", positive),
+			validation.AtPointer("/a~1b/~0c", validation.StringValidator(validation.Required[string]())).Required(),
+		)).To(Succeed())
+		g.Expect(validation.ValidatePointers(order, validation.AtPointer("/items/1/price", positive))).
+			To(MatchError("items[1].price: must be positive"))
+	})
+
+	t.Run("expands wildcards", func(t *testing.T) {
+		g := NewWithT(t)
+		err := validation.ValidatePointers(order, validation.AtPointer("/items/*/price", positive).Required())
+		g.Expect(err).To(MatchError("items[1].price: must be positive\nitems[2].price: required"))
+		g.Expect(validation.ToFormErrors(err)).To(HaveKey("items[2].price"))
+
+		err = validation.ValidatePointers(map[string]any{"prices": map[string]any{"eu": 1.0, "us": -1.0}},
+			validation.AtPointer("/prices/*", positive))
+		g.Expect(err).To(MatchError("prices.us: must be positive"))
+	})
+
+	t.Run("ignores absent values unless required", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(validation.ValidatePointers(order, validation.AtPointer("/shipping/zip", validation.StringValidator(validation.Required[string]())))).To(Succeed())
+		g.Expect(validation.ValidatePointers(order, validation.AtPointer("/shipping/zip").Required())).To(MatchError("shipping.zip: required"))
+		g.Expect(validation.ValidatePointers(order, validation.AtPointer("/items/5").Required())).To(MatchError("items[5]: required"))
+		g.Expect(validation.ValidatePointers(order, validation.AtPointer("/lines/*/sku").Required())).To(Succeed())
+	})
+
+	t.Run("validates the whole document with the empty pointer", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(validation.ValidatePointers(order, validation.AtPointer("", validation.MaxDepth[any](1)))).
+			To(MatchError("must not be nested deeper than 1 levels"))
+	})
+
+	t.Run("returns system errors", func(t *testing.T) {
+		g := NewWithT(t)
+		outage := errors.New("connection refused")
+		err := validation.ValidatePointers(order, validation.AtPointer("/customer/email", func(any) error { return outage }))
+		g.Expect(validation.IsSystemError(err)).To(BeTrue())
+		g.Expect(errors.Is(err, outage)).To(BeTrue())
+	})
+
+	t.Run("decodes JSON documents", func(t *testing.T) {
+		g := NewWithT(t)
+		rule := validation.AtPointer("/items/*/quantity", validation.IntValidator(validation.Range(1, 10)))
+		g.Expect(validation.ValidatePointersJSON([]byte(`{"items":[{"quantity":2}]}`), rule)).To(Succeed())
+		g.Expect(validation.ValidatePointersJSON([]byte(`{"items":[{"quantity":20}]}`), rule)).To(MatchError(ContainSubstring("items[0].quantity")))
+		err := validation.ValidatePointersJSON([]byte(`{`), rule)
+		g.Expect(validation.IsValidationError(err)).To(BeTrue())
+	})
+
+	t.Run("panics on invalid pointers", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(func() { validation.AtPointer("items") }).To(Panic())
+	})
+}
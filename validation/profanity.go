@@ -0,0 +1,91 @@
+package validation
+
+import (
+	_ "embed"
+	"strings"
+	"unicode"
+)
+
+// WordFilter detects forbidden words in a text.
+// Implement it to plug an external filter or moderation service into NoForbiddenWords.
+// A returned error is treated as a system error (see IsSystemError).
+type WordFilter interface {
+	ContainsForbidden(text string) (bool, error)
+}
+
+// WordFilterFunc adapts a function to the WordFilter interface.
+type WordFilterFunc func(text string) (bool, error)
+
+// ContainsForbidden calls f(text).
+func (f WordFilterFunc) ContainsForbidden(text string) (bool, error) {
+	return f(text)
+}
+
+// WordList is a WordFilter matching whole words, ignoring case and confusable characters
+// (see FoldConfusables), so that "Crap" and "сrap" (Cyrillic с) match "crap" but "scrap" does not.
+type WordList struct {
+	words map[string]struct{}
+}
+
+// NewWordList creates a WordList forbidding the given words.
+//
+// Example:
+//
+//	filter := validation.NewWordList("spam", "scam")
+func NewWordList(words ...string) *WordList {
+	l := &WordList{words: make(map[string]struct{}, len(words))}
+	for _, w := range words {
+		l.words[FoldCase(FoldConfusables(w))] = struct{}{}
+	}
+	return l
+}
+
+// ContainsForbidden reports whether text contains one of the words of the list.
+func (l *WordList) ContainsForbidden(text string) (bool, error) {
+	words := strings.FieldsFunc(FoldCase(FoldConfusables(text)), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for _, w := range words {
+		if _, ok := l.words[w]; ok {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+//go:embed profanity_en.txt
+var profanityEN string
+
+// EnglishProfanity is a WordList of common English profanity. It is deliberately small;
+// use an external WordFilter for thorough moderation.
+var EnglishProfanity = NewWordList(wordLines(profanityEN)...)
+
+// wordLines returns the non-empty lines of s, skipping # comments.
+func wordLines(s string) []string {
+	var words []string
+	for _, line := range strings.Split(s, "\n") {
+		if line = strings.TrimSpace(line); line != "" && !strings.HasPrefix(line, "#") {
+			words = append(words, line)
+		}
+	}
+	return words
+}
+
+// NoForbiddenWords validates that a string contains none of the words detected by filter.
+// The error does not repeat the offending word.
+//
+// Example:
+//
+//	validation.Validate(input.DisplayName, validation.NoForbiddenWords(validation.EnglishProfanity))
+func NoForbiddenWords(filter WordFilter) Validator[string] {
+	return func(v string) error {
+		found, err := filter.ContainsForbidden(v)
+		if err != nil {
+			return err
+		}
+		if found {
+			return NewValidationError("must not contain inappropriate language")
+		}
+		return nil
+	}
+}
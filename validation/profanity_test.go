@@ -0,0 +1,57 @@
+package validation_test
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/quantumcycle/protego/validation"
+)
+
+func TestNoForbiddenWords(t *testing.T) {
+	t.Run("rejects words from the embedded list", func(t *testing.T) {
+		g := NewWithT(t)
+		err := validation.Validate("What the Crap!", validation.NoForbiddenWords(validation.EnglishProfanity))
+		g.Expect(err).To(MatchError("must not contain inappropriate language"))
+		g.Expect(validation.IsValidationError(err)).To(BeTrue())
+	})
+
+	t.Run("matches whole words only", func(t *testing.T) {
+		g := NewWithT(t)
+		for _, v := range []string{"Scunthorpe", "classic assessment", "scrap metal", ""} {
+			g.Expect(validation.Validate(v, validation.NoForbiddenWords(validation.EnglishProfanity))).To(Succeed(), v)
+		}
+	})
+
+	t.Run("folds confusable characters", func(t *testing.T) {
+		g := NewWithT(t)
+		err := validation.Validate("сrap", validation.NoForbiddenWords(validation.EnglishProfanity)) // Cyrillic с
+		g.Expect(err).To(HaveOccurred())
+	})
+
+	t.Run("supports custom word lists", func(t *testing.T) {
+		g := NewWithT(t)
+		filter := validation.NewWordList("spam", "Scam")
+		g.Expect(validation.Validate("no SCAM here", validation.NoForbiddenWords(filter))).To(HaveOccurred())
+		g.Expect(validation.Validate("spammer", validation.NoForbiddenWords(filter))).To(Succeed())
+	})
+
+	t.Run("supports external filters", func(t *testing.T) {
+		g := NewWithT(t)
+		filter := validation.WordFilterFunc(func(text string) (bool, error) {
+			return text == "flagged", nil
+		})
+		g.Expect(validation.Validate("flagged", validation.NoForbiddenWords(filter))).To(HaveOccurred())
+		g.Expect(validation.Validate("fine", validation.NoForbiddenWords(filter))).To(Succeed())
+	})
+
+	t.Run("returns filter failures as system errors", func(t *testing.T) {
+		g := NewWithT(t)
+		unavailable := errors.New("moderation service unavailable")
+		filter := validation.WordFilterFunc(func(string) (bool, error) { return false, unavailable })
+		err := validation.Validate("anything", validation.NoForbiddenWords(filter))
+		g.Expect(err).To(MatchError(unavailable))
+		g.Expect(validation.IsSystemError(err)).To(BeTrue())
+	})
+}
@@ -0,0 +1,198 @@
+package validation
+
+import (
+	"bufio"
+	"container/list"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultPwnedPasswordsEndpoint is the range API of Have I Been Pwned's Pwned Passwords.
+const DefaultPwnedPasswordsEndpoint = "https://api.pwnedpasswords.com/range/"
+
+// PwnedPasswordsOptions configures a PwnedPasswords client.
+type PwnedPasswordsOptions struct {
+	// HTTPClient sends the requests. Nil means http.DefaultClient.
+	HTTPClient *http.Client
+	// Endpoint is the URL of the range API, to which the hash prefix is appended. Empty
+	// means DefaultPwnedPasswordsEndpoint.
+	Endpoint string
+	// CacheSize is the number of hash prefixes whose ranges are cached. Zero disables the
+	// cache.
+	CacheSize int
+	// CacheTTL is how long cached ranges are used. Zero keeps them until they are evicted.
+	CacheTTL time.Duration
+	// MinOccurrences is the number of breaches from which a password is rejected. Values
+	// below 1 mean 1.
+	MinOccurrences int
+	// FailOpen accepts passwords when the API cannot be reached, fails or times out, rather
+	// than returning its error, so that an outage does not block sign-ups. Timeouts are
+	// those of HTTPClient and deadlines of the context; a canceled context still fails.
+	FailOpen bool
+}
+
+// PwnedPasswords looks up passwords in Have I Been Pwned's Pwned Passwords with the
+// k-anonymity range API: only the first 5 characters of the SHA-1 hash of a password are
+// sent, and the matching hash suffixes are compared locally. It is safe for concurrent use.
+type PwnedPasswords struct {
+	opts PwnedPasswordsOptions
+
+	mu      sync.Mutex
+	order   *list.List // of *pwnedRange, most recently used first
+	entries map[string]*list.Element
+}
+
+// pwnedRange holds the breach counts of the hash suffixes of a prefix.
+type pwnedRange struct {
+	prefix  string
+	counts  map[string]int
+	fetched time.Time
+}
+
+// NewPwnedPasswords creates a PwnedPasswords client.
+//
+// Example:
+//
+//	var pwned = validation.NewPwnedPasswords(validation.PwnedPasswordsOptions{
+//	    HTTPClient: &http.Client{Timeout: 2 * time.Second},
+//	    CacheSize:  10000,
+//	    CacheTTL:   24 * time.Hour,
+//	    FailOpen:   true,
+//	})
+func NewPwnedPasswords(opts PwnedPasswordsOptions) *PwnedPasswords {
+	if opts.HTTPClient == nil {
+		opts.HTTPClient = http.DefaultClient
+	}
+	if opts.Endpoint == "" {
+		opts.Endpoint = DefaultPwnedPasswordsEndpoint
+	}
+	opts.MinOccurrences = max(opts.MinOccurrences, 1)
+	return &PwnedPasswords{opts: opts, order: list.New(), entries: make(map[string]*list.Element)}
+}
+
+// Count returns the number of breaches password was exposed in, zero if none.
+func (p *PwnedPasswords) Count(ctx context.Context, password string) (int, error) {
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+	prefix, suffix := hash[:5], hash[5:]
+
+	counts, ok := p.cached(prefix)
+	if !ok {
+		var err error
+		if counts, err = p.fetch(ctx, prefix); err != nil {
+			return 0, err
+		}
+		p.store(prefix, counts)
+	}
+	return counts[suffix], nil
+}
+
+// fetch requests the range of prefix, with padding so that the size of the response does
+// not reveal the prefix.
+func (p *PwnedPasswords) fetch(ctx context.Context, prefix string) (map[string]int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.opts.Endpoint+prefix, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Add-Padding", "true")
+	resp, err := p.opts.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("pwned passwords: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("pwned passwords: %s", resp.Status)
+	}
+
+	counts := make(map[string]int)
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		suffix, count, found := strings.Cut(strings.TrimSpace(scanner.Text()), ":")
+		n, err := strconv.Atoi(count)
+		if !found || err != nil {
+			return nil, fmt.Errorf("pwned passwords: malformed line %q", scanner.Text())
+		}
+		if n > 0 { // padding entries have a count of 0
+			counts[suffix] = n
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("pwned passwords: %w", err)
+	}
+	return counts, nil
+}
+
+func (p *PwnedPasswords) cached(prefix string) (map[string]int, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	e, ok := p.entries[prefix]
+	if !ok {
+		return nil, false
+	}
+	r := e.Value.(*pwnedRange)
+	if p.opts.CacheTTL > 0 && time.Since(r.fetched) > p.opts.CacheTTL {
+		p.order.Remove(e)
+		delete(p.entries, prefix)
+		return nil, false
+	}
+	p.order.MoveToFront(e)
+	return r.counts, true
+}
+
+func (p *PwnedPasswords) store(prefix string, counts map[string]int) {
+	if p.opts.CacheSize <= 0 {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if e, ok := p.entries[prefix]; ok {
+		p.order.Remove(e)
+	}
+	p.entries[prefix] = p.order.PushFront(&pwnedRange{prefix: prefix, counts: counts, fetched: time.Now()})
+	if p.order.Len() > p.opts.CacheSize {
+		oldest := p.order.Back()
+		p.order.Remove(oldest)
+		delete(p.entries, oldest.Value.(*pwnedRange).prefix)
+	}
+}
+
+// NotPwnedPassword validates that a password was not exposed in data breaches known to
+// Have I Been Pwned, at least PwnedPasswordsOptions.MinOccurrences times. It is opt-in, as
+// it calls a third-party service: the password itself is never sent (see PwnedPasswords).
+// When the service fails or its context deadline passes, the password is accepted if the
+// client fails open; otherwise the error is returned as is: it is a system error (see
+// IsSystemError). The validation error never includes the password.
+//
+// ValidateWithTimeout returns ErrTimeout as soon as its own budget expires, whatever the
+// validators return: to fail open on a slow API within such a budget, set a shorter
+// PwnedPasswordsOptions.HTTPClient timeout.
+//
+// Example:
+//
+//	err := validation.ValidateCtx(ctx, input.Password,
+//	    validation.WithContext(validation.MinLength(12)),
+//	    validation.NotPwnedPassword(pwned),
+//	)
+func NotPwnedPassword(client *PwnedPasswords) ValidatorCtx[string] {
+	return func(ctx context.Context, v string) error {
+		count, err := client.Count(ctx, v)
+		if err != nil {
+			if client.opts.FailOpen && !errors.Is(ctx.Err(), context.Canceled) {
+				return nil
+			}
+			return err
+		}
+		if count >= client.opts.MinOccurrences {
+			return newSensitiveError("must not be a password exposed in a data breach")
+		}
+		return nil
+	}
+}
@@ -0,0 +1,120 @@
+package validation_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/quantumcycle/protego/validation"
+)
+
+// newPwnedServer serves a range API knowing "password" (SHA-1 5BAA61E4C9B93F3F0682250B6CF8331B7EE68FD8)
+// with the given count, counting the requests.
+func newPwnedServer(t *testing.T, status int, count int, requests *atomic.Int32) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.Header.Get("Add-Padding") != "true" {
+			t.Error("padding not requested")
+		}
+		w.WriteHeader(status)
+		if r.URL.Path == "/range/5BAA6" {
+			fmt.Fprintf(w, "0018A45C4D1DEF81644B54AB7F969B88D65:1\r\n1E4C9B93F3F0682250B6CF8331B7EE68FD8:%d\r\n", count)
+		}
+		fmt.Fprint(w, "FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFF:0\r\n")
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestNotPwnedPassword(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("rejects breached passwords", func(t *testing.T) {
+		g := NewWithT(t)
+		var requests atomic.Int32
+		server := newPwnedServer(t, http.StatusOK, 9545824, &requests)
+		pwned := validation.NewPwnedPasswords(validation.PwnedPasswordsOptions{Endpoint: server.URL + "/range/"})
+
+		err := validation.NotPwnedPassword(pwned)(ctx, "password")
+		g.Expect(err).To(MatchError("must not be a password exposed in a data breach"))
+		g.Expect(validation.IsValidationError(err)).To(BeTrue())
+
+		count, err := pwned.Count(ctx, "password")
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(count).To(Equal(9545824))
+	})
+
+	t.Run("accepts other passwords", func(t *testing.T) {
+		g := NewWithT(t)
+		var requests atomic.Int32
+		server := newPwnedServer(t, http.StatusOK, 3, &requests)
+		pwned := validation.NewPwnedPasswords(validation.PwnedPasswordsOptions{Endpoint: server.URL + "/range/"})
+		g.Expect(validation.NotPwnedPassword(pwned)(ctx, "correct horse battery staple 42")).To(Succeed())
+	})
+
+	t.Run("accepts passwords seen fewer than MinOccurrences times", func(t *testing.T) {
+		g := NewWithT(t)
+		var requests atomic.Int32
+		server := newPwnedServer(t, http.StatusOK, 3, &requests)
+		pwned := validation.NewPwnedPasswords(validation.PwnedPasswordsOptions{Endpoint: server.URL + "/range/", MinOccurrences: 10})
+		g.Expect(validation.NotPwnedPassword(pwned)(ctx, "password")).To(Succeed())
+	})
+
+	t.Run("caches ranges", func(t *testing.T) {
+		g := NewWithT(t)
+		var requests atomic.Int32
+		server := newPwnedServer(t, http.StatusOK, 1, &requests)
+		pwned := validation.NewPwnedPasswords(validation.PwnedPasswordsOptions{Endpoint: server.URL + "/range/", CacheSize: 1})
+		validator := validation.NotPwnedPassword(pwned)
+
+		g.Expect(validator(ctx, "password")).ToNot(Succeed())
+		g.Expect(validator(ctx, "password")).ToNot(Succeed())
+		g.Expect(requests.Load()).To(BeEquivalentTo(1))
+
+		g.Expect(validator(ctx, "another password")).To(Succeed()) // evicts the range of "password"
+		g.Expect(validator(ctx, "password")).ToNot(Succeed())
+		g.Expect(requests.Load()).To(BeEquivalentTo(3))
+	})
+
+	t.Run("does not cache without CacheSize", func(t *testing.T) {
+		g := NewWithT(t)
+		var requests atomic.Int32
+		server := newPwnedServer(t, http.StatusOK, 1, &requests)
+		validator := validation.NotPwnedPassword(validation.NewPwnedPasswords(validation.PwnedPasswordsOptions{Endpoint: server.URL + "/range/"}))
+		g.Expect(validator(ctx, "password")).ToNot(Succeed())
+		g.Expect(validator(ctx, "password")).ToNot(Succeed())
+		g.Expect(requests.Load()).To(BeEquivalentTo(2))
+	})
+
+	t.Run("fails closed with a system error by default", func(t *testing.T) {
+		g := NewWithT(t)
+		var requests atomic.Int32
+		server := newPwnedServer(t, http.StatusServiceUnavailable, 1, &requests)
+		pwned := validation.NewPwnedPasswords(validation.PwnedPasswordsOptions{Endpoint: server.URL + "/range/"})
+		err := validation.NotPwnedPassword(pwned)(ctx, "password")
+		g.Expect(err).To(MatchError("pwned passwords: 503 Service Unavailable"))
+		g.Expect(validation.IsSystemError(err)).To(BeTrue())
+	})
+
+	t.Run("fails open when configured", func(t *testing.T) {
+		g := NewWithT(t)
+		var requests atomic.Int32
+		server := newPwnedServer(t, http.StatusServiceUnavailable, 1, &requests)
+		pwned := validation.NewPwnedPasswords(validation.PwnedPasswordsOptions{Endpoint: server.URL + "/range/", FailOpen: true})
+		g.Expect(validation.NotPwnedPassword(pwned)(ctx, "password")).To(Succeed())
+
+		canceled, cancel := context.WithCancel(ctx)
+		cancel()
+		g.Expect(validation.NotPwnedPassword(pwned)(canceled, "password")).To(MatchError(ContainSubstring("context canceled")))
+
+		expired, cancel := context.WithDeadline(ctx, time.Now().Add(-time.Second))
+		defer cancel()
+		g.Expect(validation.NotPwnedPassword(pwned)(expired, "password")).To(Succeed())
+	})
+}
@@ -0,0 +1,54 @@
+package validation
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Quantity is a measured value with its unit of measure, such as 12.5 kg.
+type Quantity struct {
+	Value float64 `json:"value"`
+	Unit  string  `json:"unit"`
+}
+
+// IsUnit validates that a string is one of the allowed units of measure. Units are
+// compared case-sensitively, as in "mm" and "Mm".
+//
+// Example:
+//
+//	validation.Validate(input.WeightUnit, validation.IsUnit("kg", "lb"))
+func IsUnit(allowed ...string) Validator[string] {
+	return func(v string) error {
+		if !slices.Contains(allowed, v) {
+			return NewValidationError(fmt.Sprintf("must be one of the units: %s", strings.Join(allowed, ", ")))
+		}
+		return nil
+	}
+}
+
+// IsQuantity validates a Quantity with per-unit rules: the unit must be a key of rules, and
+// the value must pass the validator of its unit. Errors are attributed to the "unit" and
+// "value" fields.
+//
+// Example:
+//
+//	validation.Validate(parcel.Weight, validation.IsQuantity(map[string]validation.Validator[float64]{
+//	    "kg": validation.Range(0.0, 1000),
+//	    "lb": validation.Range(0.0, 2205),
+//	}))
+func IsQuantity(rules map[string]Validator[float64]) Validator[Quantity] {
+	units := make([]string, 0, len(rules))
+	for unit := range rules {
+		units = append(units, unit)
+	}
+	sort.Strings(units)
+	isUnit := IsUnit(units...)
+	return func(q Quantity) error {
+		if err := isUnit(q.Unit); err != nil {
+			return NewFieldError("unit", err)
+		}
+		return NewFieldError("value", Validate(q.Value, rules[q.Unit]))
+	}
+}
@@ -0,0 +1,36 @@
+package validation_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/quantumcycle/protego/validation"
+)
+
+func TestIsUnit(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(validation.Validate("kg", validation.IsUnit("kg", "lb"))).To(Succeed())
+	g.Expect(validation.Validate("KG", validation.IsUnit("kg", "lb"))).To(MatchError("must be one of the units: kg, lb"))
+}
+
+func TestIsQuantity(t *testing.T) {
+	weight := validation.IsQuantity(map[string]validation.Validator[float64]{
+		"lb": validation.Range(0.0, 2205),
+		"kg": validation.Range(0.0, 1000),
+	})
+
+	t.Run("applies the rule of the unit", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(validation.Validate(validation.Quantity{Value: 1500, Unit: "lb"}, weight)).To(Succeed())
+		g.Expect(validation.Validate(validation.Quantity{Value: 1500, Unit: "kg"}, weight)).
+			To(MatchError("value: must be between 0 and 1000"))
+	})
+
+	t.Run("fails with unknown units", func(t *testing.T) {
+		g := NewWithT(t)
+		err := validation.Validate(validation.Quantity{Value: 3, Unit: "st"}, weight)
+		g.Expect(err).To(MatchError("unit: must be one of the units: kg, lb"))
+		g.Expect(validation.IsValidationError(err)).To(BeTrue())
+	})
+}
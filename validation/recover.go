@@ -0,0 +1,67 @@
+package validation
+
+import (
+	"fmt"
+	"runtime/debug"
+)
+
+// PanicError reports a panic recovered from a validator by Recover or ValidateSafe.
+// It is not a validation error: a panicking validator is a bug, not invalid input.
+type PanicError struct {
+	value any
+	stack []byte
+}
+
+// Error returns a message including the value passed to panic.
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("validator panicked: %v", e.value)
+}
+
+// Value returns the value passed to panic.
+func (e *PanicError) Value() any {
+	return e.value
+}
+
+// Unwrap returns the value passed to panic if it is an error, such as a runtime.Error,
+// and nil otherwise.
+func (e *PanicError) Unwrap() error {
+	err, _ := e.value.(error)
+	return err
+}
+
+// Stack returns the stack trace of the goroutine at the time of the panic.
+func (e *PanicError) Stack() []byte {
+	return e.stack
+}
+
+// Recover returns a middleware converting panics in the wrapped validator into a *PanicError.
+//
+// Example:
+//
+//	validation.Validate(input, validation.Wrap(lookupValidator, validation.Recover[Input]()))
+func Recover[T any]() Middleware[T] {
+	return func(next Validator[T]) Validator[T] {
+		return func(v T) (err error) {
+			defer func() {
+				if r := recover(); r != nil {
+					err = &PanicError{value: r, stack: debug.Stack()}
+				}
+			}()
+			return next(v)
+		}
+	}
+}
+
+// ValidateSafe is like Validate, but recovers panics from the validators and returns them as
+// a *PanicError, so a buggy custom validator cannot take down a request handler.
+//
+// Example:
+//
+//	err := validation.ValidateSafe(input, validation.Custom(checkInput))
+//	var panicErr *validation.PanicError
+//	if errors.As(err, &panicErr) {
+//	    log.Printf("%v\n%s", panicErr, panicErr.Stack())
+//	}
+func ValidateSafe[T any](value T, validators ...Validator[T]) error {
+	return Validate(value, Wrap(And(validators...), Recover[T]()))
+}
@@ -0,0 +1,68 @@
+package validation_test
+
+import (
+	"errors"
+	"runtime"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/quantumcycle/protego/validation"
+)
+
+func TestValidateSafe(t *testing.T) {
+	buggy := validation.Custom(func(s string) error {
+		var lengths map[string]int
+		lengths[s] = len(s) // assignment to nil map
+		return nil
+	})
+
+	t.Run("runs validators like Validate", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(validation.ValidateSafe("john", validation.MinLength(3))).To(Succeed())
+		g.Expect(validation.ValidateSafe("jo", validation.MinLength(3))).To(MatchError("must be at least 3 characters"))
+	})
+
+	t.Run("converts panics into errors", func(t *testing.T) {
+		g := NewWithT(t)
+		err := validation.ValidateSafe("john", validation.MinLength(3), buggy)
+		var panicErr *validation.PanicError
+		g.Expect(errors.As(err, &panicErr)).To(BeTrue())
+		g.Expect(err.Error()).To(ContainSubstring("validator panicked: assignment to entry in nil map"))
+		g.Expect(string(panicErr.Stack())).To(ContainSubstring("recover_test.go"))
+		g.Expect(panicErr.Value()).ToNot(BeNil())
+		g.Expect(validation.IsValidationError(err)).To(BeFalse())
+		var runtimeErr runtime.Error
+		g.Expect(errors.As(err, &runtimeErr)).To(BeTrue())
+	})
+
+	t.Run("remains a system error when the panic value is a validation error", func(t *testing.T) {
+		g := NewWithT(t)
+		err := validation.ValidateSafe("john", validation.Custom(func(string) error {
+			panic(validation.NewValidationError("must not panic"))
+		}))
+		g.Expect(validation.IsSystemError(err)).To(BeTrue())
+	})
+
+	t.Run("does not run validators after a failure", func(t *testing.T) {
+		g := NewWithT(t)
+		err := validation.ValidateSafe("jo", validation.MinLength(3), buggy)
+		g.Expect(err).To(MatchError("must be at least 3 characters"))
+	})
+}
+
+func TestRecover(t *testing.T) {
+	g := NewWithT(t)
+	validator := validation.Wrap(validation.Custom(func(int) error {
+		panic("boom")
+	}), validation.Recover[int]())
+	err := validation.Validate(1, validator)
+	g.Expect(err).To(MatchError("validator panicked: boom"))
+	g.Expect(errors.Unwrap(err)).To(BeNil())
+
+	sentinel := errors.New("sentinel")
+	err = validation.Validate(1, validation.Wrap(validation.Custom(func(int) error {
+		panic(sentinel)
+	}), validation.Recover[int]()))
+	g.Expect(errors.Is(err, sentinel)).To(BeTrue())
+}
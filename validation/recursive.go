@@ -0,0 +1,148 @@
+package validation
+
+import (
+	"fmt"
+	"sync"
+)
+
+// SchemaRule is a rule validating nested objects, map[string]any values, against schema.
+//
+// Example:
+//
+//	validation.Key("address", validation.SchemaRule(addressSchema)).Required()
+func SchemaRule(schema *Schema) Rule {
+	r := NewRule("schema", nil, func(v any) error {
+		m, err := asObject(v)
+		if err != nil {
+			return err
+		}
+		return schema.Validate(m)
+	})
+	r.regexes = func(v any) int {
+		if m, ok := v.(map[string]any); ok {
+			return schema.regexExecutions(m)
+		}
+		return 0
+	}
+	return r
+}
+
+// EachRule is a rule validating every element of arrays, []any values, with rule. Errors are
+// attributed to the index of the element, as by Each.
+//
+// Example:
+//
+//	validation.Key("tags", validation.EachRule(validation.MaxLengthRule(20)))
+func EachRule(rule Rule) Rule {
+	params := map[string]any{}
+	for name, value := range rule.params {
+		params[name] = value
+	}
+	r := NewRule("each_"+rule.name, params, each(rule.validator))
+	if rule.lenient != nil {
+		r.lenient = each(rule.lenient)
+	}
+	if rule.regexes != nil {
+		r.regexes = func(v any) int {
+			elements, _ := v.([]any)
+			executions := 0
+			for _, element := range elements {
+				executions += rule.regexes(element)
+			}
+			return executions
+		}
+	}
+	return r
+}
+
+func each(validator Validator[any]) Validator[any] {
+	return func(v any) error {
+		elements, ok := v.([]any)
+		if !ok {
+			return NewValidationError("must be an array")
+		}
+		report := NewReport()
+		for i, element := range elements {
+			if err := validator(element); err != nil {
+				if IsSystemError(err) {
+					report.Release()
+					return fmt.Errorf("index %d: %w", i, err)
+				}
+				report.Add(NewFieldError(fmt.Sprintf("[%d]", i), err))
+			}
+		}
+		return collect(report)
+	}
+}
+
+// Recursive creates a schema referencing itself, such as a category with child categories,
+// which schemas built from values cannot express. build receives self, a rule validating
+// nested objects against the schema being built, and returns the schema. Objects nested
+// deeper than maxDepth levels below the validated one are rejected, so hostile payloads
+// cannot recurse without bound.
+//
+// Each level of nesting is built on first use, by calling build again, which never loops:
+// build must only define the schema, and not validate with self.
+//
+// Example:
+//
+//	var categorySchema = validation.Recursive(10, func(self validation.Rule) *validation.Schema {
+//	    return validation.NewSchema(
+//	        validation.Key("name", validation.MinLengthRule(1)).Required(),
+//	        validation.Key("children", validation.EachRule(self)),
+//	    )
+//	})
+func Recursive(maxDepth int, build func(self Rule) *Schema) *Schema {
+	r := &recursion{maxDepth: maxDepth, build: build}
+	return r.level(0)
+}
+
+// recursion builds the levels of a recursive schema, the self rule of each level validating
+// against the next one.
+type recursion struct {
+	maxDepth int
+	build    func(self Rule) *Schema
+
+	mu     sync.Mutex
+	levels []*Schema
+}
+
+func (r *recursion) level(depth int) *Schema {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for len(r.levels) <= depth {
+		d := len(r.levels)
+		r.levels = append(r.levels, r.build(r.self(d+1)))
+	}
+	return r.levels[depth]
+}
+
+// self returns the rule of nested objects at depth.
+func (r *recursion) self(depth int) Rule {
+	tooDeep := NewValidationError(fmt.Sprintf("must not be nested deeper than %d levels", r.maxDepth))
+	rule := NewRule("recursive", map[string]any{"max": r.maxDepth}, func(v any) error {
+		m, err := asObject(v)
+		if err != nil {
+			return err
+		}
+		if depth > r.maxDepth {
+			return tooDeep
+		}
+		return r.level(depth).Validate(m)
+	})
+	rule.regexes = func(v any) int {
+		if m, ok := v.(map[string]any); ok && depth <= r.maxDepth {
+			return r.level(depth).regexExecutions(m)
+		}
+		return 0
+	}
+	return rule
+}
+
+func asObject(v any) (map[string]any, error) {
+	m, ok := v.(map[string]any)
+	if !ok {
+		return nil, NewValidationError("must be an object")
+	}
+	return m, nil
+}
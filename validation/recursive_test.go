@@ -0,0 +1,62 @@
+package validation_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/quantumcycle/protego/validation"
+)
+
+func TestRecursive(t *testing.T) {
+	category := validation.Recursive(2, func(self validation.Rule) *validation.Schema {
+		return validation.NewSchema(
+			validation.Key("name", validation.MinLengthRule(1)).Required(),
+			validation.Key("children", validation.EachRule(self)),
+		)
+	})
+	node := func(name string, children ...any) map[string]any {
+		return map[string]any{"name": name, "children": children}
+	}
+
+	t.Run("validates nested objects against the schema itself", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(category.Validate(node("root", node("a", node("a1")), node("b")))).To(Succeed())
+		err := category.Validate(node("root", node("a"), node("")))
+		g.Expect(err).To(MatchError(ContainSubstring(`key "children": [1]: key "name": must be at least 1 characters`)))
+		g.Expect(validation.IsValidationError(err)).To(BeTrue())
+		g.Expect(category.Validate(node("root", "a"))).To(MatchError(ContainSubstring("[0]: must be an object")))
+	})
+
+	t.Run("bounds the depth of nesting", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(category.Validate(node("root", node("a", node("a1", node("a2")))))).
+			To(MatchError(ContainSubstring("must not be nested deeper than 2 levels")))
+	})
+
+	t.Run("is introspectable", func(t *testing.T) {
+		g := NewWithT(t)
+		deeper := validation.Recursive(5, func(self validation.Rule) *validation.Schema {
+			return validation.NewSchema(
+				validation.Key("name", validation.MinLengthRule(1)).Required(),
+				validation.Key("children", validation.EachRule(self)),
+			)
+		})
+		g.Expect(validation.DiffRules(category, deeper).Changes).To(Equal([]validation.RuleChange{{
+			Key: "children", Rule: "each_recursive", Kind: validation.RuleLoosened,
+			Old: map[string]any{"max": 2}, New: map[string]any{"max": 5},
+		}}))
+	})
+
+	t.Run("nests schemas", func(t *testing.T) {
+		g := NewWithT(t)
+		address := validation.NewSchema(validation.Key("city", validation.MinLengthRule(2)).Required())
+		user := validation.NewSchema(
+			validation.Key("address", validation.SchemaRule(address)),
+			validation.Key("tags", validation.EachRule(validation.MaxLengthRule(3))),
+		)
+		g.Expect(user.Validate(map[string]any{"address": map[string]any{"city": "Paris"}, "tags": []any{"a", "b"}})).To(Succeed())
+		g.Expect(user.Validate(map[string]any{"address": map[string]any{}})).To(MatchError(`key "address": key "city" is required`))
+		g.Expect(user.Validate(map[string]any{"tags": []any{"a", "long"}})).To(MatchError(`key "tags": [1]: must be at most 3 characters`))
+	})
+}
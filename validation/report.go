@@ -0,0 +1,184 @@
+package validation
+
+import (
+	"cmp"
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// maxPooledErrors is the capacity above which a Report buffer is dropped rather than pooled,
+// so that one huge batch does not keep its buffer alive for the rest of the process.
+const maxPooledErrors = 1024
+
+var reportPool = sync.Pool{
+	New: func() any { return new(Report) },
+}
+
+// Report collects the errors of many validations, such as the elements of a batch, into a
+// pooled buffer. Each, ValidateStruct and the schema validators use it internally, and
+// high-throughput consumers aggregating their own errors can use it to avoid growing a new
+// slice on every call.
+//
+// A Report is taken with NewReport and must be given back with Release once its error has
+// been built. It must not be used after Release, nor shared between goroutines.
+//
+// Example:
+//
+//	report := validation.NewReport()
+//	defer report.Release()
+//	for i, order := range orders {
+//	    report.Add(validation.NewFieldError(fmt.Sprintf("[%d]", i), order.Validate()))
+//	}
+//	return report.Err()
+type Report struct {
+	errs []error
+}
+
+// NewReport returns an empty Report from the pool.
+func NewReport() *Report {
+	return reportPool.Get().(*Report)
+}
+
+// Add records err, ignoring nil errors.
+func (r *Report) Add(err error) {
+	if err != nil {
+		r.errs = append(r.errs, err)
+	}
+}
+
+// Len returns the number of errors recorded.
+func (r *Report) Len() int {
+	return len(r.errs)
+}
+
+// Err returns the recorded errors joined, like errors.Join, or nil if there are none. The
+// returned error does not share the buffer of the Report, so it stays valid after Release.
+func (r *Report) Err() error {
+	return errors.Join(r.errs...)
+}
+
+// Release empties the Report and gives it back to the pool.
+func (r *Report) Release() {
+	if cap(r.errs) > maxPooledErrors {
+		r.errs = nil
+	} else {
+		clear(r.errs)
+		r.errs = r.errs[:0]
+	}
+	reportPool.Put(r)
+}
+
+// collect returns the error of report and releases it, for the one-shot use of the
+// validators of this package.
+func collect(report *Report) error {
+	err := report.Err()
+	report.Release()
+	return err
+}
+
+// Stable returns err with its errors in a deterministic order, for snapshot tests and clients
+// comparing responses: joined errors are flattened, sorted by field path then message, and
+// errors with the same path and message are kept once. Numbers in paths and messages compare
+// by value, so "items[2]" comes before "items[10]" and "index 2" before "index 10". Each
+// error keeps its field attribution (see FieldError), and Stable returns nil if err is nil.
+//
+// Example:
+//
+//	var errs []error
+//	for sku, line := range cart.Lines { // a map, iterated in random order
+//	    errs = append(errs, validation.NewFieldError(sku, line.Validate()))
+//	}
+//	return validation.Stable(errors.Join(errs...))
+func Stable(err error) error {
+	if err == nil {
+		return nil
+	}
+	var leaves []stableError
+	flattenErrors(&leaves, err, nil, "")
+	sort.SliceStable(leaves, func(i, j int) bool {
+		if c := naturalCompare(leaves[i].path, leaves[j].path); c != 0 {
+			return c < 0
+		}
+		return naturalCompare(leaves[i].msg, leaves[j].msg) < 0
+	})
+
+	errs := make([]error, 0, len(leaves))
+	for i, leaf := range leaves {
+		if i > 0 && leaf.path == leaves[i-1].path && leaf.msg == leaves[i-1].msg {
+			continue
+		}
+		errs = append(errs, leaf.err)
+	}
+	return errors.Join(errs...)
+}
+
+// stableError is an error flattened by Stable, attributed again to its fields.
+type stableError struct {
+	err  error
+	path string
+	msg  string
+}
+
+func flattenErrors(out *[]stableError, err error, fields []string, path string) {
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		for _, e := range joined.Unwrap() {
+			flattenErrors(out, e, fields, path)
+		}
+		return
+	}
+	if fieldErr, ok := err.(*FieldError); ok {
+		fields = append(fields[:len(fields):len(fields)], fieldErr.Field())
+		flattenErrors(out, fieldErr.Unwrap(), fields, joinPath(path, fieldErr.Field()))
+		return
+	}
+	leaf := stableError{path: path, msg: err.Error()}
+	for i := len(fields) - 1; i >= 0; i-- {
+		err = NewFieldError(fields[i], err)
+	}
+	leaf.err = err
+	*out = append(*out, leaf)
+}
+
+// naturalCompare compares strings like strings.Compare, except that runs of digits compare
+// by numeric value.
+func naturalCompare(a, b string) int {
+	for a != "" && b != "" {
+		if isDigit(a[0]) && isDigit(b[0]) {
+			na, restA := digitRun(a)
+			nb, restB := digitRun(b)
+			// without leading zeros, the longer run is the larger number
+			if len(na) != len(nb) {
+				return cmp.Compare(len(na), len(nb))
+			}
+			if na != nb {
+				return strings.Compare(na, nb)
+			}
+			a, b = restA, restB
+			continue
+		}
+		if a[0] != b[0] {
+			return cmp.Compare(a[0], b[0])
+		}
+		a, b = a[1:], b[1:]
+	}
+	return cmp.Compare(len(a), len(b))
+}
+
+// digitRun splits s after its leading digits, returned without leading zeros.
+func digitRun(s string) (digits, rest string) {
+	end := 0
+	for end < len(s) && isDigit(s[end]) {
+		end++
+	}
+	start := 0
+	for start < end-1 && s[start] == '0' {
+		start++
+	}
+	return s[start:end], s[end:]
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
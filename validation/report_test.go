@@ -0,0 +1,115 @@
+package validation_test
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/quantumcycle/protego/validation"
+)
+
+func TestReport(t *testing.T) {
+
+	t.Run("returns nil without errors", func(t *testing.T) {
+		g := NewWithT(t)
+		report := validation.NewReport()
+		defer report.Release()
+		report.Add(nil)
+		g.Expect(report.Len()).To(Equal(0))
+		g.Expect(report.Err()).To(BeNil())
+	})
+
+	t.Run("joins the recorded errors", func(t *testing.T) {
+		g := NewWithT(t)
+		report := validation.NewReport()
+		defer report.Release()
+		report.Add(validation.NewFieldError("name", validation.NewValidationError("required")))
+		report.Add(nil)
+		report.Add(validation.NewFieldError("age", validation.NewValidationError("must be positive")))
+		g.Expect(report.Len()).To(Equal(2))
+		g.Expect(report.Err()).To(MatchError("name: required\nage: must be positive"))
+	})
+
+	t.Run("error stays valid after release", func(t *testing.T) {
+		g := NewWithT(t)
+		report := validation.NewReport()
+		report.Add(errors.New("first"))
+		err := report.Err()
+		report.Release()
+
+		reused := validation.NewReport()
+		defer reused.Release()
+		g.Expect(reused.Len()).To(Equal(0))
+		reused.Add(errors.New("second"))
+		g.Expect(err).To(MatchError("first"))
+	})
+
+	t.Run("Each collects element errors", func(t *testing.T) {
+		g := NewWithT(t)
+		err := validation.Validate([]int{1, -1, 2, -2}, validation.Each(validation.Min(0)))
+		g.Expect(err).To(MatchError("index 1: must be at least 0\nindex 3: must be at least 0"))
+	})
+}
+
+func TestStable(t *testing.T) {
+
+	t.Run("returns nil for nil", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(validation.Stable(nil)).To(BeNil())
+	})
+
+	t.Run("orders errors by field path, indexes by value", func(t *testing.T) {
+		g := NewWithT(t)
+		err := validation.Stable(errors.Join(
+			validation.NewFieldError("items", validation.NewFieldError("[10]", validation.NewValidationError("required"))),
+			validation.NewFieldError("name", validation.NewValidationError("required")),
+			validation.NewValidationError("unknown field"),
+			validation.NewFieldError("items", errors.Join(
+				validation.NewFieldError("[2]", validation.NewValidationError("required")),
+				validation.NewFieldError("[2]", validation.NewValidationError("must be positive")),
+			)),
+		))
+		g.Expect(err).To(MatchError("unknown field\n" +
+			"items: [2]: must be positive\n" +
+			"items: [2]: required\n" +
+			"items: [10]: required\n" +
+			"name: required"))
+		g.Expect(validation.ToFormErrors(err)).To(HaveKeyWithValue("items[10]", []string{"required"}))
+	})
+
+	t.Run("orders messages with numbers by value", func(t *testing.T) {
+		g := NewWithT(t)
+		values := make([]int, 12)
+		values[2], values[10] = -1, -1
+		err := validation.Stable(validation.Validate(values, validation.Each(validation.Min(0))))
+		g.Expect(err).To(MatchError("index 2: must be at least 0\nindex 10: must be at least 0"))
+	})
+
+	t.Run("removes duplicates", func(t *testing.T) {
+		g := NewWithT(t)
+		err := validation.Stable(errors.Join(
+			validation.NewFieldError("email", validation.NewValidationError("required")),
+			validation.NewFieldError("email", validation.NewValidationError("required")),
+			validation.NewFieldError("name", validation.NewValidationError("required")),
+		))
+		g.Expect(err).To(MatchError("email: required\nname: required"))
+	})
+
+	t.Run("is independent of the input order", func(t *testing.T) {
+		g := NewWithT(t)
+		a := validation.NewFieldError("a", validation.NewValidationError("required"))
+		b := validation.NewFieldError("b", validation.NewValidationError("required"))
+		g.Expect(validation.Stable(errors.Join(a, b)).Error()).To(Equal(validation.Stable(errors.Join(b, a)).Error()))
+	})
+
+	t.Run("keeps system errors", func(t *testing.T) {
+		g := NewWithT(t)
+		err := validation.Stable(errors.Join(
+			validation.NewFieldError("name", validation.NewValidationError("required")),
+			validation.NewFieldError("email", errors.New("lookup failed")),
+		))
+		g.Expect(validation.IsSystemError(err)).To(BeTrue())
+		g.Expect(err).To(MatchError("email: lookup failed\nname: required"))
+	})
+}
@@ -12,7 +12,7 @@ func Required[T comparable]() Validator[T] {
 	return func(v T) error {
 		var zero T
 		if v == zero {
-			return NewValidationError("required")
+			return NewCodedError(CodeRequired, "required", nil)
 		}
 		return nil
 	}
@@ -33,7 +33,7 @@ func RequiredIf[T comparable](condition bool) Validator[T] {
 		}
 		var zero T
 		if v == zero {
-			return NewValidationError("required")
+			return NewCodedError(CodeRequired, "required", nil)
 		}
 		return nil
 	}
@@ -0,0 +1,84 @@
+package validation
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// RetryPolicy configures WithRetry.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of calls, including the first one. Values below 1 mean 1.
+	MaxAttempts int
+	// Backoff returns the delay before the given retry, starting at 1. Nil retries immediately.
+	Backoff func(retry int) time.Duration
+	// Retryable reports whether a system error is transient. Nil treats every system error
+	// (see IsSystemError) as transient.
+	Retryable func(err error) bool
+}
+
+// ExponentialBackoff returns a RetryPolicy.Backoff doubling the delay after each retry,
+// starting at base and capped at maximum.
+func ExponentialBackoff(base, maximum time.Duration) func(retry int) time.Duration {
+	return func(retry int) time.Duration {
+		delay := base
+		for i := 1; i < retry && delay < maximum; i++ {
+			delay *= 2
+		}
+		return min(delay, maximum)
+	}
+}
+
+// WithRetry retries a context-aware validator calling an external system when it fails with
+// a transient error, such as a timeout from a remote service.
+//
+// Validation errors are genuine failures and are returned immediately. Transient errors are
+// retried according to the policy; when attempts are exhausted, the last error is returned
+// wrapped as "after N attempts: <err>", so errors.Is and errors.As still match it and it is
+// still not a validation error, to be answered with a 5xx status.
+// Retries stop early when ctx is done.
+//
+// Example:
+//
+//	available := validation.WithRetry(usernameAvailable(client), validation.RetryPolicy{
+//	    MaxAttempts: 3,
+//	    Backoff:     validation.ExponentialBackoff(50*time.Millisecond, time.Second),
+//	})
+//	err := validation.ValidateCtx(ctx, input.Username, available)
+func WithRetry[T any](validator ValidatorCtx[T], policy RetryPolicy) ValidatorCtx[T] {
+	attempts := max(policy.MaxAttempts, 1)
+	retryable := policy.Retryable
+	if retryable == nil {
+		retryable = IsSystemError
+	}
+	return func(ctx context.Context, v T) error {
+		var err error
+		for attempt := 1; ; attempt++ {
+			err = validator(ctx, v)
+			if !IsSystemError(err) || !retryable(err) || ctx.Err() != nil {
+				return err
+			}
+			if attempt == attempts {
+				return fmt.Errorf("after %d attempts: %w", attempts, err)
+			}
+			if err := sleep(ctx, policy.Backoff, attempt); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// sleep waits for the backoff delay before the given retry, or until ctx is done.
+func sleep(ctx context.Context, backoff func(int) time.Duration, retry int) error {
+	if backoff == nil {
+		return nil
+	}
+	timer := time.NewTimer(backoff(retry))
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
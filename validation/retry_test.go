@@ -0,0 +1,92 @@
+package validation_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/quantumcycle/protego/validation"
+)
+
+func TestWithRetry(t *testing.T) {
+	ctx := context.Background()
+	unavailable := errors.New("service unavailable")
+
+	// flaky fails with the given errors, then succeeds.
+	flaky := func(calls *int, errs ...error) validation.ValidatorCtx[string] {
+		return func(context.Context, string) error {
+			*calls++
+			if *calls <= len(errs) {
+				return errs[*calls-1]
+			}
+			return nil
+		}
+	}
+
+	t.Run("retries transient errors", func(t *testing.T) {
+		g := NewWithT(t)
+		calls := 0
+		validator := validation.WithRetry(flaky(&calls, unavailable, unavailable), validation.RetryPolicy{MaxAttempts: 3})
+		g.Expect(validation.ValidateCtx(ctx, "john", validator)).To(Succeed())
+		g.Expect(calls).To(Equal(3))
+	})
+
+	t.Run("returns validation errors immediately", func(t *testing.T) {
+		g := NewWithT(t)
+		calls := 0
+		taken := validation.NewValidationError("already taken")
+		validator := validation.WithRetry(flaky(&calls, taken), validation.RetryPolicy{MaxAttempts: 3})
+		g.Expect(validation.ValidateCtx(ctx, "john", validator)).To(MatchError("already taken"))
+		g.Expect(calls).To(Equal(1))
+	})
+
+	t.Run("wraps the last error as a system error when attempts are exhausted", func(t *testing.T) {
+		g := NewWithT(t)
+		calls := 0
+		validator := validation.WithRetry(flaky(&calls, unavailable, unavailable, unavailable), validation.RetryPolicy{MaxAttempts: 2})
+		err := validation.ValidateCtx(ctx, "john", validator)
+		g.Expect(err).To(MatchError("after 2 attempts: service unavailable"))
+		g.Expect(errors.Is(err, unavailable)).To(BeTrue())
+		g.Expect(validation.IsValidationError(err)).To(BeFalse())
+		g.Expect(validation.IsSystemError(err)).To(BeTrue())
+		g.Expect(calls).To(Equal(2))
+	})
+
+	t.Run("does not retry errors the policy rejects", func(t *testing.T) {
+		g := NewWithT(t)
+		calls := 0
+		validator := validation.WithRetry(flaky(&calls, unavailable), validation.RetryPolicy{
+			MaxAttempts: 3,
+			Retryable:   func(err error) bool { return false },
+		})
+		g.Expect(validation.ValidateCtx(ctx, "john", validator)).To(Equal(unavailable))
+		g.Expect(calls).To(Equal(1))
+	})
+
+	t.Run("stops waiting when the context is done", func(t *testing.T) {
+		g := NewWithT(t)
+		calls := 0
+		validator := validation.WithRetry(flaky(&calls, unavailable), validation.RetryPolicy{
+			MaxAttempts: 3,
+			Backoff:     func(int) time.Duration { return time.Hour },
+		})
+		timeoutCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+		defer cancel()
+		err := validator(timeoutCtx, "john")
+		g.Expect(errors.Is(err, context.DeadlineExceeded)).To(BeTrue())
+		g.Expect(calls).To(Equal(1))
+	})
+}
+
+func TestExponentialBackoff(t *testing.T) {
+	g := NewWithT(t)
+	backoff := validation.ExponentialBackoff(100*time.Millisecond, time.Second)
+	g.Expect(backoff(1)).To(Equal(100 * time.Millisecond))
+	g.Expect(backoff(2)).To(Equal(200 * time.Millisecond))
+	g.Expect(backoff(4)).To(Equal(800 * time.Millisecond))
+	g.Expect(backoff(5)).To(Equal(time.Second))
+	g.Expect(backoff(50)).To(Equal(time.Second))
+}
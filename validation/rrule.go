@@ -0,0 +1,159 @@
+package validation
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	rruleFreqs = []string{"SECONDLY", "MINUTELY", "HOURLY", "DAILY", "WEEKLY", "MONTHLY", "YEARLY"}
+	rruleDays  = []string{"MO", "TU", "WE", "TH", "FR", "SA", "SU"}
+	// rruleLists maps the BYxxx parts holding integer lists to their bounds; signed parts
+	// accept negative values counting from the end.
+	rruleLists = map[string]struct {
+		min, max int
+		signed   bool
+	}{
+		"BYSECOND":   {0, 60, false},
+		"BYMINUTE":   {0, 59, false},
+		"BYHOUR":     {0, 23, false},
+		"BYMONTHDAY": {1, 31, true},
+		"BYYEARDAY":  {1, 366, true},
+		"BYWEEKNO":   {1, 53, true},
+		"BYMONTH":    {1, 12, false},
+		"BYSETPOS":   {1, 366, true},
+	}
+)
+
+// IsRRule validates an iCalendar recurrence rule (RFC 5545, section 3.3.10), such as
+// "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE;COUNT=10", with or without the "RRULE:" prefix.
+// FREQ is required, COUNT and UNTIL are mutually exclusive, values must be in range and
+// BYxxx parts must be compatible with the frequency.
+//
+// Example:
+//
+//	validation.Validate(event.Recurrence, validation.IsRRule())
+func IsRRule() Validator[string] {
+	return func(v string) error {
+		if err := checkRRule(strings.TrimPrefix(v, "RRULE:")); err != "" {
+			return NewValidationError("must be a valid RRULE: " + err)
+		}
+		return nil
+	}
+}
+
+// checkRRule returns a description of the first problem of rule, or "" if it is valid.
+func checkRRule(rule string) string {
+	parts := make(map[string]string)
+	var names []string
+	for _, part := range strings.Split(rule, ";") {
+		name, value, ok := strings.Cut(part, "=")
+		if !ok || value == "" {
+			return fmt.Sprintf("%q is not a NAME=VALUE part", part)
+		}
+		if _, dup := parts[name]; dup {
+			return fmt.Sprintf("%s is repeated", name)
+		}
+		parts[name] = value
+		names = append(names, name)
+	}
+
+	freq, ok := parts["FREQ"]
+	if !ok {
+		return "FREQ is required"
+	}
+	if !slices.Contains(rruleFreqs, freq) {
+		return fmt.Sprintf("FREQ %q is not a valid frequency", freq)
+	}
+	if _, hasCount := parts["COUNT"]; hasCount && parts["UNTIL"] != "" {
+		return "COUNT and UNTIL are mutually exclusive"
+	}
+
+	for _, name := range names {
+		if msg := checkRRulePart(name, parts[name], freq, parts); msg != "" {
+			return msg
+		}
+	}
+
+	if _, ok := parts["BYSETPOS"]; ok {
+		hasOther := false
+		for _, name := range names {
+			hasOther = hasOther || (strings.HasPrefix(name, "BY") && name != "BYSETPOS")
+		}
+		if !hasOther {
+			return "BYSETPOS requires another BYxxx part"
+		}
+	}
+	return ""
+}
+
+func checkRRulePart(name, value, freq string, parts map[string]string) string {
+	switch name {
+	case "FREQ":
+		return ""
+	case "COUNT", "INTERVAL":
+		if n, err := strconv.Atoi(value); err != nil || n < 1 || value[0] == '+' {
+			return fmt.Sprintf("%s must be a positive integer", name)
+		}
+	case "UNTIL":
+		if !isRRuleDate(value) {
+			return "UNTIL must be a date (YYYYMMDD) or a date-time (YYYYMMDDTHHMMSS[Z])"
+		}
+	case "WKST":
+		if !slices.Contains(rruleDays, value) {
+			return fmt.Sprintf("WKST %q is not a weekday", value)
+		}
+	case "BYDAY":
+		ordinalAllowed := freq == "MONTHLY" || (freq == "YEARLY" && parts["BYWEEKNO"] == "")
+		for _, day := range strings.Split(value, ",") {
+			ordinal := strings.TrimRight(day, "ABCDEFGHIJKLMNOPQRSTUVWXYZ")
+			if !slices.Contains(rruleDays, day[len(ordinal):]) {
+				return fmt.Sprintf("BYDAY %q is not a weekday", day)
+			}
+			if ordinal == "" {
+				continue
+			}
+			if !ordinalAllowed {
+				return fmt.Sprintf("BYDAY %q cannot have an ordinal with FREQ=%s", day, freq)
+			}
+			if n, err := strconv.Atoi(ordinal); err != nil || n == 0 || n < -53 || n > 53 {
+				return fmt.Sprintf("BYDAY %q has an invalid ordinal", day)
+			}
+		}
+	default:
+		bounds, ok := rruleLists[name]
+		if !ok {
+			return fmt.Sprintf("%s is not a recurrence rule part", name)
+		}
+		switch {
+		case name == "BYWEEKNO" && freq != "YEARLY":
+			return "BYWEEKNO requires FREQ=YEARLY"
+		case name == "BYYEARDAY" && (freq == "DAILY" || freq == "WEEKLY" || freq == "MONTHLY"):
+			return fmt.Sprintf("BYYEARDAY cannot be used with FREQ=%s", freq)
+		case name == "BYMONTHDAY" && freq == "WEEKLY":
+			return "BYMONTHDAY cannot be used with FREQ=WEEKLY"
+		}
+		for _, item := range strings.Split(value, ",") {
+			n, err := strconv.Atoi(item)
+			if bounds.signed && n < 0 {
+				n = -n
+			}
+			if err != nil || n < bounds.min || n > bounds.max || (!bounds.signed && strings.HasPrefix(item, "-")) {
+				return fmt.Sprintf("%s value %q is out of range", name, item)
+			}
+		}
+	}
+	return ""
+}
+
+func isRRuleDate(v string) bool {
+	for _, layout := range []string{"20060102", "20060102T150405", "20060102T150405Z"} {
+		if _, err := time.Parse(layout, v); err == nil {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,56 @@
+package validation_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/quantumcycle/protego/validation"
+)
+
+func TestIsRRule(t *testing.T) {
+
+	t.Run("passes with valid rules", func(t *testing.T) {
+		g := NewWithT(t)
+		for _, rule := range []string{
+			"FREQ=DAILY",
+			"RRULE:FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE,FR;COUNT=10",
+			"FREQ=MONTHLY;BYDAY=-1FR",
+			"FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1",
+			"FREQ=YEARLY;BYMONTH=1,2;BYMONTHDAY=-1;UNTIL=20301231T235959Z",
+			"FREQ=YEARLY;BYWEEKNO=20;BYDAY=MO;WKST=SU",
+			"FREQ=HOURLY;BYHOUR=9,10,11;BYMINUTE=0,30;UNTIL=20301231",
+		} {
+			g.Expect(validation.Validate(rule, validation.IsRRule())).To(Succeed(), rule)
+		}
+	})
+
+	t.Run("fails with invalid rules", func(t *testing.T) {
+		g := NewWithT(t)
+		for rule, msg := range map[string]string{
+			"":                                  `"" is not a NAME=VALUE part`,
+			"INTERVAL=2":                        "FREQ is required",
+			"FREQ=FORTNIGHTLY":                  `FREQ "FORTNIGHTLY" is not a valid frequency`,
+			"FREQ=DAILY;FREQ=WEEKLY":            "FREQ is repeated",
+			"FREQ=DAILY;COUNT=5;UNTIL=20301231": "COUNT and UNTIL are mutually exclusive",
+			"FREQ=DAILY;INTERVAL=0":             "INTERVAL must be a positive integer",
+			"FREQ=DAILY;UNTIL=2030-12-31":       "UNTIL must be a date (YYYYMMDD) or a date-time (YYYYMMDDTHHMMSS[Z])",
+			"FREQ=WEEKLY;BYDAY=MO,XX":           `BYDAY "XX" is not a weekday`,
+			"FREQ=WEEKLY;BYDAY=1MO":             `BYDAY "1MO" cannot have an ordinal with FREQ=WEEKLY`,
+			"FREQ=MONTHLY;BYDAY=6X":             `BYDAY "6X" is not a weekday`,
+			"FREQ=MONTHLY;BYDAY=0MO":            `BYDAY "0MO" has an invalid ordinal`,
+			"FREQ=YEARLY;BYMONTH=13":            `BYMONTH value "13" is out of range`,
+			"FREQ=MONTHLY;BYMONTHDAY=-32":       `BYMONTHDAY value "-32" is out of range`,
+			"FREQ=DAILY;BYHOUR=-1":              `BYHOUR value "-1" is out of range`,
+			"FREQ=MONTHLY;BYWEEKNO=1":           "BYWEEKNO requires FREQ=YEARLY",
+			"FREQ=WEEKLY;BYMONTHDAY=1":          "BYMONTHDAY cannot be used with FREQ=WEEKLY",
+			"FREQ=MONTHLY;BYYEARDAY=100":        "BYYEARDAY cannot be used with FREQ=MONTHLY",
+			"FREQ=MONTHLY;BYSETPOS=1":           "BYSETPOS requires another BYxxx part",
+			"FREQ=DAILY;COLOR=RED":              "COLOR is not a recurrence rule part",
+			"FREQ=DAILY;WKST=MONDAY":            `WKST "MONDAY" is not a weekday`,
+			"FREQ=YEARLY;BYWEEKNO=1;BYDAY=1MO":  `BYDAY "1MO" cannot have an ordinal with FREQ=YEARLY`,
+		} {
+			g.Expect(validation.Validate(rule, validation.IsRRule())).To(MatchError("must be a valid RRULE: "+msg), rule)
+		}
+	})
+}
@@ -0,0 +1,27 @@
+package validation
+
+import "math/rand"
+
+// Sampled returns a validator applying validator to a random fraction rate of the values,
+// and passing the others, for high-volume streams such as telemetry pipelines where
+// validating everything is too expensive. A rate of 0 or less validates nothing, and a rate
+// of 1 or more everything. Invalid values are only caught with probability rate: sample
+// where a few invalid values getting through is acceptable, such as to monitor the quality
+// of a feed.
+//
+// Example:
+//
+//	validate := validation.Sampled(0.01, validation.Custom(checkSpan)) // 1% of spans
+func Sampled[T any](rate float64, validator Validator[T]) Validator[T] {
+	return func(v T) error {
+		if !sample(rate) {
+			return nil
+		}
+		return validator(v)
+	}
+}
+
+// sample reports whether to validate a value, with probability rate.
+func sample(rate float64) bool {
+	return rate >= 1 || (rate > 0 && rand.Float64() < rate)
+}
@@ -0,0 +1,44 @@
+package validation_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/quantumcycle/protego/validation"
+)
+
+func TestSampled(t *testing.T) {
+	calls := 0
+	counting := func(v int) error {
+		calls++
+		return validation.Validate(v, validation.Positive[int]())
+	}
+
+	t.Run("validates every value at rate 1", func(t *testing.T) {
+		g := NewWithT(t)
+		calls = 0
+		validate := validation.Sampled(1, counting)
+		g.Expect(validate(-1)).To(MatchError("must be positive"))
+		g.Expect(validate(1)).To(Succeed())
+		g.Expect(calls).To(Equal(2))
+	})
+
+	t.Run("passes every value at rate 0", func(t *testing.T) {
+		g := NewWithT(t)
+		calls = 0
+		validate := validation.Sampled(0, counting)
+		g.Expect(validate(-1)).To(Succeed())
+		g.Expect(calls).To(BeZero())
+	})
+
+	t.Run("validates a fraction of the values", func(t *testing.T) {
+		g := NewWithT(t)
+		calls = 0
+		validate := validation.Sampled(0.25, counting)
+		for i := 0; i < 10000; i++ {
+			_ = validate(i)
+		}
+		g.Expect(calls).To(BeNumerically("~", 2500, 300))
+	})
+}
@@ -0,0 +1,113 @@
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// TimeRange is a range of the day between two "HH:MM" times. End may be "24:00".
+type TimeRange struct {
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+// WeeklySchedule maps lower-case English day names ("monday" to "sunday") to the time
+// ranges of the day, such as opening hours.
+type WeeklySchedule map[string][]TimeRange
+
+var weekDays = []string{"monday", "tuesday", "wednesday", "thursday", "friday", "saturday", "sunday"}
+
+// IsWeeklySchedule validates a weekly schedule: days must be valid day names and each range
+// must be well-formed, within 00:00–24:00, end after it starts and not overlap the other
+// ranges of the day. Ranges crossing midnight must be split across both days. A positive
+// maxSegmentsPerDay limits the number of ranges per day.
+// All failures are returned joined, each attributed to its day.
+//
+// Example:
+//
+//	validation.Validate(store.OpeningHours, validation.IsWeeklySchedule(3))
+func IsWeeklySchedule(maxSegmentsPerDay int) Validator[WeeklySchedule] {
+	return func(s WeeklySchedule) error {
+		days := make([]string, 0, len(s))
+		for day := range s {
+			days = append(days, day)
+		}
+		sort.Slice(days, func(i, j int) bool {
+			if a, b := dayIndex(days[i]), dayIndex(days[j]); a != b {
+				return a < b
+			}
+			return days[i] < days[j]
+		})
+
+		var errs []error
+		for _, day := range days {
+			errs = append(errs, NewFieldError(day, validateDay(day, s[day], maxSegmentsPerDay)))
+		}
+		return errors.Join(errs...)
+	}
+}
+
+func validateDay(day string, ranges []TimeRange, maxSegments int) error {
+	if dayIndex(day) == len(weekDays) {
+		return NewValidationError("must be a day of the week")
+	}
+	if maxSegments > 0 && len(ranges) > maxSegments {
+		return NewValidationError(fmt.Sprintf("must have at most %d time ranges", maxSegments))
+	}
+
+	type minutes struct{ start, end int }
+	parsed := make([]minutes, len(ranges))
+	for i, r := range ranges {
+		start, ok := clockMinutes(r.Start)
+		end, endOK := clockMinutes(r.End)
+		switch {
+		case !ok || start == 24*60:
+			return NewValidationError(fmt.Sprintf("range %d: start must be a time between 00:00 and 23:59", i))
+		case !endOK:
+			return NewValidationError(fmt.Sprintf("range %d: end must be a time between 00:00 and 24:00", i))
+		case end <= start:
+			return NewValidationError(fmt.Sprintf("range %d: must end after it starts", i))
+		}
+		parsed[i] = minutes{start, end}
+	}
+
+	order := make([]int, len(ranges))
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(a, b int) bool { return parsed[order[a]].start < parsed[order[b]].start })
+	for k := 1; k < len(order); k++ {
+		prev, cur := order[k-1], order[k]
+		if parsed[cur].start < parsed[prev].end {
+			return NewValidationError(fmt.Sprintf("ranges %s-%s and %s-%s overlap",
+				ranges[prev].Start, ranges[prev].End, ranges[cur].Start, ranges[cur].End))
+		}
+	}
+	return nil
+}
+
+// dayIndex returns the position of day in the week, or len(weekDays) for unknown days.
+func dayIndex(day string) int {
+	for i, d := range weekDays {
+		if d == day {
+			return i
+		}
+	}
+	return len(weekDays)
+}
+
+// clockMinutes parses a "HH:MM" time between 00:00 and 24:00 into minutes since midnight.
+func clockMinutes(s string) (int, bool) {
+	hh, mm, ok := strings.Cut(s, ":")
+	if !ok || len(hh) != 2 || len(mm) != 2 || strings.Trim(hh+mm, "0123456789") != "" {
+		return 0, false
+	}
+	h := int(hh[0]-'0')*10 + int(hh[1]-'0')
+	m := int(mm[0]-'0')*10 + int(mm[1]-'0')
+	if m > 59 || h > 24 || (h == 24 && m != 0) {
+		return 0, false
+	}
+	return h*60 + m, true
+}
@@ -0,0 +1,56 @@
+package validation_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/quantumcycle/protego/validation"
+)
+
+func TestIsWeeklySchedule(t *testing.T) {
+
+	t.Run("passes with well-formed schedules", func(t *testing.T) {
+		g := NewWithT(t)
+		schedule := validation.WeeklySchedule{
+			"monday":   {{Start: "13:00", End: "18:00"}, {Start: "09:00", End: "12:00"}},
+			"saturday": {{Start: "00:00", End: "24:00"}},
+			"sunday":   {},
+		}
+		g.Expect(validation.Validate(schedule, validation.IsWeeklySchedule(2))).To(Succeed())
+	})
+
+	t.Run("reports every invalid day in week order", func(t *testing.T) {
+		g := NewWithT(t)
+		schedule := validation.WeeklySchedule{
+			"sunday":    {{Start: "10:00", End: "09:00"}},
+			"funday":    {{Start: "10:00", End: "11:00"}},
+			"monday":    {{Start: "09:00", End: "12:00"}, {Start: "11:30", End: "14:00"}},
+			"tuesday":   {{Start: "9:00", End: "12:00"}},
+			"wednesday": {{Start: "09:00", End: "24:30"}},
+			"thursday":  {{Start: "24:00", End: "24:00"}},
+		}
+		err := validation.Validate(schedule, validation.IsWeeklySchedule(0))
+		g.Expect(err).To(MatchError("monday: ranges 09:00-12:00 and 11:30-14:00 overlap\n" +
+			"tuesday: range 0: start must be a time between 00:00 and 23:59\n" +
+			"wednesday: range 0: end must be a time between 00:00 and 24:00\n" +
+			"thursday: range 0: start must be a time between 00:00 and 23:59\n" +
+			"sunday: range 0: must end after it starts\n" +
+			"funday: must be a day of the week"))
+		g.Expect(validation.IsValidationError(err)).To(BeTrue())
+	})
+
+	t.Run("limits the number of ranges per day", func(t *testing.T) {
+		g := NewWithT(t)
+		schedule := validation.WeeklySchedule{
+			"friday": {{Start: "08:00", End: "09:00"}, {Start: "10:00", End: "11:00"}, {Start: "12:00", End: "13:00"}},
+		}
+		g.Expect(validation.Validate(schedule, validation.IsWeeklySchedule(2))).To(MatchError("friday: must have at most 2 time ranges"))
+	})
+
+	t.Run("allows adjacent ranges", func(t *testing.T) {
+		g := NewWithT(t)
+		schedule := validation.WeeklySchedule{"monday": {{Start: "09:00", End: "12:00"}, {Start: "12:00", End: "14:00"}}}
+		g.Expect(validation.Validate(schedule, validation.IsWeeklySchedule(0))).To(Succeed())
+	})
+}
@@ -0,0 +1,198 @@
+package validation
+
+import (
+	"slices"
+	"time"
+)
+
+// Schema validates map[string]any payloads, such as decoded JSON objects, like
+// ValidateAnyMap, with named rules that can be inspected: unlike validators, which are
+// opaque functions, the rules of two schemas can be compared (see DiffRules).
+//
+// Example:
+//
+//	var userSchema = validation.NewSchema(
+//	    validation.Key("name", validation.MinLengthRule(3), validation.MaxLengthRule(50)).Required(),
+//	    validation.Key("role", validation.InRule("admin", "member")),
+//	)
+//
+//	err := userSchema.Validate(payload)
+type Schema struct {
+	keys       []SchemaKey
+	allowExtra bool
+	strictness Strictness
+	budget     Budget
+	cache      *resultCache
+	mapRules   []MapKeyRule[any]
+}
+
+// SchemaKey is the definition of a key of a Schema, created with Key.
+type SchemaKey struct {
+	name      string
+	required  bool
+	nullable  bool
+	immutable bool
+	rules     []Rule
+}
+
+// Rule is a named validator of the values of a schema key, with the parameters that
+// configure it, such as {"min": 3} for a minimum length.
+type Rule struct {
+	name      string
+	params    map[string]any
+	validator Validator[any]
+	lenient   Validator[any]  // validator of Lenient schemas, if different
+	regexes   func(v any) int // number of regex executions validating v, if any
+}
+
+// NewSchema creates a schema from its keys. Keys not defined are rejected, unless
+// AllowExtra is called. The schema is Strict, see WithStrictness.
+func NewSchema(keys ...SchemaKey) *Schema {
+	return &Schema{keys: keys, strictness: Strict, mapRules: mapRules(keys, Strict)}
+}
+
+// AllowExtra returns a copy of the schema accepting keys it does not define.
+func (s *Schema) AllowExtra() *Schema {
+	c := s.clone()
+	c.allowExtra = true
+	return c
+}
+
+// withKey returns a copy of the schema with an additional key.
+func (s *Schema) withKey(k SchemaKey) *Schema {
+	c := NewSchema(append(slices.Clip(s.keys), k)...).WithStrictness(s.strictness)
+	c.allowExtra, c.budget = s.allowExtra, s.budget
+	return c
+}
+
+// Validate validates m against the rules of its keys, like ValidateAnyMap.
+func (s *Schema) Validate(m map[string]any) error {
+	if s.budget != (Budget{}) {
+		if err := s.checkBudget(m); err != nil {
+			return err
+		}
+	}
+	if s.cache != nil {
+		// normalized first, as cached results skip the write-back of the rules
+		s.normalize(m)
+		return s.cache.validate(m, s.validateKeys)
+	}
+	return s.validateKeys(m)
+}
+
+func (s *Schema) validateKeys(m map[string]any) error {
+	return validateMap(m, s.acceptsExtra(), s.mapRules)
+}
+
+// Key defines a schema key validated by rules, which are applied in order when the key is
+// present.
+func Key(name string, rules ...Rule) SchemaKey {
+	return SchemaKey{name: name, rules: rules}
+}
+
+// Required marks the key as required.
+func (k SchemaKey) Required() SchemaKey {
+	k.required = true
+	return k
+}
+
+// Nullable marks the key as accepting null, whose rules are then skipped. In a merge patch
+// (see ValidatePatch), null removes the key, which only nullable keys allow.
+func (k SchemaKey) Nullable() SchemaKey {
+	k.nullable = true
+	return k
+}
+
+// Immutable marks the key as set on creation only: merge patches (see ValidatePatch) cannot
+// change it. It has no effect on Validate.
+func (k SchemaKey) Immutable() SchemaKey {
+	k.immutable = true
+	return k
+}
+
+func skipNull(validator Validator[any]) Validator[any] {
+	return func(v any) error {
+		if v == nil {
+			return nil
+		}
+		return validator(v)
+	}
+}
+
+// NewRule creates a rule named name, such as "min_length", validating values with
+// validator. params are the parameters of the rule, reported by DiffRules: by convention,
+// "min" and "max" hold bounds and "values" the allowed values, so that DiffRules can tell
+// tightened rules from loosened ones.
+//
+// Example:
+//
+//	validation.NewRule("prefix", map[string]any{"prefix": "sku-"}, validation.StringValidator(validation.StartsWith("sku-")))
+func NewRule(name string, params map[string]any, validator Validator[any]) Rule {
+	return Rule{name: name, params: params, validator: validator}
+}
+
+// Name returns the name of the rule.
+func (r Rule) Name() string {
+	return r.name
+}
+
+// Params returns the parameters of the rule.
+func (r Rule) Params() map[string]any {
+	return r.params
+}
+
+// MinLengthRule is MinLength as a rule, for string values.
+func MinLengthRule(minimum int) Rule {
+	return NewRule("min_length", map[string]any{"min": minimum}, StringValidator(MinLength(minimum)))
+}
+
+// MaxLengthRule is MaxLength as a rule, for string values.
+func MaxLengthRule(maximum int) Rule {
+	return NewRule("max_length", map[string]any{"max": maximum}, StringValidator(MaxLength(maximum)))
+}
+
+// RangeRule is Range as a rule, for number values.
+func RangeRule(minimum, maximum float64) Rule {
+	return NewRule("range", map[string]any{"min": minimum, "max": maximum}, FloatValidator(Range(minimum, maximum)))
+}
+
+// InRule is In as a case-sensitive rule, for string values.
+func InRule(values ...string) Rule {
+	return NewRule("in", map[string]any{"values": values}, StringValidator(In(false, values...)))
+}
+
+// PatternRule is MatchesPattern as a rule, for string values.
+func PatternRule(pattern string) Rule {
+	r := NewRule("pattern", map[string]any{"pattern": pattern}, StringValidator(MatchesPattern(pattern)))
+	r.regexes = func(v any) int {
+		if _, ok := v.(string); ok {
+			return 1
+		}
+		return 0
+	}
+	return r
+}
+
+// DateTimeRule is IsRFC3339DateTime as a rule, for string values. Lenient schemas also
+// accept a space instead of the "T", and date-times without offset or time, such as
+// "2024-03-01 12:30:00" and "2024-03-01", which are taken as UTC.
+func DateTimeRule() Rule {
+	r := NewRule("date_time", nil, StringValidator(IsRFC3339DateTime()))
+	r.lenient = StringValidator(func(v string) error {
+		for _, layout := range lenientDateTimeLayouts {
+			if _, err := time.Parse(layout, v); err == nil {
+				return nil
+			}
+		}
+		return NewValidationError("must be a valid date-time")
+	})
+	return r
+}
+
+var lenientDateTimeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02 15:04:05Z07:00",
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	time.DateOnly,
+}
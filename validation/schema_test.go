@@ -0,0 +1,59 @@
+package validation_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/quantumcycle/protego/validation"
+)
+
+func TestSchema(t *testing.T) {
+	schema := validation.NewSchema(
+		validation.Key("name", validation.MinLengthRule(3), validation.MaxLengthRule(10)).Required(),
+		validation.Key("role", validation.InRule("admin", "member")),
+		validation.Key("score", validation.RangeRule(0, 100)),
+	)
+
+	t.Run("accepts valid payloads", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(schema.Validate(map[string]any{"name": "alice", "role": "admin", "score": 42.0})).To(Succeed())
+		g.Expect(schema.Validate(map[string]any{"name": "bob"})).To(Succeed())
+	})
+
+	t.Run("applies the rules of present keys", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(schema.Validate(map[string]any{"name": "al"})).To(MatchError(ContainSubstring("name")))
+		g.Expect(schema.Validate(map[string]any{"name": "alice", "role": "owner"})).To(MatchError(ContainSubstring("role")))
+		g.Expect(schema.Validate(map[string]any{"name": "alice", "score": 101.0})).To(MatchError(ContainSubstring("score")))
+	})
+
+	t.Run("rejects missing required keys", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(schema.Validate(map[string]any{"role": "admin"})).To(MatchError(`key "name" is required`))
+	})
+
+	t.Run("rejects extra keys unless allowed", func(t *testing.T) {
+		g := NewWithT(t)
+		payload := map[string]any{"name": "alice", "team": "core"}
+		g.Expect(schema.Validate(payload)).To(MatchError(`key "team" not expected`))
+		g.Expect(schema.AllowExtra().Validate(payload)).To(Succeed())
+		g.Expect(schema.Validate(payload)).To(HaveOccurred())
+	})
+
+	t.Run("exposes rule names and params", func(t *testing.T) {
+		g := NewWithT(t)
+		rule := validation.MinLengthRule(3)
+		g.Expect(rule.Name()).To(Equal("min_length"))
+		g.Expect(rule.Params()).To(Equal(map[string]any{"min": 3}))
+		g.Expect(validation.InRule("a", "b").Params()).To(Equal(map[string]any{"values": []string{"a", "b"}}))
+	})
+
+	t.Run("supports custom rules", func(t *testing.T) {
+		g := NewWithT(t)
+		schema := validation.NewSchema(validation.Key("sku",
+			validation.NewRule("prefix", map[string]any{"prefix": "sku-"}, validation.StringValidator(validation.StartsWith("sku-")))))
+		g.Expect(schema.Validate(map[string]any{"sku": "sku-1"})).To(Succeed())
+		g.Expect(schema.Validate(map[string]any{"sku": "1"})).To(HaveOccurred())
+	})
+}
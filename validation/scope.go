@@ -0,0 +1,64 @@
+package validation
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Codes of the errors of IsScopeList, whose "scope" parameter is the offending scope, for
+// clients to highlight it.
+const (
+	CodeScopeNotAllowed = "scope_not_allowed"
+	CodeScopeDuplicate  = "scope_duplicate"
+)
+
+// IsScopeList validates an OAuth 2.0 scope parameter (RFC 6749, section 3.3): scopes of
+// printable ASCII characters other than space, double quote and backslash, separated by
+// single spaces, each one of allowedScopes and none repeated. An empty allowedScopes
+// accepts any scope; a zero maximum any number of scopes. Errors name the first offending
+// scope.
+//
+// Example:
+//
+//	validation.Validate(r.FormValue("scope"), validation.IsScopeList([]string{"openid", "profile", "email", "orders:read"}, 10))
+func IsScopeList(allowedScopes []string, maximum int) Validator[string] {
+	allowed := make(map[string]struct{}, len(allowedScopes))
+	for _, scope := range allowedScopes {
+		allowed[scope] = struct{}{}
+	}
+	return func(v string) error {
+		scopes := strings.Split(v, " ")
+		for _, scope := range scopes {
+			if !isScopeToken(scope) {
+				return NewValidationError("must be scopes separated by single spaces")
+			}
+		}
+		if maximum > 0 && len(scopes) > maximum {
+			return NewValidationError(fmt.Sprintf("must not have more than %d scopes", maximum))
+		}
+		seen := make(map[string]struct{}, len(scopes))
+		for _, scope := range scopes {
+			if _, ok := allowed[scope]; !ok && len(allowed) > 0 {
+				return NewCodedError(CodeScopeNotAllowed, fmt.Sprintf("scope %q is not allowed", scope), map[string]any{"scope": scope})
+			}
+			if _, ok := seen[scope]; ok {
+				return NewCodedError(CodeScopeDuplicate, fmt.Sprintf("scope %q is repeated", scope), map[string]any{"scope": scope})
+			}
+			seen[scope] = struct{}{}
+		}
+		return nil
+	}
+}
+
+// isScopeToken reports whether s is a scope-token of RFC 6749.
+func isScopeToken(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if c := s[i]; c <= ' ' || c > '~' || c == '"' || c == '\\' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,63 @@
+package validation_test
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/quantumcycle/protego/validation"
+)
+
+func TestIsScopeList(t *testing.T) {
+	validator := validation.IsScopeList([]string{"openid", "profile", "email", "orders:read"}, 3)
+	scopeError := func(err error) (string, any) {
+		var valErr *validation.Error
+		if !errors.As(err, &valErr) {
+			return "", nil
+		}
+		return valErr.Code(), valErr.Params()["scope"]
+	}
+
+	t.Run("accepts allowed scopes", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(validator("openid")).To(Succeed())
+		g.Expect(validator("openid orders:read email")).To(Succeed())
+	})
+
+	t.Run("rejects scopes not allowed", func(t *testing.T) {
+		g := NewWithT(t)
+		err := validator("openid admin")
+		g.Expect(err).To(MatchError(`scope "admin" is not allowed`))
+		code, scope := scopeError(err)
+		g.Expect(code).To(Equal(validation.CodeScopeNotAllowed))
+		g.Expect(scope).To(Equal("admin"))
+	})
+
+	t.Run("rejects repeated scopes", func(t *testing.T) {
+		g := NewWithT(t)
+		err := validator("email openid email")
+		g.Expect(err).To(MatchError(`scope "email" is repeated`))
+		code, scope := scopeError(err)
+		g.Expect(code).To(Equal(validation.CodeScopeDuplicate))
+		g.Expect(scope).To(Equal("email"))
+	})
+
+	t.Run("rejects too many scopes", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(validator("openid profile email orders:read")).To(MatchError("must not have more than 3 scopes"))
+	})
+
+	t.Run("rejects malformed lists", func(t *testing.T) {
+		g := NewWithT(t)
+		for _, v := range []string{"", " openid", "openid ", "openid  email", "openid\temail", `say"hi"`, "café"} {
+			g.Expect(validator(v)).To(MatchError("must be scopes separated by single spaces"), v)
+		}
+	})
+
+	t.Run("accepts any scope without allowlist", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(validation.IsScopeList(nil, 0)("https://www.googleapis.com/auth/drive.readonly openid")).To(Succeed())
+		g.Expect(validation.IsScopeList(nil, 0)("openid openid")).To(MatchError(`scope "openid" is repeated`))
+	})
+}
@@ -0,0 +1,164 @@
+package validation
+
+import (
+	_ "embed"
+	"fmt"
+	"math"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// The validators of this file are meant for passwords, API keys and other secrets: their
+// errors never include the offending value, even with SetIncludeValues enabled.
+
+//go:embed common_passwords.txt
+var commonPasswordsList string
+
+var commonPasswords = func() map[string]struct{} {
+	words := wordLines(commonPasswordsList)
+	set := make(map[string]struct{}, len(words))
+	for _, w := range words {
+		set[w] = struct{}{}
+	}
+	return set
+}()
+
+// IsStrongSecret validates that a secret has an estimated entropy of at least minEntropyBits.
+// The estimate is the length multiplied by the size of the character classes used (lower
+// case, upper case, digits, symbols), in bits, not counting the characters of repeated
+// blocks and of long runs of repeated or consecutive characters, so that secrets such as
+// "aaaaaaaa" or "abcabcabc" score low. Random keys of minEntropyBits, such as 128-bit keys
+// written as 32 hexadecimal or 22 base64url characters, pass.
+//
+// Example:
+//
+//	validation.Validate(apiKey, validation.IsStrongSecret(128))
+func IsStrongSecret(minEntropyBits int) Validator[string] {
+	return func(v string) error {
+		if secretEntropy(v) < float64(minEntropyBits) {
+			return newSensitiveError(fmt.Sprintf("must have at least %d bits of entropy", minEntropyBits))
+		}
+		return nil
+	}
+}
+
+// NotCommonPassword validates that a password is not in an embedded list of 808 of the most
+// frequently leaked passwords, not a full top 10,000 list. The comparison ignores case. For
+// a broader check, see NotPwnedPassword.
+//
+// Example:
+//
+//	validation.Validate(password, validation.MinLength(10), validation.NotCommonPassword())
+func NotCommonPassword() Validator[string] {
+	return func(v string) error {
+		if _, ok := commonPasswords[strings.ToLower(v)]; ok {
+			return newSensitiveError("must not be a commonly used password")
+		}
+		return nil
+	}
+}
+
+// NoWhitespaceEdges validates that a string neither starts nor ends with whitespace, which
+// usually betrays a secret copied with surrounding spaces or a trailing newline.
+//
+// Example:
+//
+//	validation.Validate(token, validation.NoWhitespaceEdges())
+func NoWhitespaceEdges() Validator[string] {
+	return func(v string) error {
+		first, _ := utf8.DecodeRuneInString(v)
+		last, _ := utf8.DecodeLastRuneInString(v)
+		if v != "" && (unicode.IsSpace(first) || unicode.IsSpace(last)) {
+			return newSensitiveError("must not start or end with whitespace")
+		}
+		return nil
+	}
+}
+
+// secretEntropy estimates the entropy of s in bits: the bits of the character classes it
+// uses for each character that is not predictable (see predictableRunes).
+func secretEntropy(s string) float64 {
+	var lower, upper, digit, symbol, other bool
+	runes := []rune(s)
+	for _, r := range runes {
+		switch {
+		case r >= 'a' && r <= 'z':
+			lower = true
+		case r >= 'A' && r <= 'Z':
+			upper = true
+		case r >= '0' && r <= '9':
+			digit = true
+		case r < utf8.RuneSelf:
+			symbol = true
+		default:
+			other = true
+		}
+	}
+
+	pool := 0
+	for _, class := range []struct {
+		used bool
+		size int
+	}{{lower, 26}, {upper, 26}, {digit, 10}, {symbol, 33}, {other, 100}} {
+		if class.used {
+			pool += class.size
+		}
+	}
+	if pool == 0 {
+		return 0
+	}
+	return float64(len(runes)-predictableRunes(runes)) * math.Log2(float64(pool))
+}
+
+// predictableRunes returns the number of runes of a secret that add no entropy: those
+// repeating a shorter block, as in "abcabcabc", and those extending a run of more than three
+// repeated or consecutive runes, as in "aaaa" or "12345". Shorter runs are not counted, as
+// random secrets often have some. It takes linear time, secrets being untrusted input.
+func predictableRunes(runes []rune) int {
+	period := smallestPeriod(runes)
+
+	predictable := len(runes) - period
+	run, step := 1, rune(0)
+	for i, r := range runes[:period] {
+		if i > 0 {
+			d := r - runes[i-1]
+			switch {
+			case d < -1 || d > 1:
+				run = 1
+			case run > 1 && d == step:
+				run++
+			default:
+				run = 2
+			}
+			step = d
+		}
+		if run > 3 {
+			predictable++
+		}
+	}
+	return predictable
+}
+
+// smallestPeriod returns the length of the shortest block that runes repeats, possibly
+// partially at the end, such as 3 for "abcabca": its length minus that of its longest
+// proper prefix that is also a suffix, computed with the prefix function of
+// Knuth-Morris-Pratt.
+func smallestPeriod(runes []rune) int {
+	if len(runes) == 0 {
+		return 0
+	}
+	// border[i] is the length of the longest proper prefix of runes[:i+1] that is also its suffix
+	border := make([]int, len(runes))
+	for i := 1; i < len(runes); i++ {
+		k := border[i-1]
+		for k > 0 && runes[i] != runes[k] {
+			k = border[k-1]
+		}
+		if runes[i] == runes[k] {
+			k++
+		}
+		border[i] = k
+	}
+	return len(runes) - border[len(runes)-1]
+}
@@ -0,0 +1,111 @@
+package validation_test
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/quantumcycle/protego/validation"
+)
+
+func TestIsStrongSecret(t *testing.T) {
+	t.Run("passes with random secrets", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(validation.Validate("9f8e2c41d7b06a35e1c4f0b92d8a7e63", validation.IsStrongSecret(100))).To(Succeed())
+		g.Expect(validation.Validate("xK#9v!Qm2$pL7&wZ", validation.IsStrongSecret(60))).To(Succeed())
+	})
+
+	t.Run("passes with 128-bit keys", func(t *testing.T) {
+		g := NewWithT(t)
+		for _, v := range []string{
+			"1ca4471970242d20820d53be827576e7", // hex
+			"235e5cb332c348c725c31eea82423075",
+			"y6xeonDEO7BUTJ2cmtzVLg", // base64url without symbols
+			"QHARIC7qM4FrHJqhNiw75A",
+			"B6Mq3Liww9NaZ1_TpXg3eQ", // base64url
+			"X7YP-7Ivd52KZTlRruQEWw",
+		} {
+			g.Expect(validation.Validate(v, validation.IsStrongSecret(128))).To(Succeed(), v)
+		}
+
+		for i := 0; i < 100; i++ { // 256-bit keys, whose estimate is far above 128 bits
+			key := make([]byte, 32)
+			_, _ = rand.Read(key)
+			g.Expect(validation.Validate(base64.RawURLEncoding.EncodeToString(key), validation.IsStrongSecret(128))).To(Succeed())
+			g.Expect(validation.Validate(hex.EncodeToString(key), validation.IsStrongSecret(128))).To(Succeed())
+		}
+	})
+
+	t.Run("fails with short or repetitive secrets", func(t *testing.T) {
+		g := NewWithT(t)
+		for _, v := range []string{"", "abc123", "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa", "abababababababababababababababab", "Passw0rd!Passw0rd!Passw0rd!"} {
+			g.Expect(validation.Validate(v, validation.IsStrongSecret(64))).To(MatchError("must have at least 64 bits of entropy"), v)
+		}
+	})
+
+	t.Run("fails with sequences", func(t *testing.T) {
+		g := NewWithT(t)
+		for _, v := range []string{"abcdefghijklmnopqrstuvwxyz", "1234567890123456789", "zyxwvutsrqponmlkjihgf"} {
+			g.Expect(validation.Validate(v, validation.IsStrongSecret(64))).To(HaveOccurred(), v)
+		}
+	})
+
+	t.Run("estimates long secrets in linear time", func(t *testing.T) {
+		g := NewWithT(t)
+		repeated := strings.Repeat("Passw0rd!", 100_000) + "x" // no short period to stop at early
+		start := time.Now()
+		g.Expect(validation.Validate(repeated, validation.IsStrongSecret(64))).To(Succeed())
+		g.Expect(validation.Validate(strings.Repeat("Passw0rd!", 100_000), validation.IsStrongSecret(64))).To(HaveOccurred())
+		g.Expect(time.Since(start)).To(BeNumerically("<", time.Second))
+	})
+}
+
+func TestNotCommonPassword(t *testing.T) {
+	t.Run("fails with common passwords, ignoring case", func(t *testing.T) {
+		g := NewWithT(t)
+		for _, v := range []string{"password", "Password1", "QWERTY", "letmein"} {
+			g.Expect(validation.Validate(v, validation.NotCommonPassword())).To(MatchError("must not be a commonly used password"), v)
+		}
+	})
+
+	t.Run("passes with other passwords", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(validation.Validate("correct horse battery staple", validation.NotCommonPassword())).To(Succeed())
+	})
+}
+
+func TestNoWhitespaceEdges(t *testing.T) {
+	t.Run("fails with leading or trailing whitespace", func(t *testing.T) {
+		g := NewWithT(t)
+		for _, v := range []string{" token", "token\n", "\ttoken", "token "} {
+			g.Expect(validation.Validate(v, validation.NoWhitespaceEdges())).To(MatchError("must not start or end with whitespace"), v)
+		}
+	})
+
+	t.Run("passes otherwise", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(validation.Validate("to ken", validation.NoWhitespaceEdges())).To(Succeed())
+		g.Expect(validation.Validate("", validation.NoWhitespaceEdges())).To(Succeed())
+	})
+}
+
+func TestSecretValidatorsNeverIncludeValues(t *testing.T) {
+	includeValues(t)
+	g := NewWithT(t)
+	for value, v := range map[string]validation.Validator[string]{
+		"qzx1":   validation.IsStrongSecret(64),
+		"monkey": validation.NotCommonPassword(),
+		"qzx1\n": validation.NoWhitespaceEdges(),
+	} {
+		err := validation.Validate(value, v)
+		g.Expect(err).To(HaveOccurred())
+		g.Expect(err.Error()).ToNot(ContainSubstring("got"))
+		_, ok := err.(*validation.Error).Value()
+		g.Expect(ok).To(BeFalse())
+	}
+}
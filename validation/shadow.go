@@ -0,0 +1,35 @@
+package validation
+
+// ShadowReport receives the results of a shadow validation (see Shadow): candidateErr is the
+// error of the candidate validator, and currentErr the one returned to the caller. A value
+// accepted today and rejected by the candidate, currentErr being nil, would break if the
+// candidate was enforced.
+type ShadowReport[T any] func(value T, currentErr, candidateErr error)
+
+// Shadow returns a middleware evaluating a candidate validator, such as a stricter version
+// of a schema, alongside the wrapped one, to measure breakage before enforcing it. The
+// result of the wrapped validator is returned unchanged; failures of the candidate are only
+// passed to report, as are its panics, recovered as a *PanicError. The candidate runs
+// synchronously after the wrapped validator, so it adds its latency to every call.
+//
+// Example:
+//
+//	validate := validation.Wrap(orderV1.Validate, validation.Shadow(orderV2.Validate,
+//	    func(order map[string]any, currentErr, candidateErr error) {
+//	        if currentErr == nil {
+//	            shadowRejections.Inc()
+//	            slog.Info("order v2 would reject", "error", candidateErr)
+//	        }
+//	    }))
+func Shadow[T any](candidate Validator[T], report ShadowReport[T]) Middleware[T] {
+	candidate = Wrap(candidate, Recover[T]())
+	return func(next Validator[T]) Validator[T] {
+		return func(v T) error {
+			err := next(v)
+			if candidateErr := candidate(v); candidateErr != nil {
+				report(v, err, candidateErr)
+			}
+			return err
+		}
+	}
+}
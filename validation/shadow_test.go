@@ -0,0 +1,72 @@
+package validation_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/quantumcycle/protego/validation"
+)
+
+func TestShadow(t *testing.T) {
+	type report struct {
+		value                    string
+		currentErr, candidateErr error
+	}
+	shadowed := func(reports *[]report) validation.Validator[string] {
+		return validation.Wrap(validation.MaxLength(10), validation.Shadow(validation.MaxLength(5),
+			func(value string, currentErr, candidateErr error) {
+				*reports = append(*reports, report{value, currentErr, candidateErr})
+			}))
+	}
+
+	t.Run("returns the result of the current validator", func(t *testing.T) {
+		g := NewWithT(t)
+		var reports []report
+		validate := shadowed(&reports)
+		g.Expect(validate("abc")).To(Succeed())
+		g.Expect(validate("abcdefg")).To(Succeed())
+		g.Expect(validate("abcdefghijkl")).To(MatchError("must be at most 10 characters"))
+	})
+
+	t.Run("reports the failures of the candidate", func(t *testing.T) {
+		g := NewWithT(t)
+		var reports []report
+		validate := shadowed(&reports)
+		_ = validate("abc")
+		_ = validate("abcdefg")
+		_ = validate("abcdefghijkl")
+		g.Expect(reports).To(HaveLen(2))
+		g.Expect(reports[0].value).To(Equal("abcdefg"))
+		g.Expect(reports[0].currentErr).ToNot(HaveOccurred())
+		g.Expect(reports[0].candidateErr).To(MatchError("must be at most 5 characters"))
+		g.Expect(reports[1].currentErr).To(MatchError("must be at most 10 characters"))
+	})
+
+	t.Run("reports panics of the candidate", func(t *testing.T) {
+		g := NewWithT(t)
+		var candidateErr error
+		validate := validation.Wrap(validation.MaxLength(10), validation.Shadow(func(string) error { panic("boom") },
+			func(_ string, _, err error) { candidateErr = err }))
+		g.Expect(validate("abc")).To(Succeed())
+		var panicErr *validation.PanicError
+		g.Expect(candidateErr).To(BeAssignableToTypeOf(panicErr))
+	})
+
+	t.Run("shadows schemas", func(t *testing.T) {
+		g := NewWithT(t)
+		current := validation.NewSchema(validation.Key("name", validation.MinLengthRule(3)))
+		stricter := validation.NewSchema(validation.Key("name", validation.MinLengthRule(5)).Required())
+		rejected := 0
+		validate := validation.Wrap(current.Validate, validation.Shadow(stricter.Validate,
+			func(_ map[string]any, currentErr, _ error) {
+				if currentErr == nil {
+					rejected++
+				}
+			}))
+		g.Expect(validate(map[string]any{})).To(Succeed())
+		g.Expect(validate(map[string]any{"name": "alice"})).To(Succeed())
+		g.Expect(validate(map[string]any{"name": "bob"})).To(Succeed())
+		g.Expect(rejected).To(Equal(2))
+	})
+}
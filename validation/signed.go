@@ -0,0 +1,163 @@
+package validation
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Codes of the errors of VerifySignedRequest, one per failure mode, for logs and metrics to
+// tell replays from forgeries and clock issues.
+const (
+	CodeStaleTimestamp   = "stale_timestamp"
+	CodeMissingNonce     = "missing_nonce"
+	CodeReplayedNonce    = "replayed_nonce"
+	CodeInvalidSignature = "invalid_signature"
+)
+
+// SignedRequest is a request signed by its sender, such as a webhook delivery, as read from
+// its headers and body by the application.
+type SignedRequest struct {
+	// Timestamp is when the sender signed the request.
+	Timestamp time.Time
+	// Nonce is a value the sender never reuses, such as a delivery ID. It must be covered by
+	// the signature, or a captured request could be replayed with a fresh nonce.
+	Nonce string
+	// Message is the signed content, as defined by the signature scheme of the sender. It
+	// must cover the nonce and the timestamp, such as the nonce, a period, the timestamp, a
+	// period and the body in Standard Webhooks; only the signature of Message is verified.
+	Message []byte
+	// Signature is the decoded signature of Message.
+	Signature []byte
+}
+
+// NonceStore records the nonces of signed requests, so that replayed requests can be
+// detected. Implement it with a shared store, such as Redis SET NX with an expiry, when
+// requests are verified by several instances.
+type NonceStore interface {
+	// Remember records nonce for ttl, reporting whether it was already recorded. A returned
+	// error is treated as a system error (see IsSystemError).
+	Remember(ctx context.Context, nonce string, ttl time.Duration) (seen bool, err error)
+}
+
+// SignedRequestOptions configures VerifySignedRequest.
+type SignedRequestOptions struct {
+	// Tolerance is the maximum difference between the timestamp of a request and the
+	// current time, in either direction, to allow for clock skew and delivery delays. It
+	// must be positive.
+	Tolerance time.Duration
+	// Nonces records the nonces of verified requests, for twice the tolerance, after which
+	// replays are rejected by the timestamp check. Nil disables the nonce check.
+	Nonces NonceStore
+	// Verify reports whether the signature of a request is valid, such as HMACSHA256. It is
+	// required.
+	Verify func(ctx context.Context, r SignedRequest) (bool, error)
+}
+
+// VerifySignedRequest validates a signed request against replays and forgeries, in order:
+// its timestamp must be within the tolerance, its signature must be valid, and its nonce
+// must not have been seen before. The nonce is only recorded for authentic requests, so
+// that forged requests cannot burn the nonces of legitimate ones. Each failure is attributed
+// to the field at fault, "timestamp", "signature" or "nonce", with a distinct code:
+// CodeStaleTimestamp, CodeInvalidSignature, and CodeMissingNonce or CodeReplayedNonce.
+// Errors of Verify and Nonces are returned as is. It panics if opts has no positive
+// Tolerance or no Verify function.
+//
+// Example:
+//
+//	verify := validation.VerifySignedRequest(validation.SignedRequestOptions{
+//	    Tolerance: 5 * time.Minute,
+//	    Nonces:    validation.NewMemoryNonceStore(),
+//	    Verify:    validation.HMACSHA256(webhookSecret),
+//	})
+//	id, timestamp := r.Header.Get("Webhook-Id"), r.Header.Get("Webhook-Timestamp")
+//	err := validation.ValidateCtx(r.Context(), validation.SignedRequest{
+//	    Timestamp: time.Unix(ts, 0),
+//	    Nonce:     id,
+//	    Message:   []byte(id + "." + timestamp + "." + string(body)),
+//	    Signature: signature,
+//	}, verify)
+func VerifySignedRequest(opts SignedRequestOptions) ValidatorCtx[SignedRequest] {
+	if opts.Tolerance <= 0 {
+		panic("validation: VerifySignedRequest requires a positive Tolerance")
+	}
+	if opts.Verify == nil {
+		panic("validation: VerifySignedRequest requires a Verify function")
+	}
+	return func(ctx context.Context, r SignedRequest) error {
+		if skew := time.Since(r.Timestamp); skew > opts.Tolerance || skew < -opts.Tolerance {
+			return NewFieldError("timestamp", NewCodedError(CodeStaleTimestamp,
+				fmt.Sprintf("must be within %s of the current time", formatDuration(opts.Tolerance)),
+				map[string]any{"tolerance": opts.Tolerance.String()}))
+		}
+
+		valid, err := opts.Verify(ctx, r)
+		if err != nil {
+			return err
+		}
+		if !valid {
+			return NewFieldError("signature", NewCodedError(CodeInvalidSignature, "must be a valid signature of the request", nil))
+		}
+
+		if opts.Nonces != nil {
+			if r.Nonce == "" {
+				return NewFieldError("nonce", NewCodedError(CodeMissingNonce, "required", nil))
+			}
+			seen, err := opts.Nonces.Remember(ctx, r.Nonce, 2*opts.Tolerance)
+			if err != nil {
+				return err
+			}
+			if seen {
+				return NewFieldError("nonce", NewCodedError(CodeReplayedNonce, "must not have been used before", nil))
+			}
+		}
+		return nil
+	}
+}
+
+// HMACSHA256 returns a SignedRequestOptions.Verify function checking that signatures are
+// the HMAC-SHA256 of the message with secret, in constant time.
+func HMACSHA256(secret []byte) func(ctx context.Context, r SignedRequest) (bool, error) {
+	return func(_ context.Context, r SignedRequest) (bool, error) {
+		mac := hmac.New(sha256.New, secret)
+		mac.Write(r.Message)
+		return hmac.Equal(mac.Sum(nil), r.Signature), nil
+	}
+}
+
+// MemoryNonceStore is a NonceStore keeping nonces in memory, for applications verifying
+// requests on a single instance. It is safe for concurrent use.
+type MemoryNonceStore struct {
+	mu        sync.Mutex
+	expiries  map[string]time.Time
+	nextSweep int
+}
+
+// NewMemoryNonceStore creates an empty MemoryNonceStore.
+func NewMemoryNonceStore() *MemoryNonceStore {
+	return &MemoryNonceStore{expiries: make(map[string]time.Time), nextSweep: 1024}
+}
+
+// Remember records nonce for ttl, reporting whether it was already recorded and has not
+// expired. Expired nonces are dropped as the store grows.
+func (s *MemoryNonceStore) Remember(_ context.Context, nonce string, ttl time.Duration) (bool, error) {
+	now := time.Now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if expiry, ok := s.expiries[nonce]; ok && now.Before(expiry) {
+		return true, nil
+	}
+	s.expiries[nonce] = now.Add(ttl)
+	if len(s.expiries) >= s.nextSweep {
+		for n, expiry := range s.expiries {
+			if !now.Before(expiry) {
+				delete(s.expiries, n)
+			}
+		}
+		s.nextSweep = max(2*len(s.expiries), 1024)
+	}
+	return false, nil
+}
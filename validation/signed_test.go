@@ -0,0 +1,160 @@
+package validation_test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/quantumcycle/protego/validation"
+)
+
+type failingNonceStore struct{}
+
+func (failingNonceStore) Remember(context.Context, string, time.Duration) (bool, error) {
+	return false, errors.New("redis unavailable")
+}
+
+func TestVerifySignedRequest(t *testing.T) {
+	secret := []byte("webhook-secret")
+	sign := func(message string) []byte {
+		mac := hmac.New(sha256.New, secret)
+		mac.Write([]byte(message))
+		return mac.Sum(nil)
+	}
+	// message is the signed content of Standard Webhooks, covering the nonce and timestamp.
+	message := func(nonce string, timestamp time.Time, body string) []byte {
+		return []byte(nonce + "." + strconv.FormatInt(timestamp.Unix(), 10) + "." + body)
+	}
+	request := func(nonce string, timestamp time.Time) validation.SignedRequest {
+		m := message(nonce, timestamp, `{"event":"paid"}`)
+		return validation.SignedRequest{Timestamp: timestamp, Nonce: nonce, Message: m, Signature: sign(string(m))}
+	}
+	newVerifier := func() validation.ValidatorCtx[validation.SignedRequest] {
+		return validation.VerifySignedRequest(validation.SignedRequestOptions{
+			Tolerance: 5 * time.Minute,
+			Nonces:    validation.NewMemoryNonceStore(),
+			Verify:    validation.HMACSHA256(secret),
+		})
+	}
+	code := func(err error) string {
+		var valErr *validation.Error
+		if !errors.As(err, &valErr) {
+			return ""
+		}
+		return valErr.Code()
+	}
+	ctx := context.Background()
+
+	t.Run("accepts fresh authentic requests", func(t *testing.T) {
+		g := NewWithT(t)
+		verify := newVerifier()
+		g.Expect(verify(ctx, request("evt_1", time.Now()))).To(Succeed())
+		g.Expect(verify(ctx, request("evt_2", time.Now().Add(-4*time.Minute)))).To(Succeed())
+		g.Expect(verify(ctx, request("evt_3", time.Now().Add(time.Minute)))).To(Succeed())
+	})
+
+	t.Run("rejects timestamps out of tolerance", func(t *testing.T) {
+		g := NewWithT(t)
+		verify := newVerifier()
+		for _, timestamp := range []time.Time{time.Now().Add(-6 * time.Minute), time.Now().Add(6 * time.Minute)} {
+			err := verify(ctx, request("evt_1", timestamp))
+			g.Expect(err).To(MatchError("timestamp: must be within 5m0s of the current time"))
+			g.Expect(code(err)).To(Equal(validation.CodeStaleTimestamp))
+		}
+	})
+
+	t.Run("rejects invalid signatures", func(t *testing.T) {
+		g := NewWithT(t)
+		verify := newVerifier()
+		forged := request("evt_1", time.Now())
+		forged.Message = message("evt_1", forged.Timestamp, `{"event":"refunded"}`)
+		err := verify(ctx, forged)
+		g.Expect(err).To(MatchError("signature: must be a valid signature of the request"))
+		g.Expect(code(err)).To(Equal(validation.CodeInvalidSignature))
+
+		// the nonce of the forged request was not burned
+		g.Expect(verify(ctx, request("evt_1", time.Now()))).To(Succeed())
+	})
+
+	t.Run("rejects replays with a fresh nonce", func(t *testing.T) {
+		g := NewWithT(t)
+		verify := newVerifier()
+		captured := request("evt_1", time.Now())
+		g.Expect(verify(ctx, captured)).To(Succeed())
+
+		replayed := captured
+		replayed.Nonce = "evt_2"
+		replayed.Message = message(replayed.Nonce, replayed.Timestamp, `{"event":"paid"}`)
+		err := verify(ctx, replayed)
+		g.Expect(err).To(MatchError("signature: must be a valid signature of the request"))
+		g.Expect(code(err)).To(Equal(validation.CodeInvalidSignature))
+	})
+
+	t.Run("rejects missing nonces", func(t *testing.T) {
+		g := NewWithT(t)
+		err := newVerifier()(ctx, request("", time.Now()))
+		g.Expect(err).To(MatchError("nonce: required"))
+		g.Expect(code(err)).To(Equal(validation.CodeMissingNonce))
+	})
+
+	t.Run("rejects replayed nonces", func(t *testing.T) {
+		g := NewWithT(t)
+		verify := newVerifier()
+		g.Expect(verify(ctx, request("evt_1", time.Now()))).To(Succeed())
+		err := verify(ctx, request("evt_1", time.Now()))
+		g.Expect(err).To(MatchError("nonce: must not have been used before"))
+		g.Expect(code(err)).To(Equal(validation.CodeReplayedNonce))
+	})
+
+	t.Run("skips the nonce check without store", func(t *testing.T) {
+		g := NewWithT(t)
+		verify := validation.VerifySignedRequest(validation.SignedRequestOptions{Tolerance: time.Minute, Verify: validation.HMACSHA256(secret)})
+		g.Expect(verify(ctx, request("", time.Now()))).To(Succeed())
+		g.Expect(verify(ctx, request("", time.Now()))).To(Succeed())
+	})
+
+	t.Run("panics without tolerance or verify function", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(func() {
+			validation.VerifySignedRequest(validation.SignedRequestOptions{Verify: validation.HMACSHA256(secret)})
+		}).To(PanicWith("validation: VerifySignedRequest requires a positive Tolerance"))
+		g.Expect(func() {
+			validation.VerifySignedRequest(validation.SignedRequestOptions{Tolerance: -time.Minute, Verify: validation.HMACSHA256(secret)})
+		}).To(Panic())
+		g.Expect(func() {
+			validation.VerifySignedRequest(validation.SignedRequestOptions{Tolerance: time.Minute})
+		}).To(PanicWith("validation: VerifySignedRequest requires a Verify function"))
+	})
+
+	t.Run("returns store errors as system errors", func(t *testing.T) {
+		g := NewWithT(t)
+		verify := validation.VerifySignedRequest(validation.SignedRequestOptions{Tolerance: time.Minute, Nonces: failingNonceStore{}, Verify: validation.HMACSHA256(secret)})
+		err := verify(ctx, request("evt_1", time.Now()))
+		g.Expect(err).To(MatchError("redis unavailable"))
+		g.Expect(validation.IsSystemError(err)).To(BeTrue())
+	})
+}
+
+func TestMemoryNonceStore(t *testing.T) {
+	g := NewWithT(t)
+	store := validation.NewMemoryNonceStore()
+	ctx := context.Background()
+
+	seen, err := store.Remember(ctx, "a", time.Hour)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(seen).To(BeFalse())
+
+	seen, _ = store.Remember(ctx, "a", time.Hour)
+	g.Expect(seen).To(BeTrue())
+
+	seen, _ = store.Remember(ctx, "b", -time.Second) // already expired
+	g.Expect(seen).To(BeFalse())
+	seen, _ = store.Remember(ctx, "b", time.Hour)
+	g.Expect(seen).To(BeFalse())
+}
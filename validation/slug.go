@@ -0,0 +1,97 @@
+package validation
+
+import (
+	"context"
+	"strings"
+)
+
+// Codes of the errors of IsAvailableSlug, for clients to tell a reserved slug from a taken
+// one, for instance to suggest alternatives for the latter only.
+const (
+	CodeSlugReserved = "slug_reserved"
+	CodeSlugTaken    = "slug_taken"
+)
+
+// DefaultReservedPaths are route names commonly used by web applications, which slugs used
+// as URL paths, such as "example.com/{handle}", must not shadow.
+var DefaultReservedPaths = []string{
+	"about", "account", "admin", "api", "app", "assets", "auth", "blog", "dashboard", "docs",
+	"help", "home", "login", "logout", "me", "new", "oauth", "privacy", "register", "root",
+	"search", "settings", "signin", "signout", "signup", "static", "status", "support",
+	"system", "terms", "www",
+}
+
+// SlugResolver looks up whether a slug is free, typically in the table of existing handles.
+// A returned error is treated as a system error (see IsSystemError).
+type SlugResolver interface {
+	SlugAvailable(ctx context.Context, slug string) (bool, error)
+}
+
+// SlugResolverFunc adapts a function to the SlugResolver interface.
+type SlugResolverFunc func(ctx context.Context, slug string) (bool, error)
+
+// SlugAvailable calls f(ctx, slug).
+func (f SlugResolverFunc) SlugAvailable(ctx context.Context, slug string) (bool, error) {
+	return f(ctx, slug)
+}
+
+// IsSlug validates that a string is a URL slug: lowercase ASCII letters and digits, in
+// words separated by single hyphens, such as "jane-doe-42".
+//
+// Example:
+//
+//	validation.Validate(article.Slug, validation.IsSlug(), validation.MaxLength(80))
+func IsSlug() Validator[string] {
+	return func(v string) error {
+		if !isSlug(v) {
+			return NewValidationError("must only contain lowercase letters, digits and single hyphens between them")
+		}
+		return nil
+	}
+}
+
+func isSlug(v string) bool {
+	if v == "" || v[0] == '-' || v[len(v)-1] == '-' || strings.Contains(v, "--") {
+		return false
+	}
+	for i := 0; i < len(v); i++ {
+		if c := v[i]; (c < 'a' || c > 'z') && (c < '0' || c > '9') && c != '-' {
+			return false
+		}
+	}
+	return true
+}
+
+// IsAvailableSlug validates a slug chosen by a user, such as a handle or a workspace name,
+// in the order of the "choose your handle" flow: its format (see IsSlug), then that it is
+// not one of reservedPaths, ignoring case, and finally that resolver reports it available.
+// The lookup is only made for well-formed, unreserved slugs. Reserved and taken slugs are
+// reported with CodeSlugReserved and CodeSlugTaken; errors of resolver are returned as is.
+//
+// Example:
+//
+//	available := validation.IsAvailableSlug(validation.SlugResolverFunc(users.HandleAvailable), validation.DefaultReservedPaths)
+//	err := validation.ValidateCtx(ctx, input.Handle, validation.WithContext(validation.Length(3, 30)), available)
+func IsAvailableSlug(resolver SlugResolver, reservedPaths []string) ValidatorCtx[string] {
+	reserved := make(map[string]struct{}, len(reservedPaths))
+	for _, path := range reservedPaths {
+		reserved[strings.ToLower(path)] = struct{}{}
+	}
+	format := IsSlug()
+	return func(ctx context.Context, v string) error {
+		if err := format(v); err != nil {
+			return err
+		}
+		if _, ok := reserved[v]; ok {
+			return NewCodedError(CodeSlugReserved, "is reserved", nil)
+		}
+		available, err := resolver.SlugAvailable(ctx, v)
+		if err != nil {
+			return err
+		}
+		if !available {
+			return NewCodedError(CodeSlugTaken, "already taken", nil)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,91 @@
+package validation_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/quantumcycle/protego/validation"
+)
+
+func TestIsSlug(t *testing.T) {
+	t.Run("accepts slugs", func(t *testing.T) {
+		g := NewWithT(t)
+		for _, slug := range []string{"jane", "jane-doe-42", "42"} {
+			g.Expect(validation.IsSlug()(slug)).To(Succeed(), slug)
+		}
+	})
+
+	t.Run("rejects malformed slugs", func(t *testing.T) {
+		g := NewWithT(t)
+		for _, slug := range []string{"", "Jane", "jane--doe", "-jane", "jane-", "jane_doe", "jane doe", "jäne"} {
+			g.Expect(validation.IsSlug()(slug)).To(MatchError("must only contain lowercase letters, digits and single hyphens between them"), slug)
+		}
+	})
+}
+
+func TestIsAvailableSlug(t *testing.T) {
+	taken := map[string]bool{"jane": true}
+	var lookups []string
+	resolver := validation.SlugResolverFunc(func(_ context.Context, slug string) (bool, error) {
+		lookups = append(lookups, slug)
+		if slug == "outage" {
+			return false, errors.New("database unavailable")
+		}
+		return !taken[slug], nil
+	})
+	validator := validation.IsAvailableSlug(resolver, append([]string{"Pricing"}, validation.DefaultReservedPaths...))
+	code := func(err error) string {
+		var valErr *validation.Error
+		if !errors.As(err, &valErr) {
+			return ""
+		}
+		return valErr.Code()
+	}
+
+	t.Run("accepts available slugs", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(validator(context.Background(), "john")).To(Succeed())
+	})
+
+	t.Run("rejects malformed slugs without lookup", func(t *testing.T) {
+		g := NewWithT(t)
+		lookups = nil
+		err := validator(context.Background(), "John!")
+		g.Expect(err).To(MatchError("must only contain lowercase letters, digits and single hyphens between them"))
+		g.Expect(lookups).To(BeEmpty())
+	})
+
+	t.Run("rejects reserved paths without lookup", func(t *testing.T) {
+		g := NewWithT(t)
+		lookups = nil
+		for _, slug := range []string{"admin", "api", "login", "pricing"} {
+			err := validator(context.Background(), slug)
+			g.Expect(err).To(MatchError("is reserved"), slug)
+			g.Expect(code(err)).To(Equal(validation.CodeSlugReserved))
+		}
+		g.Expect(lookups).To(BeEmpty())
+	})
+
+	t.Run("rejects taken slugs", func(t *testing.T) {
+		g := NewWithT(t)
+		err := validator(context.Background(), "jane")
+		g.Expect(err).To(MatchError("already taken"))
+		g.Expect(code(err)).To(Equal(validation.CodeSlugTaken))
+	})
+
+	t.Run("returns resolver errors as system errors", func(t *testing.T) {
+		g := NewWithT(t)
+		err := validator(context.Background(), "outage")
+		g.Expect(err).To(MatchError("database unavailable"))
+		g.Expect(validation.IsSystemError(err)).To(BeTrue())
+	})
+
+	t.Run("combines with ValidateCtx", func(t *testing.T) {
+		g := NewWithT(t)
+		err := validation.ValidateCtx(context.Background(), "jo", validation.WithContext(validation.Length(3, 30)), validator)
+		g.Expect(err).To(MatchError("must be between 3 and 30 characters"))
+	})
+}
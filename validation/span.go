@@ -0,0 +1,89 @@
+package validation
+
+import (
+	"fmt"
+	"time"
+)
+
+// MaxSpan validates that a (from, to) time range supplied outside a struct, such as the
+// bounds of a report, lasts at most max. The error is attributed to the "to" field. A range
+// ending before it starts has a negative span and passes; check the order with TimesOrdered.
+//
+// Example:
+//
+//	err := validation.MaxSpan(query.From, query.To, 31*24*time.Hour) // "to: must be at most 31 days after from"
+func MaxSpan(from, to time.Time, maximum time.Duration) error {
+	if to.Sub(from) > maximum {
+		return NewFieldError("to", NewValidationError(fmt.Sprintf("must be at most %s after from", formatDuration(maximum))))
+	}
+	return nil
+}
+
+// MinSpan validates that a (from, to) time range supplied outside a struct lasts at least
+// minimum. The error is attributed to the "to" field.
+//
+// Example:
+//
+//	err := validation.MinSpan(booking.CheckIn, booking.CheckOut, 24*time.Hour) // "to: must be at least 24 hours after from"
+func MinSpan(from, to time.Time, minimum time.Duration) error {
+	if to.Sub(from) < minimum {
+		return NewFieldError("to", NewValidationError(fmt.Sprintf("must be at least %s after from", formatDuration(minimum))))
+	}
+	return nil
+}
+
+// MaxDateSpan is MaxSpan for RFC3339 date-time strings. An invalid date-time is reported on
+// its own field, "from" or "to".
+//
+// Example:
+//
+//	err := validation.MaxDateSpan(q.Get("from"), q.Get("to"), 31*24*time.Hour)
+func MaxDateSpan(from, to string, maximum time.Duration) error {
+	f, t, err := parseSpan(from, to)
+	if err != nil {
+		return err
+	}
+	return MaxSpan(f, t, maximum)
+}
+
+// MinDateSpan is MinSpan for RFC3339 date-time strings. An invalid date-time is reported on
+// its own field, "from" or "to".
+//
+// Example:
+//
+//	err := validation.MinDateSpan(q.Get("from"), q.Get("to"), time.Hour)
+func MinDateSpan(from, to string, minimum time.Duration) error {
+	f, t, err := parseSpan(from, to)
+	if err != nil {
+		return err
+	}
+	return MinSpan(f, t, minimum)
+}
+
+func parseSpan(from, to string) (time.Time, time.Time, error) {
+	f, err := time.Parse(time.RFC3339, from)
+	if err != nil {
+		return time.Time{}, time.Time{}, NewFieldError("from", NewValidationError("must be a valid RFC3339 date-time"))
+	}
+	t, err := time.Parse(time.RFC3339, to)
+	if err != nil {
+		return time.Time{}, time.Time{}, NewFieldError("to", NewValidationError("must be a valid RFC3339 date-time"))
+	}
+	return f, t, nil
+}
+
+// formatDuration formats a duration for error messages, in days or hours when it is a whole
+// number of them, e.g. "31 days" or "24 hours".
+func formatDuration(d time.Duration) string {
+	const day = 24 * time.Hour
+	switch {
+	case d > day && d%day == 0:
+		return fmt.Sprintf("%d days", d/day)
+	case d == time.Hour:
+		return "1 hour"
+	case d%time.Hour == 0:
+		return fmt.Sprintf("%d hours", d/time.Hour)
+	default:
+		return d.String()
+	}
+}
@@ -0,0 +1,50 @@
+package validation_test
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/quantumcycle/protego/validation"
+)
+
+func TestMaxSpan(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	month := 31 * 24 * time.Hour
+
+	t.Run("passes ranges up to the maximum", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(validation.MaxSpan(from, from.AddDate(0, 0, 31), month)).To(Succeed())
+		g.Expect(validation.MaxSpan(from, from, month)).To(Succeed())
+	})
+
+	t.Run("rejects longer ranges", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(validation.MaxSpan(from, from.AddDate(0, 0, 32), month)).To(MatchError("to: must be at most 31 days after from"))
+		g.Expect(validation.MaxSpan(from, from.Add(91*time.Minute), 90*time.Minute)).To(MatchError("to: must be at most 1h30m0s after from"))
+	})
+}
+
+func TestMinSpan(t *testing.T) {
+	g := NewWithT(t)
+	from := time.Date(2024, 1, 1, 14, 0, 0, 0, time.UTC)
+	g.Expect(validation.MinSpan(from, from.Add(24*time.Hour), 24*time.Hour)).To(Succeed())
+	g.Expect(validation.MinSpan(from, from.Add(23*time.Hour), 24*time.Hour)).To(MatchError("to: must be at least 24 hours after from"))
+	g.Expect(validation.MinSpan(from, from.Add(-time.Hour), time.Hour)).To(MatchError("to: must be at least 1 hour after from"))
+}
+
+func TestDateSpan(t *testing.T) {
+	t.Run("parses RFC3339 date-times", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(validation.MaxDateSpan("2024-01-01T00:00:00Z", "2024-01-08T00:00:00Z", 7*24*time.Hour)).To(Succeed())
+		g.Expect(validation.MaxDateSpan("2024-01-01T00:00:00Z", "2024-01-08T00:00:00-01:00", 7*24*time.Hour)).To(MatchError("to: must be at most 7 days after from"))
+		g.Expect(validation.MinDateSpan("2024-01-01T10:00:00Z", "2024-01-01T10:30:00Z", time.Hour)).To(MatchError("to: must be at least 1 hour after from"))
+	})
+
+	t.Run("reports invalid date-times on their field", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(validation.MaxDateSpan("2024-01-01", "2024-01-08T00:00:00Z", time.Hour)).To(MatchError("from: must be a valid RFC3339 date-time"))
+		g.Expect(validation.MinDateSpan("2024-01-01T00:00:00Z", "", time.Hour)).To(MatchError("to: must be a valid RFC3339 date-time"))
+	})
+}
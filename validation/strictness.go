@@ -0,0 +1,130 @@
+package validation
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Strictness is how strictly a Schema validates payloads, for a schema to serve both
+// internal endpoints, tolerant of sloppy callers, and public ones (see WithStrictness).
+type Strictness int
+
+// Levels of strictness, from the most to the least tolerant.
+const (
+	// Lenient accepts keys the schema does not define, trims the whitespace around string
+	// values before validating them, and lets date rules such as DateTimeRule accept
+	// common variants of their format.
+	Lenient Strictness = iota
+	// Strict is the default: keys the schema does not define are rejected unless AllowExtra
+	// was called, and values are validated as they are.
+	Strict
+	// Pedantic is Strict, but also rejects keys the schema does not define when AllowExtra
+	// was called, and string values starting or ending with whitespace.
+	Pedantic
+)
+
+// String returns the name of the level, such as "strict".
+func (s Strictness) String() string {
+	switch s {
+	case Lenient:
+		return "lenient"
+	case Strict:
+		return "strict"
+	case Pedantic:
+		return "pedantic"
+	}
+	return fmt.Sprintf("Strictness(%d)", int(s))
+}
+
+// WithStrictness returns a copy of the schema validating payloads at level. Like
+// MapKeyRule.Normalize, the string values trimmed by Lenient are written back to the
+// payload. Nested schemas, such as those of SchemaRule, keep their own strictness.
+//
+// Example:
+//
+//	var internalOrder = orderSchema.WithStrictness(validation.Lenient)
+//	var publicOrder = orderSchema.WithStrictness(validation.Pedantic)
+func (s *Schema) WithStrictness(level Strictness) *Schema {
+	c := s.clone()
+	c.strictness = level
+	c.mapRules = mapRules(c.keys, level)
+	return c
+}
+
+// acceptsExtra reports whether the schema accepts keys it does not define.
+func (s *Schema) acceptsExtra() bool {
+	switch s.strictness {
+	case Lenient:
+		return true
+	case Pedantic:
+		return false
+	}
+	return s.allowExtra
+}
+
+// mapRules returns the rules validating the keys at level.
+func mapRules(keys []SchemaKey, level Strictness) []MapKeyRule[any] {
+	rules := make([]MapKeyRule[any], len(keys))
+	for i, k := range keys {
+		keyRules := level.rules(k)
+		validators := make([]Validator[any], len(keyRules))
+		for j, r := range keyRules {
+			validators[j] = r.validator
+			if k.nullable {
+				validators[j] = skipNull(r.validator)
+			}
+		}
+		rules[i] = MapKey(k.name, k.required, validators...)
+		if level == Lenient {
+			rules[i] = rules[i].Normalize(level.normalize)
+		}
+	}
+	return rules
+}
+
+// rules returns the rules of k at the level: their lenient variants when Lenient, preceded
+// by a check of the whitespace around strings when Pedantic.
+func (s Strictness) rules(k SchemaKey) []Rule {
+	switch s {
+	case Lenient:
+		rules := make([]Rule, len(k.rules))
+		for i, r := range k.rules {
+			if r.lenient != nil {
+				r.validator = r.lenient
+			}
+			rules[i] = r
+		}
+		return rules
+	case Pedantic:
+		return append([]Rule{noWhitespaceEdgesRule}, k.rules...)
+	}
+	return k.rules
+}
+
+// normalize writes the values of the keys of m normalized at the strictness of the schema
+// back to m, as its rules do.
+func (s *Schema) normalize(m map[string]any) {
+	if s.strictness != Lenient {
+		return
+	}
+	for _, k := range s.keys {
+		if v, exists := m[k.name]; exists {
+			m[k.name] = s.strictness.normalize(v)
+		}
+	}
+}
+
+// normalize trims the whitespace around strings when Lenient.
+func (s Strictness) normalize(v any) any {
+	if str, ok := v.(string); ok && s == Lenient {
+		return strings.TrimSpace(str)
+	}
+	return v
+}
+
+var noWhitespaceEdgesRule = NewRule("no_whitespace_edges", nil, func(v any) error {
+	if str, ok := v.(string); ok {
+		return NoWhitespaceEdges()(str)
+	}
+	return nil
+})
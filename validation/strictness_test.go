@@ -0,0 +1,92 @@
+package validation_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/quantumcycle/protego/validation"
+)
+
+func TestStrictness(t *testing.T) {
+	schema := validation.NewSchema(
+		validation.Key("name", validation.MinLengthRule(3), validation.MaxLengthRule(10)).Required(),
+		validation.Key("placedAt", validation.DateTimeRule()),
+		validation.Key("tags", validation.EachRule(validation.DateTimeRule())),
+	)
+	lenient := schema.WithStrictness(validation.Lenient)
+	pedantic := schema.AllowExtra().WithStrictness(validation.Pedantic)
+
+	t.Run("is strict by default", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(schema.Validate(map[string]any{"name": "alice", "placedAt": "2024-03-01T12:30:00Z"})).To(Succeed())
+		g.Expect(schema.Validate(map[string]any{"name": "alice", "placedAt": "2024-03-01"})).To(MatchError(ContainSubstring("must be a valid RFC3339 date-time")))
+		g.Expect(schema.Validate(map[string]any{"name": "  al  "})).To(Succeed())
+		g.Expect(schema.Validate(map[string]any{"name": "alice", "team": "core"})).To(MatchError(`key "team" not expected`))
+		g.Expect(schema.AllowExtra().Validate(map[string]any{"name": "alice", "team": "core"})).To(Succeed())
+	})
+
+	t.Run("lenient schemas trim strings and accept extra keys and date variants", func(t *testing.T) {
+		g := NewWithT(t)
+		payload := map[string]any{"name": "  al  ", "team": "core"}
+		g.Expect(lenient.Validate(payload)).To(MatchError(ContainSubstring("must be at least 3 characters")))
+		g.Expect(payload["name"]).To(Equal("al"))
+
+		payload = map[string]any{
+			"name":     " alice\n",
+			"placedAt": "2024-03-01 12:30:00",
+			"tags":     []any{"2024-03-01", "2024-03-01T12:30:00"},
+		}
+		g.Expect(lenient.Validate(payload)).To(Succeed())
+		g.Expect(payload["name"]).To(Equal("alice"))
+		g.Expect(lenient.Validate(map[string]any{"name": "alice", "placedAt": "yesterday"})).To(MatchError(ContainSubstring("must be a valid date-time")))
+	})
+
+	t.Run("pedantic schemas reject whitespace edges and extra keys", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(pedantic.Validate(map[string]any{"name": "alice"})).To(Succeed())
+		g.Expect(pedantic.Validate(map[string]any{"name": "alice "})).To(MatchError(ContainSubstring("must not start or end with whitespace")))
+		g.Expect(pedantic.Validate(map[string]any{"name": "alice", "team": "core"})).To(MatchError(`key "team" not expected`))
+	})
+
+	t.Run("applies to patches and explanations", func(t *testing.T) {
+		g := NewWithT(t)
+		patch := map[string]any{"name": " bob ", "team": "core"}
+		g.Expect(lenient.ValidatePatch(patch)).To(Succeed())
+		g.Expect(patch["name"]).To(Equal("bob"))
+		g.Expect(pedantic.ValidatePatch(map[string]any{"name": " bob "})).To(HaveOccurred())
+
+		explanation := validation.Explain(pedantic, map[string]any{"name": " bob "})
+		g.Expect(explanation.Failures()).To(HaveExactElements(HaveField("Rule", "no_whitespace_edges")))
+		g.Expect(validation.Explain(lenient, map[string]any{"name": " bob ", "team": "core"}).Valid).To(BeTrue())
+	})
+
+	t.Run("keeps the strictest level when composing", func(t *testing.T) {
+		g := NewWithT(t)
+		base := validation.NewSchema(validation.Key("id")).WithStrictness(validation.Lenient)
+		g.Expect(base.Merge(lenient).Validate(map[string]any{"name": "alice", "team": "core"})).To(Succeed())
+		g.Expect(base.Merge(pedantic).Validate(map[string]any{"name": "alice "})).To(HaveOccurred())
+		g.Expect(validation.AllOf(base, lenient).Validate(map[string]any{"name": " alice"})).To(Succeed())
+		g.Expect(validation.AllOf(base, schema).Validate(map[string]any{"name": "alice", "team": "core"})).To(HaveOccurred())
+	})
+
+	t.Run("reports stricter schemas as breaking", func(t *testing.T) {
+		g := NewWithT(t)
+		diff := validation.DiffRules(lenient, schema)
+		g.Expect(diff.Breaking()).To(BeTrue())
+		g.Expect(diff.Changes).To(ContainElement(validation.RuleChange{
+			Rule:     "strictness",
+			Kind:     validation.RuleTightened,
+			Old:      map[string]any{"level": "lenient"},
+			New:      map[string]any{"level": "strict"},
+			Breaking: true,
+		}))
+		g.Expect(validation.DiffRules(pedantic, schema.AllowExtra()).Breaking()).To(BeFalse())
+	})
+
+	t.Run("names levels", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(validation.Pedantic.String()).To(Equal("pedantic"))
+		g.Expect(validation.Strictness(7).String()).To(Equal("Strictness(7)"))
+	})
+}
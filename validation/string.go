@@ -1,10 +1,13 @@
 package validation
 
 import (
+	"container/list"
 	"fmt"
 	"regexp"
 	"strconv"
 	"strings"
+	"sync"
+	"unicode/utf8"
 )
 
 // MinLength validates that a string has at least the specified minimum length.
@@ -15,7 +18,7 @@ import (
 func MinLength(minimum int) Validator[string] {
 	return func(v string) error {
 		if len(v) < minimum {
-			return NewValidationError(fmt.Sprintf("must be at least %d characters", minimum))
+			return NewCodedError(CodeMinLength, fmt.Sprintf("must be at least %d characters", minimum), map[string]any{"min": minimum})
 		}
 		return nil
 	}
@@ -29,7 +32,7 @@ func MinLength(minimum int) Validator[string] {
 func MaxLength(maximum int) Validator[string] {
 	return func(v string) error {
 		if len(v) > maximum {
-			return NewValidationError(fmt.Sprintf("must be at most %d characters", maximum))
+			return NewCodedError(CodeMaxLength, fmt.Sprintf("must be at most %d characters", maximum), map[string]any{"max": maximum})
 		}
 		return nil
 	}
@@ -44,7 +47,8 @@ func Length(minimum, maximum int) Validator[string] {
 	return func(v string) error {
 		length := len(v)
 		if length < minimum || length > maximum {
-			return NewValidationError(fmt.Sprintf("must be between %d and %d characters", minimum, maximum))
+			return NewCodedError(CodeLength, fmt.Sprintf("must be between %d and %d characters", minimum, maximum),
+				map[string]any{"min": minimum, "max": maximum})
 		}
 		return nil
 	}
@@ -70,15 +74,59 @@ func IsInt() Validator[string] {
 //
 //	validation.Validate(code, validation.MatchesPattern(`^[A-Z]{3}-\d{4}$`))
 func MatchesPattern(pattern string) Validator[string] {
-	regex := regexp.MustCompile(pattern)
+	regex := compilePattern(pattern)
+	msg := fmt.Sprintf("must match pattern %q", pattern)
 	return func(v string) error {
 		if !regex.MatchString(v) {
-			return NewValidationError(fmt.Sprintf("must match pattern %q", pattern))
+			return NewCodedError(CodePattern, msg, map[string]any{"pattern": pattern})
 		}
 		return nil
 	}
 }
 
+// maxCachedPatterns bounds the number of regular expressions kept by compilePattern, so
+// patterns built from runtime data do not grow the cache without bound.
+const maxCachedPatterns = 1024
+
+// patterns caches the regular expressions compiled by MatchesPattern, which is often
+// called inline in Validate methods and would otherwise compile its pattern on every call.
+// It keeps the maxCachedPatterns most recently used.
+var patterns = struct {
+	mu      sync.Mutex
+	order   *list.List // of *cachedPattern, most recently used first
+	entries map[string]*list.Element
+}{order: list.New(), entries: make(map[string]*list.Element)}
+
+type cachedPattern struct {
+	pattern string
+	regex   *regexp.Regexp
+}
+
+// compilePattern returns the compiled pattern from the cache, compiling it on first use.
+// Like regexp.MustCompile, it panics if the pattern is invalid.
+func compilePattern(pattern string) *regexp.Regexp {
+	patterns.mu.Lock()
+	if e, ok := patterns.entries[pattern]; ok {
+		patterns.order.MoveToFront(e)
+		patterns.mu.Unlock()
+		return e.Value.(*cachedPattern).regex
+	}
+	patterns.mu.Unlock()
+
+	regex := regexp.MustCompile(pattern)
+	patterns.mu.Lock()
+	defer patterns.mu.Unlock()
+	if e, ok := patterns.entries[pattern]; ok {
+		return e.Value.(*cachedPattern).regex
+	}
+	patterns.entries[pattern] = patterns.order.PushFront(&cachedPattern{pattern: pattern, regex: regex})
+	if patterns.order.Len() > maxCachedPatterns {
+		oldest := patterns.order.Remove(patterns.order.Back()).(*cachedPattern)
+		delete(patterns.entries, oldest.pattern)
+	}
+	return regex
+}
+
 // StartsWith validates that a string starts with the specified prefix.
 //
 // Example:
@@ -120,3 +168,125 @@ func Contains(substring string) Validator[string] {
 		return nil
 	}
 }
+
+// IsUUIDString validates that a string is a UUID in its canonical hyphenated form
+// (xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx), regardless of version. Hex digits may be
+// upper or lower case.
+//
+// Example:
+//
+//	validation.Validate(orderID, validation.IsUUIDString())
+func IsUUIDString() Validator[string] {
+	return func(v string) error {
+		if !isUUID(v) {
+			return NewValidationError("must be a valid UUID")
+		}
+		return nil
+	}
+}
+
+func isUUID(v string) bool {
+	if len(v) != 36 {
+		return false
+	}
+	for i := 0; i < len(v); i++ {
+		c := v[i]
+		switch i {
+		case 8, 13, 18, 23:
+			if c != '-' {
+				return false
+			}
+		default:
+			if !('0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F') {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// NanoIDAlphabet is the default URL-safe alphabet used by Nano ID.
+const NanoIDAlphabet = "_-0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
+
+// ShortCodeAlphabet is the default alphabet of IsShortCode: ASCII letters and digits.
+const ShortCodeAlphabet = "0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
+
+// AmbiguousCharacters are the characters rejected by IsShortCode when
+// ShortCodeOptions.ExcludeAmbiguous is set, as they are easily confused when read or typed.
+const AmbiguousCharacters = "01IOl"
+
+// IsNanoID validates that a string is a Nano ID of exactly length characters taken from
+// alphabet. An empty alphabet means NanoIDAlphabet. Like IsShortCode, characters are
+// counted as runes, for alphabets that are not ASCII.
+//
+// Example:
+//
+//	validation.Validate(id, validation.IsNanoID(21, ""))
+func IsNanoID(length int, alphabet string) Validator[string] {
+	if alphabet == "" {
+		alphabet = NanoIDAlphabet
+	}
+	return func(v string) error {
+		if utf8.RuneCountInString(v) != length || !onlyContains(v, alphabet) {
+			return NewValidationError(fmt.Sprintf("must be a valid Nano ID of %d characters", length))
+		}
+		return nil
+	}
+}
+
+// ShortCodeOptions configures IsShortCode.
+type ShortCodeOptions struct {
+	// MinLength and MaxLength bound the code length (inclusive). A zero MaxLength means no maximum.
+	MinLength, MaxLength int
+	// Alphabet lists the allowed characters. Empty means ShortCodeAlphabet.
+	Alphabet string
+	// ExcludeAmbiguous rejects AmbiguousCharacters even when they are part of Alphabet.
+	ExcludeAmbiguous bool
+}
+
+// IsShortCode validates URL-shortener-style codes, such as invite or referral codes.
+//
+// Example:
+//
+//	validation.Validate(code, validation.IsShortCode(validation.ShortCodeOptions{
+//	    MinLength:        6,
+//	    MaxLength:        8,
+//	    ExcludeAmbiguous: true,
+//	}))
+func IsShortCode(opts ShortCodeOptions) Validator[string] {
+	alphabet := opts.Alphabet
+	if alphabet == "" {
+		alphabet = ShortCodeAlphabet
+	}
+	if opts.ExcludeAmbiguous {
+		alphabet = strings.Map(func(r rune) rune {
+			if strings.ContainsRune(AmbiguousCharacters, r) {
+				return -1
+			}
+			return r
+		}, alphabet)
+	}
+	return func(v string) error {
+		length := utf8.RuneCountInString(v)
+		if length < opts.MinLength || (opts.MaxLength > 0 && length > opts.MaxLength) {
+			if opts.MaxLength == 0 {
+				return NewValidationError(fmt.Sprintf("must be at least %d characters", opts.MinLength))
+			}
+			return NewValidationError(fmt.Sprintf("must be between %d and %d characters", opts.MinLength, opts.MaxLength))
+		}
+		if !onlyContains(v, alphabet) {
+			return NewValidationError(fmt.Sprintf("must only contain characters from %q", alphabet))
+		}
+		return nil
+	}
+}
+
+// onlyContains reports whether every character of v is in alphabet.
+func onlyContains(v, alphabet string) bool {
+	for _, r := range v {
+		if !strings.ContainsRune(alphabet, r) {
+			return false
+		}
+	}
+	return true
+}
@@ -1,9 +1,8 @@
 package validation
 
 import (
-	"errors"
-	"fmt"
 	"reflect"
+	"strings"
 	"unsafe"
 )
 
@@ -17,22 +16,36 @@ type FieldDef[S any] struct {
 func Field[S any, T any](s *S, fieldPtr *T, validators ...Validator[T]) FieldDef[S] {
 	return FieldDef[S]{
 		validate: func() error {
-			name := resolveFieldName(s, fieldPtr)
-			if err := Validate(*fieldPtr, validators...); err != nil {
-				return fmt.Errorf("%s: %w", name, err)
-			}
-			return nil
+			return NewFieldError(resolveFieldName(s, fieldPtr), Validate(*fieldPtr, validators...))
+		},
+	}
+}
+
+// FieldWithDefault is like Field, but first sets the field to defaultValue when it holds the
+// zero value (see WithDefault). s must be the struct to update, not a copy.
+//
+// Example:
+//
+//	func (in *ListOrdersInput) Validate() error {
+//	    return validation.ValidateStruct(
+//	        validation.FieldWithDefault(in, &in.PageSize, 20, validation.Range(1, 100)),
+//	    )
+//	}
+func FieldWithDefault[S any, T comparable](s *S, fieldPtr *T, defaultValue T, validators ...Validator[T]) FieldDef[S] {
+	return FieldDef[S]{
+		validate: func() error {
+			return NewFieldError(resolveFieldName(s, fieldPtr), WithDefault(fieldPtr, defaultValue, validators...))
 		},
 	}
 }
 
 // ValidateStruct runs all FieldDefs and joins their errors.
 func ValidateStruct[S any](fields ...FieldDef[S]) error {
-	errs := make([]error, 0, len(fields))
+	report := NewReport()
 	for _, f := range fields {
-		errs = append(errs, f.validate())
+		report.Add(f.validate())
 	}
-	return errors.Join(errs...)
+	return collect(report)
 }
 
 // resolveFieldName finds the struct field name by matching the field pointer offset.
@@ -44,19 +57,21 @@ func resolveFieldName[S any, T any](s *S, fieldPtr *T) string {
 
 	t := reflect.TypeOf(*s)
 	for i := 0; i < t.NumField(); i++ {
-		f := t.Field(i)
-		if f.Offset == offset {
-			if tag, ok := f.Tag.Lookup("json"); ok && tag != "" && tag != "-" {
-				// strip options like omitempty
-				for j, c := range tag {
-					if c == ',' {
-						return tag[:j]
-					}
-				}
-				return tag
-			}
-			return f.Name
+		if f := t.Field(i); f.Offset == offset {
+			return fieldName(f)
 		}
 	}
 	return "unknown"
 }
+
+// fieldName returns the name of a struct field used in error messages: the "json" tag
+// name if present, the Go field name otherwise.
+func fieldName(f reflect.StructField) string {
+	if tag, ok := f.Tag.Lookup("json"); ok && tag != "" && tag != "-" {
+		// strip options like omitempty
+		if name, _, _ := strings.Cut(tag, ","); name != "" {
+			return name
+		}
+	}
+	return f.Name
+}
@@ -68,3 +68,32 @@ func TestValidateStruct(t *testing.T) {
 		}
 	})
 }
+
+func TestFieldWithDefault(t *testing.T) {
+	type ListInput struct {
+		PageSize int `json:"page_size"`
+	}
+
+	t.Run("defaults the field before validating", func(t *testing.T) {
+		in := ListInput{}
+		err := validation.ValidateStruct(
+			validation.FieldWithDefault(&in, &in.PageSize, 20, validation.Range(1, 100)),
+		)
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if in.PageSize != 20 {
+			t.Fatalf("expected page size 20, got: %d", in.PageSize)
+		}
+	})
+
+	t.Run("field error uses json tag as name", func(t *testing.T) {
+		in := ListInput{PageSize: 500}
+		err := validation.ValidateStruct(
+			validation.FieldWithDefault(&in, &in.PageSize, 20, validation.Range(1, 100)),
+		)
+		if err == nil || err.Error() != "page_size: must be between 1 and 100" {
+			t.Fatalf("expected page_size error, got: %v", err)
+		}
+	})
+}
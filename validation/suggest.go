@@ -0,0 +1,77 @@
+package validation
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// unexpectedKey returns the error of a key the schema or rules do not define. When one of
+// known, the defined keys, is close enough to be what was meant, the message suggests it
+// and the "suggestion" parameter holds it, for clients to point at the typo. Keys present
+// in m are not suggested: they were not mistyped.
+func unexpectedKey[V any](key string, known []string, m map[string]V) error {
+	suggestion, ok := closestKey(key, known, m)
+	if !ok {
+		return NewValidationError(fmt.Sprintf("key %q not expected", key))
+	}
+	return NewCodedError(CodeInvalid, fmt.Sprintf("key %q not expected, did you mean %q?", key, suggestion),
+		map[string]any{"suggestion": suggestion})
+}
+
+// maxSuggestedKeyLength is the length in runes beyond which unexpected keys are not
+// compared with the defined ones, the edit distance taking quadratic time.
+const maxSuggestedKeyLength = 64
+
+// closestKey returns the key of known absent from m closest to key, first in order among
+// equally close ones, if key is a likely typo of it: at most one edit, insertion, deletion,
+// substitution or transposition, away for every three characters, ignoring case. Keys
+// longer than maxSuggestedKeyLength get no suggestion.
+func closestKey[V any](key string, known []string, m map[string]V) (string, bool) {
+	length := utf8.RuneCountInString(key)
+	if length > maxSuggestedKeyLength {
+		return "", false
+	}
+	maxDistance := max(1, length/3)
+	best, bestDistance := "", maxDistance+1
+	for _, candidate := range known {
+		if _, present := m[candidate]; present {
+			continue
+		}
+		// the distance is at least the difference in length
+		if d := utf8.RuneCountInString(candidate) - length; d > maxDistance || -d > maxDistance {
+			continue
+		}
+		if d := editDistance(strings.ToLower(key), strings.ToLower(candidate)); d < bestDistance {
+			best, bestDistance = candidate, d
+		}
+	}
+	return best, best != ""
+}
+
+// editDistance returns the optimal string alignment distance between a and b: the number
+// of rune insertions, deletions, substitutions and transpositions of adjacent runes turning
+// a into b.
+func editDistance(a, b string) int {
+	s, t := []rune(a), []rune(b)
+	// rows i-2, i-1 and i of the distances between the prefixes of s and t
+	prev2, prev, row := make([]int, len(t)+1), make([]int, len(t)+1), make([]int, len(t)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(s); i++ {
+		row[0] = i
+		for j := 1; j <= len(t); j++ {
+			cost := 1
+			if s[i-1] == t[j-1] {
+				cost = 0
+			}
+			row[j] = min(prev[j]+1, row[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && s[i-1] == t[j-2] && s[i-2] == t[j-1] {
+				row[j] = min(row[j], prev2[j-2]+1)
+			}
+		}
+		prev2, prev, row = prev, row, prev2
+	}
+	return prev[len(t)]
+}
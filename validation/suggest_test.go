@@ -0,0 +1,72 @@
+package validation_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/quantumcycle/protego/validation"
+)
+
+func TestUnexpectedKeySuggestions(t *testing.T) {
+	schema := validation.NewSchema(
+		validation.Key("name", validation.MinLengthRule(3)),
+		validation.Key("email"),
+		validation.Key("username"),
+		validation.Key("shippingAddress"),
+	)
+
+	t.Run("suggests keys a few edits away", func(t *testing.T) {
+		g := NewWithT(t)
+		for typo, key := range map[string]string{
+			"nmae":            "name",     // transposition
+			"emial":           "email",    // transposition
+			"usrname":         "username", // deletion
+			"Email":           "email",    // case
+			"shipingAdress":   "shippingAddress",
+			"shipping_adress": "shippingAddress",
+		} {
+			err := schema.Validate(map[string]any{typo: "x"})
+			g.Expect(err).To(MatchError(`key "`+typo+`" not expected, did you mean "`+key+`"?`), typo)
+
+			var valErr *validation.Error
+			g.Expect(errors.As(err, &valErr)).To(BeTrue())
+			g.Expect(valErr.Code()).To(Equal(validation.CodeInvalid))
+			g.Expect(valErr.Params()).To(Equal(map[string]any{"suggestion": key}))
+		}
+	})
+
+	t.Run("does not suggest distant or present keys", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(schema.Validate(map[string]any{"team": "core"})).To(MatchError(`key "team" not expected`))
+		g.Expect(schema.Validate(map[string]any{"name": "alice", "nmae": "alice"})).To(MatchError(`key "nmae" not expected`))
+
+		long := strings.Repeat("n", 100_000) + "ame"
+		g.Expect(schema.Validate(map[string]any{long: "x"})).To(MatchError(`key "` + long + `" not expected`))
+	})
+
+	t.Run("suggests keys of map rules and groups", func(t *testing.T) {
+		g := NewWithT(t)
+		err := validation.ValidateAnyMap(map[string]any{"phnoe": "555"}, false,
+			validation.MapKey[any]("name", false),
+			validation.OneOfKeys[any]("email", "phone"),
+		)
+		g.Expect(err).To(MatchError(ContainSubstring(`key "phnoe" not expected, did you mean "phone"?`)))
+	})
+
+	t.Run("suggests keys in patches and explanations", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(schema.ValidatePatch(map[string]any{"nmae": "alice"})).To(MatchError(`key "nmae" not expected, did you mean "name"?`))
+
+		explanation := validation.Explain(schema, map[string]any{"nmae": "alice"})
+		g.Expect(explanation.Failures()).To(Equal([]validation.RuleResult{{
+			Key:     "nmae",
+			Rule:    "extra_keys",
+			Params:  map[string]any{"suggestion": "name"},
+			Outcome: validation.Failed,
+			Reason:  `key not expected, did you mean "name"?`,
+		}}))
+	})
+}
@@ -0,0 +1,89 @@
+package validation
+
+import (
+	"fmt"
+	"time"
+)
+
+// IsUTC validates that a string is an RFC3339 date-time in UTC, written with the Z offset.
+// Explicit zero offsets such as "+00:00" are rejected so that stored values compare as strings.
+//
+// Example:
+//
+//	validation.Validate(event.CreatedAt, validation.IsUTC())
+func IsUTC() Validator[string] {
+	return func(v string) error {
+		if _, err := time.Parse(time.RFC3339, v); err != nil {
+			return NewValidationError("must be a valid RFC3339 date-time")
+		}
+		if v[len(v)-1] != 'Z' {
+			return NewValidationError("must be a UTC time with the Z offset")
+		}
+		return nil
+	}
+}
+
+// SameOffsetAs validates that an RFC3339 date-time has the same UTC offset as other, such as
+// the start and end of an event, since mixing offsets in a payload is usually a client bug.
+// An invalid other is ignored, leaving its own validation to report it.
+//
+// Example:
+//
+//	return validation.NewFieldError("end", validation.Validate(e.End, validation.SameOffsetAs(e.Start)))
+func SameOffsetAs(other string) Validator[string] {
+	return OffsetDiffersBy(other, 0)
+}
+
+// OffsetDiffersBy validates that the UTC offset of an RFC3339 date-time is the offset of other
+// plus diff, such as a departure and an arrival across known time zones. An invalid other is
+// ignored, leaving its own validation to report it.
+//
+// Example:
+//
+//	// arrival in New York (-05:00) of a flight from Paris (+01:00)
+//	validation.Validate(f.Arrival, validation.OffsetDiffersBy(f.Departure, -6*time.Hour))
+func OffsetDiffersBy(other string, diff time.Duration) Validator[string] {
+	return func(v string) error {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return NewValidationError("must be a valid RFC3339 date-time")
+		}
+		o, err := time.Parse(time.RFC3339, other)
+		if err != nil {
+			return nil
+		}
+		_, offset := t.Zone()
+		_, otherOffset := o.Zone()
+		if expected := otherOffset + int(diff/time.Second); offset != expected {
+			return NewValidationError(fmt.Sprintf("must have UTC offset %s", formatOffset(expected)))
+		}
+		return nil
+	}
+}
+
+// SameZoneAs validates that a time.Time is in the same location as other, comparing location
+// names such as "Europe/Paris".
+//
+// Example:
+//
+//	validation.Validate(meeting.End, validation.SameZoneAs(meeting.Start))
+func SameZoneAs(other time.Time) Validator[time.Time] {
+	return func(v time.Time) error {
+		if v.Location().String() != other.Location().String() {
+			return NewValidationError(fmt.Sprintf("must be in time zone %s", other.Location()))
+		}
+		return nil
+	}
+}
+
+// formatOffset formats an offset in seconds east of UTC as in RFC3339, e.g. "-05:00".
+func formatOffset(seconds int) string {
+	if seconds == 0 {
+		return "Z"
+	}
+	sign := '+'
+	if seconds < 0 {
+		sign, seconds = '-', -seconds
+	}
+	return fmt.Sprintf("%c%02d:%02d", sign, seconds/3600, seconds%3600/60)
+}
@@ -0,0 +1,55 @@
+package validation_test
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/quantumcycle/protego/validation"
+)
+
+func TestIsUTC(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(validation.Validate("2024-03-01T10:00:00Z", validation.IsUTC())).To(Succeed())
+	g.Expect(validation.Validate("2024-03-01T10:00:00.123Z", validation.IsUTC())).To(Succeed())
+	g.Expect(validation.Validate("2024-03-01T10:00:00+00:00", validation.IsUTC())).To(MatchError("must be a UTC time with the Z offset"))
+	g.Expect(validation.Validate("2024-03-01T10:00:00+02:00", validation.IsUTC())).To(MatchError("must be a UTC time with the Z offset"))
+	g.Expect(validation.Validate("2024-03-01", validation.IsUTC())).To(MatchError("must be a valid RFC3339 date-time"))
+}
+
+func TestSameOffsetAs(t *testing.T) {
+	t.Run("passes matching offsets", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(validation.Validate("2024-03-01T12:00:00+02:00", validation.SameOffsetAs("2024-03-01T10:00:00+02:00"))).To(Succeed())
+		g.Expect(validation.Validate("2024-03-01T12:00:00Z", validation.SameOffsetAs("2024-03-01T10:00:00+00:00"))).To(Succeed())
+	})
+
+	t.Run("rejects different offsets", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(validation.Validate("2024-03-01T12:00:00Z", validation.SameOffsetAs("2024-03-01T10:00:00+02:00"))).To(MatchError("must have UTC offset +02:00"))
+		g.Expect(validation.Validate("2024-03-01T12:00:00+05:30", validation.SameOffsetAs("2024-03-01T10:00:00Z"))).To(MatchError("must have UTC offset Z"))
+	})
+
+	t.Run("ignores an invalid other", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(validation.Validate("2024-03-01T12:00:00Z", validation.SameOffsetAs("yesterday"))).To(Succeed())
+		g.Expect(validation.Validate("noon", validation.SameOffsetAs("2024-03-01T10:00:00Z"))).To(MatchError("must be a valid RFC3339 date-time"))
+	})
+}
+
+func TestOffsetDiffersBy(t *testing.T) {
+	g := NewWithT(t)
+	departure := "2024-03-01T10:00:00+01:00"
+	g.Expect(validation.Validate("2024-03-01T13:00:00-05:00", validation.OffsetDiffersBy(departure, -6*time.Hour))).To(Succeed())
+	g.Expect(validation.Validate("2024-03-01T13:00:00-04:00", validation.OffsetDiffersBy(departure, -6*time.Hour))).To(MatchError("must have UTC offset -05:00"))
+	g.Expect(validation.Validate("2024-03-02T01:00:00+05:30", validation.OffsetDiffersBy(departure, 270*time.Minute))).To(Succeed())
+}
+
+func TestSameZoneAs(t *testing.T) {
+	g := NewWithT(t)
+	paris := time.FixedZone("Europe/Paris", 3600)
+	start := time.Date(2024, 3, 1, 10, 0, 0, 0, paris)
+	g.Expect(validation.Validate(start.Add(time.Hour), validation.SameZoneAs(start))).To(Succeed())
+	g.Expect(validation.Validate(start.UTC(), validation.SameZoneAs(start))).To(MatchError("must be in time zone Europe/Paris"))
+}
@@ -20,7 +20,10 @@
 //	}
 package validation
 
-import "errors"
+import (
+	"context"
+	"errors"
+)
 
 // Validator is a generic validation function that validates a value of type T.
 // It returns an error if validation fails, or nil if the value is valid.
@@ -36,10 +39,13 @@ type Validator[T any] func(T) error
 //	    validation.MinLength(3),
 //	    validation.MaxLength(50),
 //	)
+//
+// When SetIncludeValues is enabled, the validation Error returned by a validator records the
+// offending value (see Error.Value).
 func Validate[T any](value T, validators ...Validator[T]) error {
 	for _, validator := range validators {
 		if err := validator(value); err != nil {
-			return err
+			return withValue(err, value)
 		}
 	}
 	return nil
@@ -52,7 +58,9 @@ type Validatable interface {
 }
 
 // ValidateNested checks if the value implements Validatable and calls its Validate() method.
-// If the value doesn't implement Validatable, it returns nil (no validation performed).
+// Values implementing ValidatableCtx are validated with context.Background(); use
+// ValidateNestedCtx to pass a context.
+// If the value doesn't implement either interface, it returns nil (no validation performed).
 //
 // Example:
 //
@@ -71,10 +79,7 @@ type Validatable interface {
 //	    )
 //	}
 func ValidateNested[T any](value T) error {
-	if v, ok := any(value).(Validatable); ok {
-		return v.Validate()
-	}
-	return nil
+	return ValidateNestedCtx(context.Background(), value)
 }
 
 // Nested returns a validator that calls the Validate() method on nested structs.
@@ -92,16 +97,60 @@ func Nested[T Validatable]() Validator[T] {
 // Error represents an error that occurred during validation.
 // It wraps validation errors to make them identifiable as Protego errors.
 type Error struct {
-	msg string
-	err error
+	msg       string
+	err       error
+	code      string
+	params    map[string]any
+	value     any
+	hasValue  bool
+	sensitive bool
 }
 
-// Error returns the error message.
+// Error returns the error message, followed by the offending value when one was recorded
+// (see SetIncludeValues), e.g. "must be at most 5, got 12".
 func (e *Error) Error() string {
+	msg := e.msg
 	if e.err != nil {
-		return e.err.Error()
+		msg = e.err.Error()
+	}
+	if e.hasValue {
+		msg += ", got " + formatValue(e.value)
+	}
+	return msg
+}
+
+// Value returns the offending value recorded with the error, if any (see SetIncludeValues).
+// Values of errors returned by Sensitive validators are never recorded.
+func (e *Error) Value() (any, bool) {
+	return e.value, e.hasValue
+}
+
+// Code returns the machine-readable code of the error (see NewCodedError). Errors created
+// without one get the code of the error they wrap, if it has a Code method, such as the
+// playground TagError, and CodeInvalid otherwise.
+func (e *Error) Code() string {
+	if e.code != "" {
+		return e.code
+	}
+	var coded interface{ Code() string }
+	if e.err != nil && errors.As(e.err, &coded) {
+		return coded.Code()
 	}
-	return e.msg
+	return CodeInvalid
+}
+
+// Params returns the parameters of the failed rule (see NewCodedError), such as the minimum
+// of a range, taken from the wrapped error like Code when the error has none. It returns nil
+// when there are no parameters.
+func (e *Error) Params() map[string]any {
+	if e.params != nil {
+		return e.params
+	}
+	var withParams interface{ Params() map[string]any }
+	if e.err != nil && errors.As(e.err, &withParams) {
+		return withParams.Params()
+	}
+	return nil
 }
 
 // Unwrap returns the underlying error, if any.
@@ -125,6 +174,17 @@ func NewValidationError(msg string) error {
 	return &Error{msg: msg}
 }
 
+// NewCodedError creates a validation Error with a machine-readable code and the parameters
+// of the failed rule, for clients to translate or react to failures without parsing
+// messages. They appear in the JSON form of the error (see ErrorObjects).
+//
+// Example:
+//
+//	return validation.NewCodedError("too_short", "must be at least 3 characters", map[string]any{"min": 3})
+func NewCodedError(code, msg string, params map[string]any) error {
+	return &Error{msg: msg, code: code, params: params}
+}
+
 // WrapError wraps an existing error as a validation Error.
 // If the error is already a validation Error, it returns it as-is.
 // This is useful for wrapping errors from external libraries (like go-playground/validator).
@@ -143,6 +203,41 @@ func WrapError(err error) error {
 	return &Error{err: err}
 }
 
+// FieldError is an error attributed to a named field, map key or request parameter.
+// The message is prefixed with the field name, e.g. "email: required".
+type FieldError struct {
+	field string
+	err   error
+}
+
+// Error returns the error message prefixed with the field name.
+func (e *FieldError) Error() string {
+	return e.field + ": " + e.err.Error()
+}
+
+// Field returns the name of the field the error is attributed to.
+func (e *FieldError) Field() string {
+	return e.field
+}
+
+// Unwrap returns the underlying error.
+func (e *FieldError) Unwrap() error {
+	return e.err
+}
+
+// NewFieldError attributes an error to the named field.
+// It returns nil if err is nil, so it can wrap validator results directly.
+//
+// Example:
+//
+//	return validation.NewFieldError("email", validation.Validate(input.Email, validation.Required[string]()))
+func NewFieldError(field string, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &FieldError{field: field, err: err}
+}
+
 // IsValidationError checks if an error is a validation Error or wraps one.
 // This allows users to detect if an error came from Protego validation.
 //
@@ -155,3 +250,39 @@ func IsValidationError(err error) bool {
 	var valErr *Error
 	return errors.As(err, &valErr)
 }
+
+// IsSystemError reports whether err is, or contains, an error that is not a validation
+// Error, such as a database outage in a uniqueness check or a canceled context.
+// Such failures are not the caller's fault: they should be answered with a 5xx status
+// rather than a 4xx, even when joined with validation errors.
+//
+// Example:
+//
+//	switch {
+//	case validation.IsSystemError(err):
+//	    http.Error(w, "internal error", http.StatusInternalServerError)
+//	case err != nil:
+//	    http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+//	}
+func IsSystemError(err error) bool {
+	switch e := err.(type) {
+	case nil:
+		return false
+	case *Error:
+		return false
+	case *PanicError:
+		return true
+	case interface{ Unwrap() []error }:
+		for _, inner := range e.Unwrap() {
+			if IsSystemError(inner) {
+				return true
+			}
+		}
+		return false
+	case interface{ Unwrap() error }:
+		inner := e.Unwrap()
+		return inner == nil || IsSystemError(inner)
+	default:
+		return true
+	}
+}
@@ -1,8 +1,11 @@
 package validation_test
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
+	"strings"
 	"testing"
 	"time"
 
@@ -224,6 +227,24 @@ func TestIn(t *testing.T) {
 		err := validation.Validate("AcTiVe", validation.In(true, "ACTIVE", "INACTIVE"))
 		g.Expect(err).To(BeNil())
 	})
+
+	t.Run("large list - checks membership of every value", func(t *testing.T) {
+		g := NewWithT(t)
+		codes := make([]string, 600)
+		for i := range codes {
+			codes[i] = fmt.Sprintf("SKU-%04d", i)
+		}
+		in := validation.In(true, codes...)
+		g.Expect(in("sku-0000")).To(BeNil())
+		g.Expect(in("SKU-0599")).To(BeNil())
+		g.Expect(in("SKU-0600")).To(MatchError(ContainSubstring("must be one of")))
+	})
+
+	t.Run("non-string values - compares their formatted forms case-insensitively", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(validation.Validate(2, validation.In(true, 1, 2, 3))).To(BeNil())
+		g.Expect(validation.Validate(4, validation.In(true, 1, 2, 3))).To(MatchError("must be one of: [1 2 3]"))
+	})
 }
 
 func TestInSlice(t *testing.T) {
@@ -273,6 +294,38 @@ func TestEach(t *testing.T) {
 	})
 }
 
+func TestEachFailFast(t *testing.T) {
+
+	t.Run("passes when all elements valid", func(t *testing.T) {
+		g := NewWithT(t)
+		err := validation.Validate([]string{"test1", "test2"}, validation.EachFailFast(validation.MinLength(3)))
+		g.Expect(err).To(BeNil())
+	})
+
+	t.Run("stops at the first invalid element", func(t *testing.T) {
+		g := NewWithT(t)
+		calls := 0
+		minLength := validation.MinLength(3)
+		counted := func(v string) error {
+			calls++
+			return minLength(v)
+		}
+		err := validation.Validate([]string{"test", "ab", "cd", "test3"}, validation.EachFailFast(counted))
+		g.Expect(err).To(MatchError("index 1: must be at least 3 characters"))
+		g.Expect(validation.IsValidationError(err)).To(BeTrue())
+		g.Expect(calls).To(Equal(2))
+	})
+
+	t.Run("returns system errors unwrapped", func(t *testing.T) {
+		g := NewWithT(t)
+		err := validation.Validate([]string{"a"}, validation.EachFailFast(func(string) error {
+			return errors.New("lookup failed")
+		}))
+		g.Expect(err).To(MatchError("index 0: lookup failed"))
+		g.Expect(validation.IsSystemError(err)).To(BeTrue())
+	})
+}
+
 func TestNilOrNotEmpty(t *testing.T) {
 
 	t.Run("passes when nil", func(t *testing.T) {
@@ -321,6 +374,39 @@ func TestNilOr(t *testing.T) {
 	})
 }
 
+func TestWithDefault(t *testing.T) {
+
+	t.Run("sets the default when zero", func(t *testing.T) {
+		g := NewWithT(t)
+		size := 0
+		err := validation.WithDefault(&size, 20, validation.Range(1, 100))
+		g.Expect(err).To(BeNil())
+		g.Expect(size).To(Equal(20))
+	})
+
+	t.Run("keeps provided values", func(t *testing.T) {
+		g := NewWithT(t)
+		sort := "total"
+		err := validation.WithDefault(&sort, "created_at", validation.In(false, "created_at", "total"))
+		g.Expect(err).To(BeNil())
+		g.Expect(sort).To(Equal("total"))
+	})
+
+	t.Run("validates provided values", func(t *testing.T) {
+		g := NewWithT(t)
+		size := 500
+		err := validation.WithDefault(&size, 20, validation.Range(1, 100))
+		g.Expect(err).To(MatchError("must be between 1 and 100"))
+	})
+
+	t.Run("validates defaults", func(t *testing.T) {
+		g := NewWithT(t)
+		size := 0
+		err := validation.WithDefault(&size, 500, validation.Range(1, 100))
+		g.Expect(err).To(MatchError("must be between 1 and 100"))
+	})
+}
+
 func TestIsInt(t *testing.T) {
 
 	t.Run("passes with valid integer string", func(t *testing.T) {
@@ -433,6 +519,87 @@ func TestOr(t *testing.T) {
 	})
 }
 
+func TestOrPrimary(t *testing.T) {
+	validator := validation.OrPrimary(validation.Contains("@"), validation.StartsWith("+"), validation.StartsWith("00"))
+
+	t.Run("passes when the primary validator passes", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(validation.Validate("john@example.com", validator)).To(Succeed())
+	})
+
+	t.Run("passes when an alternative passes", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(validation.Validate("+33612345678", validator)).To(Succeed())
+	})
+
+	t.Run("returns only the primary error when all fail", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(validation.Validate("john", validator)).To(MatchError(`must contain "@"`))
+	})
+}
+
+func TestOrClosest(t *testing.T) {
+	validator := validation.OrClosest(
+		[]validation.Validator[string]{validation.Contains("@"), validation.MatchesPattern(`^[^@]+@[^@]+\.[a-z]+$`)},
+		[]validation.Validator[string]{validation.StartsWith("+"), validation.MatchesPattern(`^\+\d{10,15}$`)},
+	)
+
+	t.Run("passes when a branch passes", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(validation.Validate("john@example.com", validator)).To(Succeed())
+		g.Expect(validation.Validate("+33612345678", validator)).To(Succeed())
+	})
+
+	t.Run("returns the error of the branch that got furthest", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(validation.Validate("john@example", validator)).To(MatchError(ContainSubstring(`must match pattern "^[^@]+@`)))
+		g.Expect(validation.Validate("+33 6", validator)).To(MatchError(ContainSubstring(`must match pattern "^\\+`)))
+	})
+
+	t.Run("returns the first branch error on ties", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(validation.Validate("john", validator)).To(MatchError(`must contain "@"`))
+	})
+}
+
+func TestOneOf(t *testing.T) {
+	validator := validation.OneOf(map[string]validation.Validator[string]{
+		"a valid email":         validation.Contains("@"),
+		"an E.164 phone number": validation.MatchesPattern(`^\+\d{10,15}$`),
+		"a username":            validation.MatchesPattern(`^[a-z]{3,}$`),
+	})
+
+	t.Run("passes when an alternative passes", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(validation.Validate("john@example.com", validator)).To(Succeed())
+		g.Expect(validation.Validate("+33612345678", validator)).To(Succeed())
+	})
+
+	t.Run("lists the labels when all fail", func(t *testing.T) {
+		g := NewWithT(t)
+		err := validation.Validate("J!", validator)
+		g.Expect(err).To(MatchError("must be a username, a valid email or an E.164 phone number"))
+		g.Expect(validation.IsValidationError(err)).To(BeTrue())
+	})
+
+	t.Run("uses the label alone for a single alternative", func(t *testing.T) {
+		g := NewWithT(t)
+		single := validation.OneOf(map[string]validation.Validator[int]{"positive": validation.Positive[int]()})
+		g.Expect(validation.Validate(-1, single)).To(MatchError("must be positive"))
+	})
+
+	t.Run("tries every alternative before returning system errors", func(t *testing.T) {
+		g := NewWithT(t)
+		failure := errors.New("directory unavailable")
+		withLookup := validation.OneOf(map[string]validation.Validator[string]{
+			"a known employee": validation.Custom(func(string) error { return failure }),
+			"a valid email":    validation.Contains("@"),
+		})
+		g.Expect(validation.Validate("john@example.com", withLookup)).To(Succeed())
+		g.Expect(validation.Validate("john", withLookup)).To(Equal(failure))
+	})
+}
+
 func TestNestedValidation(t *testing.T) {
 
 	type Address struct {
@@ -618,6 +785,36 @@ func TestMultipleOf(t *testing.T) {
 	})
 }
 
+func TestFloatGuards(t *testing.T) {
+
+	t.Run("NoNaN fails with NaN", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(validation.Validate(1.5, validation.NoNaN[float64]())).To(BeNil())
+		g.Expect(validation.Validate(math.Inf(1), validation.NoNaN[float64]())).To(BeNil())
+		g.Expect(validation.Validate(math.NaN(), validation.NoNaN[float64]())).To(MatchError("must be a number"))
+	})
+
+	t.Run("NoInf fails with infinities", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(validation.Validate(math.NaN(), validation.NoInf[float64]())).To(BeNil())
+		g.Expect(validation.Validate(math.Inf(-1), validation.NoInf[float64]())).To(MatchError("must be finite"))
+		g.Expect(validation.Validate(float32(math.Inf(1)), validation.NoInf[float32]())).To(MatchError("must be finite"))
+	})
+
+	t.Run("FiniteFloat fails with NaN and infinities", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(validation.Validate(-2.5, validation.FiniteFloat[float64]())).To(BeNil())
+		g.Expect(validation.Validate(math.NaN(), validation.FiniteFloat[float64]())).To(MatchError("must be a finite number"))
+		g.Expect(validation.Validate(math.Inf(1), validation.FiniteFloat[float64]())).To(MatchError("must be a finite number"))
+	})
+
+	t.Run("NaN passes range checks without a guard", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(validation.Validate(math.NaN(), validation.Range(0.0, 1.0))).To(BeNil())
+		g.Expect(validation.Validate(math.NaN(), validation.FiniteFloat[float64](), validation.Range(0.0, 1.0))).To(HaveOccurred())
+	})
+}
+
 func TestNotIn(t *testing.T) {
 
 	t.Run("passes when not in list", func(t *testing.T) {
@@ -637,6 +834,12 @@ func TestNotIn(t *testing.T) {
 		err := validation.Validate("ADMIN", validation.NotIn(true, "admin", "root"))
 		g.Expect(err).To(MatchError(ContainSubstring("cannot be one of")))
 	})
+
+	t.Run("case insensitive - passes when not in list", func(t *testing.T) {
+		g := NewWithT(t)
+		err := validation.Validate("Guest", validation.NotIn(true, "ADMIN", "Root"))
+		g.Expect(err).To(BeNil())
+	})
 }
 
 func TestMinItems(t *testing.T) {
@@ -704,6 +907,41 @@ func TestUniqueItems(t *testing.T) {
 	})
 }
 
+func TestIsDelimitedSet(t *testing.T) {
+	scopes := validation.IsDelimitedSet(",", 3, "read", "write", "admin")
+
+	t.Run("passes with allowed items", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(validation.Validate("read", scopes)).To(BeNil())
+		g.Expect(validation.Validate("read, write,admin", scopes)).To(BeNil())
+		g.Expect(validation.Validate("", scopes)).To(BeNil())
+	})
+
+	t.Run("fails with unknown items", func(t *testing.T) {
+		g := NewWithT(t)
+		err := validation.Validate("read,delete", scopes)
+		g.Expect(err).To(MatchError(`item "delete" must be one of: [read write admin]`))
+	})
+
+	t.Run("fails with empty or duplicate items", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(validation.Validate("read,,write", scopes)).To(MatchError("item at index 1 must not be empty"))
+		g.Expect(validation.Validate("read,", scopes)).To(MatchError("item at index 1 must not be empty"))
+		g.Expect(validation.Validate("read, read", scopes)).To(MatchError(`item "read" at index 1 must not be repeated`))
+	})
+
+	t.Run("panics without separator", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(func() { validation.IsDelimitedSet("", 0, "a") }).To(PanicWith("validation: IsDelimitedSet requires a separator"))
+	})
+
+	t.Run("fails with too many items", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(validation.Validate("read,write,admin,read", scopes)).To(MatchError("must have at most 3 items"))
+		g.Expect(validation.Validate("a|b|c|d", validation.IsDelimitedSet("|", 0, "a", "b", "c", "d"))).To(BeNil())
+	})
+}
+
 func TestStartsWith(t *testing.T) {
 
 	t.Run("passes when starts with prefix", func(t *testing.T) {
@@ -762,6 +1000,111 @@ func TestMatchesPattern(t *testing.T) {
 		err := validation.Validate("abc-1234", validation.MatchesPattern(`^[A-Z]{3}-\d{4}$`))
 		g.Expect(err).To(MatchError(ContainSubstring("must match pattern")))
 	})
+	t.Run("keeps matching patterns evicted from the cache", func(t *testing.T) {
+		g := NewWithT(t)
+		first := validation.MatchesPattern(`^order-0$`)
+		for i := 0; i < 2000; i++ { // patterns built from runtime data
+			g.Expect(validation.Validate(fmt.Sprintf("order-%d", i), validation.MatchesPattern(fmt.Sprintf(`^order-%d$`, i)))).To(Succeed())
+		}
+		g.Expect(validation.Validate("order-0", first)).To(Succeed())
+		g.Expect(validation.Validate("order-0", validation.MatchesPattern(`^order-0$`))).To(Succeed())
+		g.Expect(validation.Validate("order-1", validation.MatchesPattern(`^order-0$`))).To(HaveOccurred())
+	})
+}
+
+func TestIsUUIDString(t *testing.T) {
+
+	t.Run("passes with any UUID version", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(validation.Validate("f47ac10b-58cc-4372-a567-0e02b2c3d479", validation.IsUUIDString())).To(BeNil())
+		g.Expect(validation.Validate("6BA7B810-9DAD-11D1-80B4-00C04FD430C8", validation.IsUUIDString())).To(BeNil())
+		g.Expect(validation.Validate("00000000-0000-0000-0000-000000000000", validation.IsUUIDString())).To(BeNil())
+	})
+
+	t.Run("fails with malformed UUIDs", func(t *testing.T) {
+		g := NewWithT(t)
+		for _, v := range []string{
+			"",
+			"not-a-uuid",
+			"f47ac10b58cc4372a5670e02b2c3d479",
+			"{f47ac10b-58cc-4372-a567-0e02b2c3d479}",
+			"f47ac10b-58cc-4372-a567-0e02b2c3d47g",
+			"f47ac10b-58cc-4372-a5670-e02b2c3d479",
+		} {
+			g.Expect(validation.Validate(v, validation.IsUUIDString())).To(MatchError("must be a valid UUID"), v)
+		}
+	})
+}
+
+func TestIsNanoID(t *testing.T) {
+
+	t.Run("passes with the default alphabet", func(t *testing.T) {
+		g := NewWithT(t)
+		err := validation.Validate("V1StGXR8_Z5jdHi6B-myT", validation.IsNanoID(21, ""))
+		g.Expect(err).To(BeNil())
+	})
+
+	t.Run("passes with a custom alphabet", func(t *testing.T) {
+		g := NewWithT(t)
+		err := validation.Validate("4f90d13a", validation.IsNanoID(8, "0123456789abcdef"))
+		g.Expect(err).To(BeNil())
+	})
+
+	t.Run("counts characters of non-ASCII alphabets", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(validation.Validate("αβγδαβγδ", validation.IsNanoID(8, "αβγδ"))).To(Succeed())
+		g.Expect(validation.Validate("αβγδ", validation.IsNanoID(8, "αβγδ"))).To(MatchError("must be a valid Nano ID of 8 characters"))
+	})
+
+	t.Run("fails with wrong length", func(t *testing.T) {
+		g := NewWithT(t)
+		err := validation.Validate("V1StGXR8_Z5jdHi6B", validation.IsNanoID(21, ""))
+		g.Expect(err).To(MatchError("must be a valid Nano ID of 21 characters"))
+	})
+
+	t.Run("fails with characters outside the alphabet", func(t *testing.T) {
+		g := NewWithT(t)
+		err := validation.Validate("4f90d13z", validation.IsNanoID(8, "0123456789abcdef"))
+		g.Expect(err).To(MatchError("must be a valid Nano ID of 8 characters"))
+	})
+}
+
+func TestIsShortCode(t *testing.T) {
+
+	t.Run("passes with alphanumeric codes by default", func(t *testing.T) {
+		g := NewWithT(t)
+		err := validation.Validate("aB3xY9", validation.IsShortCode(validation.ShortCodeOptions{MinLength: 4, MaxLength: 8}))
+		g.Expect(err).To(BeNil())
+	})
+
+	t.Run("fails with length out of range", func(t *testing.T) {
+		g := NewWithT(t)
+		opts := validation.ShortCodeOptions{MinLength: 4, MaxLength: 8}
+		g.Expect(validation.Validate("aB3", validation.IsShortCode(opts))).To(MatchError("must be between 4 and 8 characters"))
+		g.Expect(validation.Validate("aB3xY9zz9", validation.IsShortCode(opts))).To(MatchError("must be between 4 and 8 characters"))
+	})
+
+	t.Run("has no maximum when MaxLength is zero", func(t *testing.T) {
+		g := NewWithT(t)
+		opts := validation.ShortCodeOptions{MinLength: 4}
+		g.Expect(validation.Validate("aB3xY9zz9aB3xY9zz9", validation.IsShortCode(opts))).To(BeNil())
+		g.Expect(validation.Validate("aB", validation.IsShortCode(opts))).To(MatchError("must be at least 4 characters"))
+	})
+
+	t.Run("fails with characters outside the alphabet", func(t *testing.T) {
+		g := NewWithT(t)
+		err := validation.Validate("abc-12", validation.IsShortCode(validation.ShortCodeOptions{MinLength: 4, Alphabet: "abc123"}))
+		g.Expect(err).To(MatchError(`must only contain characters from "abc123"`))
+	})
+
+	t.Run("excludes ambiguous characters", func(t *testing.T) {
+		g := NewWithT(t)
+		opts := validation.ShortCodeOptions{MinLength: 4, MaxLength: 8, ExcludeAmbiguous: true}
+		g.Expect(validation.Validate("aB3xY9", validation.IsShortCode(opts))).To(BeNil())
+		for _, code := range []string{"aB0xY9", "aBOxY9", "aB1xY9", "aBlxY9", "aBIxY9"} {
+			g.Expect(validation.Validate(code, validation.IsShortCode(opts))).To(MatchError(ContainSubstring("must only contain characters from")), code)
+		}
+	})
 }
 
 func TestIsRFC3339DateTime(t *testing.T) {
@@ -995,6 +1338,95 @@ func TestValidateStringMap(t *testing.T) {
 		)
 		g.Expect(err).To(MatchError(ContainSubstring("not expected")))
 	})
+
+	t.Run("reports every failing, missing and unexpected key", func(t *testing.T) {
+		g := NewWithT(t)
+		m := map[string]string{"age": "thirty", "zone": "eu", "extra": "value"}
+		err := validation.ValidateStringMap(m, false,
+			validation.MapKey("name", true, validation.Required[string]()),
+			validation.MapKey("age", true, validation.IsInt(), validation.MinLength(10)),
+		)
+		g.Expect(err).To(MatchError("key \"name\" is required\n" +
+			"key \"age\": must be a valid integer\n" +
+			"key \"extra\" not expected\n" +
+			"key \"zone\" not expected"))
+		g.Expect(validation.IsValidationError(err)).To(BeTrue())
+	})
+
+	t.Run("fills defaults of missing keys", func(t *testing.T) {
+		g := NewWithT(t)
+		m := map[string]string{"name": "John"}
+		err := validation.ValidateStringMap(m, false,
+			validation.MapKey("name", true, validation.Required[string]()),
+			validation.MapKey("region", true, validation.In(false, "eu", "us")).Default("eu"),
+		)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(m).To(Equal(map[string]string{"name": "John", "region": "eu"}))
+
+		m = map[string]string{"region": "us"}
+		g.Expect(validation.ValidateStringMap(m, false,
+			validation.MapKey[string]("region", false).Default("eu"),
+		)).To(Succeed())
+		g.Expect(m["region"]).To(Equal("us"))
+	})
+
+	t.Run("validates defaults", func(t *testing.T) {
+		g := NewWithT(t)
+		err := validation.ValidateStringMap(map[string]string{}, false,
+			validation.MapKey("port", false, validation.IsInt()).Default("http"),
+		)
+		g.Expect(err).To(MatchError(`key "port": must be a valid integer`))
+	})
+
+	t.Run("normalizes values before validating them", func(t *testing.T) {
+		g := NewWithT(t)
+		m := map[string]string{"host": "  Example.COM "}
+		err := validation.ValidateStringMap(m, false,
+			validation.MapKey("host", true, validation.MaxLength(11)).Normalize(func(h string) string {
+				return strings.ToLower(strings.TrimSpace(h))
+			}),
+		)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(m["host"]).To(Equal("example.com"))
+	})
+}
+
+func TestIsCalendarDate(t *testing.T) {
+	t.Run("passes existing dates", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(validation.Validate("2024-02-29", validation.IsCalendarDate("2006-01-02"))).To(BeNil())
+		g.Expect(validation.Validate("2024-1-5", validation.IsCalendarDate("2006-1-2"))).To(BeNil())
+	})
+
+	t.Run("rejects out-of-range days and months", func(t *testing.T) {
+		g := NewWithT(t)
+		for _, v := range []string{"2023-02-29", "2024-02-30", "2024-04-31", "2024-13-01", "2024-00-10"} {
+			g.Expect(validation.Validate(v, validation.IsCalendarDate("2006-01-02"))).To(MatchError("must be a valid calendar date"), v)
+		}
+	})
+
+	t.Run("rejects values the layout would not produce", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(validation.Validate("2024-01-05", validation.IsCalendarDate("2006-1-2"))).To(MatchError("must be a valid calendar date"))
+	})
+}
+
+func TestIsLeapYearSafeDate(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(validation.Validate("02-28", validation.IsLeapYearSafeDate("01-02"))).To(BeNil())
+	g.Expect(validation.Validate("02-29", validation.IsLeapYearSafeDate("01-02"))).To(MatchError("must be a date that exists every year"))
+	g.Expect(validation.Validate("02-30", validation.IsLeapYearSafeDate("01-02"))).To(MatchError("must be a valid calendar date"))
+}
+
+func TestIsValidCalendarDate(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(validation.IsValidCalendarDate(2024, 2, 29)).To(BeNil())
+	g.Expect(validation.IsValidCalendarDate(2000, 2, 29)).To(BeNil())
+	g.Expect(validation.IsValidCalendarDate(2023, 2, 29)).To(MatchError("day: must be between 1 and 28"))
+	g.Expect(validation.IsValidCalendarDate(1900, 2, 29)).To(MatchError("day: must be between 1 and 28"))
+	g.Expect(validation.IsValidCalendarDate(2024, 4, 31)).To(MatchError("day: must be between 1 and 30"))
+	g.Expect(validation.IsValidCalendarDate(2024, 13, 1)).To(MatchError("month: must be between 1 and 12"))
+	g.Expect(validation.IsValidCalendarDate(2024, 1, 0)).To(MatchError("day: must be between 1 and 31"))
 }
 
 func TestIsFutureDate(t *testing.T) {
@@ -1166,6 +1598,50 @@ func TestValidateAnyMap(t *testing.T) {
 		g.Expect(err).To(MatchError(ContainSubstring("age")))
 		g.Expect(err).To(MatchError(ContainSubstring("must be between")))
 	})
+
+	t.Run("requires one of a group of keys", func(t *testing.T) {
+		g := NewWithT(t)
+		rules := []validation.MapKeyRule[any]{
+			validation.OneOfKeys[any]("email", "phone"),
+			validation.MapKey("email", false, validation.StringValidator(validation.Contains("@"))),
+		}
+		g.Expect(validation.ValidateAnyMap(map[string]any{"phone": "555-0100"}, false, rules...)).To(Succeed())
+		g.Expect(validation.ValidateAnyMap(map[string]any{"email": "a@b.c", "phone": "555-0100"}, false, rules...)).To(Succeed())
+		g.Expect(validation.ValidateAnyMap(map[string]any{}, false, rules...)).To(MatchError(`one of keys "email", "phone" is required`))
+		g.Expect(validation.ValidateAnyMap(map[string]any{"email": "nope"}, false, rules...)).To(MatchError(`key "email": must contain "@"`))
+	})
+
+	t.Run("requires all or none of a group of keys", func(t *testing.T) {
+		g := NewWithT(t)
+		rule := validation.AllOrNoneKeys[any]("lat", "lng")
+		g.Expect(validation.ValidateAnyMap(map[string]any{}, false, rule)).To(Succeed())
+		g.Expect(validation.ValidateAnyMap(map[string]any{"lat": 48.8, "lng": 2.3}, false, rule)).To(Succeed())
+		err := validation.ValidateAnyMap(map[string]any{"lat": 48.8}, false, rule)
+		g.Expect(err).To(MatchError(`keys "lat", "lng" must be provided together, missing "lng"`))
+		g.Expect(validation.IsValidationError(err)).To(BeTrue())
+	})
+
+	t.Run("applies conditional rules when their condition holds", func(t *testing.T) {
+		g := NewWithT(t)
+		rules := []validation.MapKeyRule[any]{
+			validation.MapKey("delivery", true, validation.StringValidator(validation.In(false, "ship", "pickup"))),
+			validation.MapKeyIf("shippingAddress", validation.KeyEquals[any]("delivery", "ship"), validation.StringValidator(validation.MinLength(10))),
+		}
+		g.Expect(validation.ValidateAnyMap(map[string]any{"delivery": "pickup"}, false, rules...)).To(Succeed())
+		g.Expect(validation.ValidateAnyMap(map[string]any{"delivery": "pickup", "shippingAddress": "x"}, false, rules...)).To(Succeed())
+		g.Expect(validation.ValidateAnyMap(map[string]any{"delivery": "ship", "shippingAddress": "1 Main Street"}, false, rules...)).To(Succeed())
+		g.Expect(validation.ValidateAnyMap(map[string]any{"delivery": "ship"}, false, rules...)).To(MatchError(`key "shippingAddress" is required`))
+		g.Expect(validation.ValidateAnyMap(map[string]any{"delivery": "ship", "shippingAddress": "x"}, false, rules...)).To(MatchError(ContainSubstring(`key "shippingAddress": must be at least 10 characters`)))
+	})
+
+	t.Run("checks key groups after defaults", func(t *testing.T) {
+		g := NewWithT(t)
+		m := map[string]string{"lat": "48.8"}
+		g.Expect(validation.ValidateStringMap(m, false,
+			validation.AllOrNoneKeys[string]("lat", "lng"),
+			validation.MapKey[string]("lng", false).Default("0"),
+		)).To(Succeed())
+	})
 }
 
 func TestStringValidator(t *testing.T) {
@@ -1242,6 +1718,67 @@ func TestIntValidator(t *testing.T) {
 		err := validator(nil)
 		g.Expect(err).To(MatchError(ContainSubstring("must be a number")))
 	})
+
+	t.Run("fails with fractional numbers instead of truncating", func(t *testing.T) {
+		g := NewWithT(t)
+		validator := validation.IntValidator(validation.Range(0, 120))
+		g.Expect(validator(30.5)).To(MatchError("must be an integer"))
+		g.Expect(validator(math.NaN())).To(MatchError("must be an integer"))
+		g.Expect(validator(json.Number("30.5"))).To(MatchError("must be an integer"))
+	})
+
+	t.Run("fails with floats too large to be exact", func(t *testing.T) {
+		g := NewWithT(t)
+		validator := validation.IntValidator(validation.Positive[int]())
+		g.Expect(validator(float64(1<<53 - 1))).To(BeNil())
+		g.Expect(validator(float64(1 << 60))).To(MatchError("must be an integer between -9007199254740991 and 9007199254740991"))
+		g.Expect(validator(math.Inf(1))).To(MatchError("must be an integer between -9007199254740991 and 9007199254740991"))
+	})
+
+	t.Run("passes with json.Number", func(t *testing.T) {
+		g := NewWithT(t)
+		validator := validation.IntValidator(validation.Range(0, 2000))
+		g.Expect(validator(json.Number("30"))).To(BeNil())
+		g.Expect(validator(json.Number("1e3"))).To(BeNil())
+		g.Expect(validator(json.Number("abc"))).To(MatchError("must be a number"))
+	})
+}
+
+func TestInt64Validator(t *testing.T) {
+
+	t.Run("passes with large json.Number IDs", func(t *testing.T) {
+		g := NewWithT(t)
+		var got int64
+		validator := validation.Int64Validator(func(v int64) error { got = v; return nil })
+		g.Expect(validator(json.Number("9007199254740993"))).To(BeNil())
+		g.Expect(got).To(Equal(int64(9007199254740993)))
+	})
+
+	t.Run("fails with out of range numbers", func(t *testing.T) {
+		g := NewWithT(t)
+		validator := validation.Int64Validator(validation.Positive[int64]())
+		g.Expect(validator(uint64(math.MaxUint64))).To(MatchError("must be an integer between -9223372036854775808 and 9223372036854775807"))
+		g.Expect(validator(float64(9007199254740993))).To(MatchError(ContainSubstring("must be an integer between")))
+	})
+}
+
+func TestUintValidator(t *testing.T) {
+
+	t.Run("passes with non-negative numbers", func(t *testing.T) {
+		g := NewWithT(t)
+		validator := validation.UintValidator(validation.Max[uint](5))
+		g.Expect(validator(3)).To(BeNil())
+		g.Expect(validator(float64(5))).To(BeNil())
+		g.Expect(validator(uint(2))).To(BeNil())
+		g.Expect(validator(6)).To(MatchError("must be at most 5"))
+	})
+
+	t.Run("fails with negative numbers", func(t *testing.T) {
+		g := NewWithT(t)
+		validator := validation.UintValidator(validation.Max[uint](5))
+		g.Expect(validator(-1)).To(MatchError("must not be negative"))
+		g.Expect(validator(float64(-1))).To(MatchError("must not be negative"))
+	})
 }
 
 func TestFloatValidator(t *testing.T) {
@@ -1281,6 +1818,14 @@ func TestFloatValidator(t *testing.T) {
 		g.Expect(err).To(MatchError(ContainSubstring("must be between")))
 	})
 
+	t.Run("fails with NaN and infinities", func(t *testing.T) {
+		g := NewWithT(t)
+		validator := validation.FloatValidator(validation.Range(0.0, 100.0))
+		g.Expect(validator(math.NaN())).To(MatchError("must be a finite number"))
+		g.Expect(validator(math.Inf(1))).To(MatchError("must be a finite number"))
+		g.Expect(validator(float32(math.Inf(-1)))).To(MatchError("must be a finite number"))
+	})
+
 	t.Run("fails when value is not a number", func(t *testing.T) {
 		g := NewWithT(t)
 		validator := validation.FloatValidator(validation.Range(0.0, 100.0))
@@ -1522,3 +2067,27 @@ func TestIsTimeAfter(t *testing.T) {
 		g.Expect(err).To(MatchError(ContainSubstring("must be after")))
 	})
 }
+
+func TestNoOverlappingRanges(t *testing.T) {
+	type period struct{ from, to time.Time }
+	day := func(d int) time.Time { return time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC) }
+	noOverlap := validation.NoOverlappingRanges(
+		func(p period) time.Time { return p.from },
+		func(p period) time.Time { return p.to },
+	)
+
+	t.Run("passes with disjoint or adjacent ranges", func(t *testing.T) {
+		g := NewWithT(t)
+		periods := []period{{day(10), day(20)}, {day(1), day(5)}, {day(5), day(10)}}
+		g.Expect(validation.Validate(periods, noOverlap)).To(BeNil())
+		g.Expect(validation.Validate([]period{}, noOverlap)).To(BeNil())
+	})
+
+	t.Run("reports every overlapping pair", func(t *testing.T) {
+		g := NewWithT(t)
+		periods := []period{{day(1), day(10)}, {day(20), day(25)}, {day(5), day(8)}, {day(9), day(21)}}
+		err := validation.Validate(periods, noOverlap)
+		g.Expect(err).To(MatchError("items 0 and 2 overlap\nitems 0 and 3 overlap\nitems 1 and 3 overlap"))
+		g.Expect(validation.IsValidationError(err)).To(BeTrue())
+	})
+}
@@ -0,0 +1,100 @@
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+)
+
+var includeValues atomic.Bool
+
+// SetIncludeValues controls whether the offending value is recorded in the validation errors
+// returned by Validate, and thus by Field and ValidateStruct. The value is appended to the
+// message ("must be at most 5, got 12") and available from Error.Value. It is disabled by
+// default; wrap validators of secrets with Sensitive to keep their values out of errors.
+//
+// Example:
+//
+//	func main() {
+//	    validation.SetIncludeValues(os.Getenv("ENV") == "development")
+//	    ...
+//	}
+func SetIncludeValues(enabled bool) {
+	includeValues.Store(enabled)
+}
+
+// Sensitive wraps a validator whose value must never appear in errors, such as a password or
+// an API key. Values already recorded by the wrapped validator are removed, and none is
+// recorded afterwards. System errors (see IsSystemError) are returned unchanged.
+//
+// Example:
+//
+//	validation.Field(in, &in.Password, validation.Sensitive(validation.MinLength(12)))
+func Sensitive[T any](validator Validator[T]) Validator[T] {
+	return func(v T) error {
+		err := validator(v)
+		if err == nil || IsSystemError(err) {
+			return err
+		}
+		return &Error{err: redact(err), sensitive: true}
+	}
+}
+
+// newSensitiveError creates a validation Error that never records the offending value.
+func newSensitiveError(msg string) error {
+	return &Error{msg: msg, sensitive: true}
+}
+
+// withValue records value in err when SetIncludeValues is enabled and err is a validation
+// Error without a value.
+func withValue(err error, value any) error {
+	e, ok := err.(*Error)
+	if !ok || e.hasValue || e.sensitive || !includeValues.Load() {
+		return err
+	}
+	return &Error{msg: e.msg, err: e.err, code: e.code, params: e.params, value: value, hasValue: true}
+}
+
+// redact removes the values recorded in the validation Errors, field errors, joined errors
+// and wrapping errors, such as those of Each and map keys, of the tree of err.
+func redact(err error) error {
+	switch e := err.(type) {
+	case *Error:
+		inner := e.err
+		if inner != nil {
+			inner = redact(inner)
+		}
+		return &Error{msg: e.msg, err: inner, code: e.code, params: e.params, sensitive: e.sensitive}
+	case *FieldError:
+		return &FieldError{field: e.field, err: redact(e.err)}
+	case interface{ Unwrap() []error }:
+		errs := e.Unwrap()
+		redacted := make([]error, len(errs))
+		for i, inner := range errs {
+			redacted[i] = redact(inner)
+		}
+		return errors.Join(redacted...)
+	case interface{ Unwrap() error }:
+		inner := e.Unwrap()
+		if inner == nil {
+			return err
+		}
+		// rebuild the wrapper, such as "index 0: ", around the redacted error; a message not
+		// ending with the wrapped one may quote the value, so it is dropped
+		redacted := redact(inner)
+		if prefix, ok := strings.CutSuffix(err.Error(), inner.Error()); ok {
+			return fmt.Errorf("%s%w", prefix, redacted)
+		}
+		return redacted
+	default:
+		return err
+	}
+}
+
+func formatValue(v any) string {
+	if s, ok := v.(string); ok {
+		return fmt.Sprintf("%q", s)
+	}
+	return fmt.Sprintf("%v", v)
+}
@@ -0,0 +1,110 @@
+package validation_test
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/quantumcycle/protego/validation"
+)
+
+func includeValues(t *testing.T) {
+	validation.SetIncludeValues(true)
+	t.Cleanup(func() { validation.SetIncludeValues(false) })
+}
+
+func TestSetIncludeValues(t *testing.T) {
+	t.Run("values are not included by default", func(t *testing.T) {
+		g := NewWithT(t)
+		err := validation.Validate(12, validation.Max(5))
+		g.Expect(err).To(MatchError("must be at most 5"))
+		_, ok := err.(*validation.Error).Value()
+		g.Expect(ok).To(BeFalse())
+	})
+
+	t.Run("includes the offending value when enabled", func(t *testing.T) {
+		includeValues(t)
+		g := NewWithT(t)
+		err := validation.Validate(12, validation.Max(5))
+		g.Expect(err).To(MatchError("must be at most 5, got 12"))
+		g.Expect(validation.IsValidationError(err)).To(BeTrue())
+
+		var valErr *validation.Error
+		g.Expect(errors.As(err, &valErr)).To(BeTrue())
+		value, ok := valErr.Value()
+		g.Expect(ok).To(BeTrue())
+		g.Expect(value).To(Equal(12))
+	})
+
+	t.Run("quotes string values", func(t *testing.T) {
+		includeValues(t)
+		g := NewWithT(t)
+		err := validation.Validate("ab", validation.MinLength(3))
+		g.Expect(err).To(MatchError(`must be at least 3 characters, got "ab"`))
+	})
+
+	t.Run("includes values in struct validation", func(t *testing.T) {
+		includeValues(t)
+		g := NewWithT(t)
+		in := struct {
+			Age int `json:"age"`
+		}{Age: 150}
+		err := validation.ValidateStruct(validation.Field(&in, &in.Age, validation.Range(0, 120)))
+		g.Expect(err).To(MatchError("age: must be between 0 and 120, got 150"))
+	})
+
+	t.Run("does not record values of system errors", func(t *testing.T) {
+		includeValues(t)
+		g := NewWithT(t)
+		failure := errors.New("database unavailable")
+		err := validation.Validate("x", validation.Custom(func(string) error { return failure }))
+		g.Expect(err).To(Equal(failure))
+	})
+}
+
+func TestSensitive(t *testing.T) {
+	t.Run("never includes the value", func(t *testing.T) {
+		includeValues(t)
+		g := NewWithT(t)
+		err := validation.Validate("hunter2", validation.Sensitive(validation.MinLength(12)))
+		g.Expect(err).To(MatchError("must be at least 12 characters"))
+		g.Expect(validation.IsValidationError(err)).To(BeTrue())
+		_, ok := err.(*validation.Error).Value()
+		g.Expect(ok).To(BeFalse())
+	})
+
+	t.Run("removes values recorded by the wrapped validator", func(t *testing.T) {
+		includeValues(t)
+		g := NewWithT(t)
+		inner := func(v string) error {
+			return errors.Join(validation.Validate(v, validation.MinLength(12)), validation.Validate(v, validation.Contains("!")))
+		}
+		err := validation.Validate("hunter2", validation.Sensitive[string](inner))
+		g.Expect(err).To(MatchError("must be at least 12 characters\nmust contain \"!\""))
+	})
+
+	t.Run("removes values wrapped by Each and map keys", func(t *testing.T) {
+		includeValues(t)
+		g := NewWithT(t)
+		minLength := func(v string) error { return validation.Validate(v, validation.MinLength(12)) }
+		err := validation.Validate([]string{"correct horse battery", "hunter2"}, validation.Sensitive(validation.Each(minLength)))
+		g.Expect(err).To(MatchError("index 1: must be at least 12 characters"))
+		g.Expect(validation.IsValidationError(err)).To(BeTrue())
+
+		credentials := func(m map[string]string) error {
+			return validation.ValidateStringMap(m, false, validation.MapKey("password", true, minLength))
+		}
+		err = validation.Validate(map[string]string{"password": "hunter2"}, validation.Sensitive(credentials))
+		g.Expect(err).To(MatchError(`key "password": must be at least 12 characters`))
+		g.Expect(err.Error()).NotTo(ContainSubstring("hunter2"))
+	})
+
+	t.Run("passes valid values and system errors through", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(validation.Validate("correct horse battery", validation.Sensitive(validation.MinLength(12)))).To(Succeed())
+		failure := errors.New("breach service unavailable")
+		err := validation.Validate("x", validation.Sensitive(validation.Custom(func(string) error { return failure })))
+		g.Expect(err).To(Equal(failure))
+	})
+}
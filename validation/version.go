@@ -0,0 +1,38 @@
+package validation
+
+import (
+	"fmt"
+	"slices"
+)
+
+// ByVersion returns a validator for endpoints accepting several versions of a payload
+// shape: extract tells the version of the payload, such as the value of an apiVersion key
+// or the major of a semantic version, and the payload is validated against the schema of
+// that version. Payloads of a version without schema are rejected, listing the supported
+// ones. Use Discriminated when the version is the value of a key, named in every schema.
+//
+// Example:
+//
+//	validate := validation.ByVersion(func(p map[string]any) string {
+//	    version, _ := p["apiVersion"].(string)
+//	    return strings.SplitN(version, ".", 2)[0]
+//	}, map[string]*validation.Schema{"v1": orderV1, "v2": orderV2})
+func ByVersion(extract func(payload map[string]any) string, schemas map[string]*Schema) Validator[map[string]any] {
+	versions := make([]string, 0, len(schemas))
+	for version := range schemas {
+		versions = append(versions, version)
+	}
+	slices.Sort(versions)
+	supported := quoteKeys(versions)
+
+	return func(payload map[string]any) error {
+		version := extract(payload)
+		if schema, ok := schemas[version]; ok {
+			return schema.Validate(payload)
+		}
+		if version == "" {
+			return NewCodedError("unsupported_version", fmt.Sprintf("version is required, one of %s", supported), map[string]any{"versions": versions})
+		}
+		return NewCodedError("unsupported_version", fmt.Sprintf("version %q is not supported, must be one of %s", version, supported), map[string]any{"versions": versions})
+	}
+}
@@ -0,0 +1,43 @@
+package validation_test
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/quantumcycle/protego/validation"
+)
+
+func TestByVersion(t *testing.T) {
+	v1 := validation.NewSchema(
+		validation.Key("apiVersion"),
+		validation.Key("name", validation.MinLengthRule(1)).Required(),
+	)
+	v2 := validation.NewSchema(
+		validation.Key("apiVersion"),
+		validation.Key("firstName", validation.MinLengthRule(1)).Required(),
+		validation.Key("lastName", validation.MinLengthRule(1)).Required(),
+	)
+	validate := validation.ByVersion(func(p map[string]any) string {
+		version, _ := p["apiVersion"].(string)
+		return version
+	}, map[string]*validation.Schema{"v1": v1, "v2": v2})
+
+	t.Run("validates payloads against the schema of their version", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(validate(map[string]any{"apiVersion": "v1", "name": "Ada"})).To(Succeed())
+		g.Expect(validate(map[string]any{"apiVersion": "v2", "firstName": "Ada", "lastName": "Lovelace"})).To(Succeed())
+		g.Expect(validate(map[string]any{"apiVersion": "v2", "name": "Ada"})).To(MatchError(ContainSubstring(`key "firstName" is required`)))
+	})
+
+	t.Run("rejects unsupported versions", func(t *testing.T) {
+		g := NewWithT(t)
+		err := validate(map[string]any{"apiVersion": "v3"})
+		g.Expect(err).To(MatchError(`version "v3" is not supported, must be one of "v1", "v2"`))
+		var valErr *validation.Error
+		g.Expect(errors.As(err, &valErr)).To(BeTrue())
+		g.Expect(valErr.Code()).To(Equal("unsupported_version"))
+		g.Expect(validate(map[string]any{"name": "Ada"})).To(MatchError(`version is required, one of "v1", "v2"`))
+	})
+}
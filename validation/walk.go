@@ -0,0 +1,271 @@
+package validation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Visitor is called by Walk for every value in a traversed structure.
+// path is the location of the value, such as "orders[2].address.city"; it is empty for the root.
+// A returned error is attributed to the path.
+type Visitor func(path string, value any) error
+
+// Walk traverses value depth-first through pointers, struct fields, slices, arrays and maps.
+// Every value implementing Validatable or ValidatableCtx has its Validate method called, with
+// context.Background() for the latter, and the visitor, if not nil, is called for every value.
+// Errors are attributed to the path of the value they came from (see FieldError) and
+// returned joined.
+//
+// Struct fields are named after their json tag when present, like Field does. Unexported
+// fields are skipped, and pointers already visited are not traversed again, so cyclic
+// structures are safe to walk.
+//
+// Because Walk validates every level, Validate methods used with it should not also call
+// ValidateNested on their children.
+//
+// Example:
+//
+//	err := validation.Walk(order, nil) // validates the order, its customer, every line item...
+//	// Errors look like: "items[1].quantity: must be positive"
+func Walk(value any, visitor Visitor) error {
+	return WalkCtx(context.Background(), value, visitor)
+}
+
+// WalkCtx is like Walk, passing ctx to values implementing ValidatableCtx.
+func WalkCtx(ctx context.Context, value any, visitor Visitor) error {
+	w := &walker{ctx: ctx, visitor: visitor, seen: make(map[visit]bool)}
+	w.walk(reflect.ValueOf(value), "")
+	return errors.Join(w.errs...)
+}
+
+// ValidateNestedDeep is like ValidateNested, but also looks through pointers, slices, arrays
+// and maps, calling the Validate method of every element implementing Validatable or
+// ValidatableCtx. Errors are prefixed with the element path, such as "[2]: name: required"
+// or "[admin]: email: required".
+//
+// Unlike Walk, it does not descend into struct fields: each struct is responsible for its
+// own fields through its Validate method.
+//
+// Example:
+//
+//	func (t Team) Validate() error {
+//	    return errors.Join(
+//	        validation.Validate(t.Name, validation.Required[string]()),
+//	        validation.NewFieldError("members", validation.ValidateNestedDeep(t.Members)), // []*Member
+//	    )
+//	}
+func ValidateNestedDeep[T any](value T) error {
+	w := &walker{ctx: context.Background(), containersOnly: true, seen: make(map[visit]bool)}
+	w.walk(reflect.ValueOf(value), "")
+	return errors.Join(w.errs...)
+}
+
+type walker struct {
+	ctx     context.Context
+	visitor Visitor
+	// containersOnly stops the traversal at struct values and at values implementing
+	// Validatable, only descending through pointers, slices, arrays and maps.
+	containersOnly bool
+	seen           map[visit]bool
+	errs           []error
+}
+
+// visit identifies a traversed pointer. The type is part of the key because a pointer to a
+// struct and a pointer to its first field share the same address.
+type visit struct {
+	typ reflect.Type
+	ptr uintptr
+}
+
+func (w *walker) walk(v reflect.Value, path string) {
+	validated := false
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return
+		}
+		if v.Kind() == reflect.Pointer {
+			key := visit{typ: v.Type(), ptr: v.Pointer()}
+			if w.seen[key] {
+				return
+			}
+			w.seen[key] = true
+		}
+		if !validated {
+			validated = w.validate(v, path)
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() || !v.CanInterface() {
+		return
+	}
+	if !validated {
+		validated = w.validate(v, path)
+	}
+	if w.containersOnly && (validated || v.Kind() == reflect.Struct) {
+		return
+	}
+	if w.visitor != nil {
+		w.add(path, w.visitor(path, v.Interface()))
+	}
+	w.descend(v, path)
+}
+
+// validate calls the Validate method if v implements Validatable or ValidatableCtx and
+// reports whether it did.
+func (w *walker) validate(v reflect.Value, path string) bool {
+	if !v.CanInterface() {
+		return false
+	}
+	switch validatable := v.Interface().(type) {
+	case Validatable:
+		w.add(path, validatable.Validate())
+	case ValidatableCtx:
+		w.add(path, validatable.Validate(w.ctx))
+	default:
+		return false
+	}
+	return true
+}
+
+func (w *walker) descend(v reflect.Value, path string) {
+	switch v.Kind() {
+	case reflect.Struct:
+		// Embedded fields are promoted: their Validate method, if any, was already called
+		// through the enclosing struct, and their fields are walked as its own.
+		for _, f := range walkFields(v.Type()) {
+			if field, ok := fieldByIndex(v, f.index); ok {
+				w.walk(field, joinPath(path, f.name))
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			w.walk(v.Index(i), fmt.Sprintf("%s[%d]", path, i))
+		}
+	case reflect.Map:
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j])
+		})
+		for _, k := range keys {
+			w.walk(v.MapIndex(k), fmt.Sprintf("%s[%v]", path, k))
+		}
+	}
+}
+
+// walkField is an exported field of a struct or one promoted from the structs it embeds.
+type walkField struct {
+	name   string
+	index  []int
+	tagged bool
+}
+
+// walkFields returns the fields of struct type t in order, resolving fields of the same
+// name like encoding/json: the least nested field wins, then the only one with a json tag
+// naming it, and the others are dropped.
+func walkFields(t reflect.Type) []walkField {
+	var fields []walkField
+	collectFields(t, nil, map[reflect.Type]bool{t: true}, &fields)
+
+	positions := make(map[string][]int) // name -> positions in fields
+	for i, f := range fields {
+		positions[f.name] = append(positions[f.name], i)
+	}
+	var visible []walkField
+	for i, f := range fields {
+		if dominantField(fields, positions[f.name]) == i {
+			visible = append(visible, f)
+		}
+	}
+	return visible
+}
+
+// dominantField returns the position of the field winning among the fields of the same
+// name at positions, or -1 if they are ambiguous.
+func dominantField(fields []walkField, positions []int) int {
+	depth := len(fields[positions[0]].index)
+	for _, p := range positions[1:] {
+		depth = min(depth, len(fields[p].index))
+	}
+	var shallowest, tagged []int
+	for _, p := range positions {
+		if len(fields[p].index) == depth {
+			shallowest = append(shallowest, p)
+			if fields[p].tagged {
+				tagged = append(tagged, p)
+			}
+		}
+	}
+	switch {
+	case len(shallowest) == 1:
+		return shallowest[0]
+	case len(tagged) == 1:
+		return tagged[0]
+	}
+	return -1
+}
+
+// collectFields appends the exported fields of struct type t to fields, descending into
+// the structs it embeds unless they are already being visited.
+func collectFields(t reflect.Type, index []int, visiting map[reflect.Type]bool, fields *[]walkField) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		fieldIndex := append(append([]int(nil), index...), i)
+		tag, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		tagged := tag != "" && tag != "-"
+		if embedded := f.Type; f.Anonymous && !tagged {
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				if !visiting[embedded] {
+					visiting[embedded] = true
+					collectFields(embedded, fieldIndex, visiting, fields)
+					delete(visiting, embedded)
+				}
+				continue
+			}
+		}
+		*fields = append(*fields, walkField{name: fieldName(f), index: fieldIndex, tagged: tagged})
+	}
+}
+
+// fieldByIndex returns the field of struct v at index, and false if it is promoted through
+// a nil embedded pointer.
+func fieldByIndex(v reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, true
+}
+
+// add records err, attributed to path unless path is the root.
+func (w *walker) add(path string, err error) {
+	if err == nil {
+		return
+	}
+	if path != "" {
+		err = NewFieldError(path, err)
+	}
+	w.errs = append(w.errs, err)
+}
+
+// joinPath appends a field name to a path using dot notation, or an index such as "[2]".
+func joinPath(path, name string) string {
+	if path == "" || strings.HasPrefix(name, "[") {
+		return path + name
+	}
+	return path + "." + name
+}
@@ -0,0 +1,191 @@
+package validation_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/quantumcycle/protego/validation"
+)
+
+type walkItem struct {
+	SKU      string `json:"sku"`
+	Quantity int    `json:"quantity"`
+}
+
+func (i walkItem) Validate() error {
+	return validation.ValidateStruct(
+		validation.Field(&i, &i.Quantity, validation.Positive[int]()),
+	)
+}
+
+type walkAddress struct {
+	City string `json:"city"`
+}
+
+func (a *walkAddress) Validate() error {
+	return validation.ValidateStruct(
+		validation.Field(a, &a.City, validation.Required[string]()),
+	)
+}
+
+type walkOrder struct {
+	ID       string                  `json:"id"`
+	Items    []walkItem              `json:"items"`
+	Shipping *walkAddress            `json:"shipping"`
+	Extra    map[string]*walkAddress `json:"extra"`
+	Parent   *walkOrder              `json:"parent"`
+	note     walkItem
+}
+
+func (o walkOrder) Validate() error {
+	return validation.ValidateStruct(
+		validation.Field(&o, &o.ID, validation.Required[string]()),
+	)
+}
+
+func TestWalk(t *testing.T) {
+	valid := func() *walkOrder {
+		return &walkOrder{
+			ID:       "o-1",
+			Items:    []walkItem{{SKU: "a", Quantity: 1}, {SKU: "b", Quantity: 2}},
+			Shipping: &walkAddress{City: "Paris"},
+		}
+	}
+
+	t.Run("passes valid structures", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(validation.Walk(valid(), nil)).To(Succeed())
+	})
+
+	t.Run("validates every level with full paths", func(t *testing.T) {
+		g := NewWithT(t)
+		order := valid()
+		order.ID = ""
+		order.Items[1].Quantity = 0
+		order.Shipping.City = ""
+		order.Extra = map[string]*walkAddress{"billing": {}, "home": {City: "Lyon"}}
+		err := validation.Walk(order, nil)
+		g.Expect(err).To(HaveOccurred())
+		g.Expect(validation.IsValidationError(err)).To(BeTrue())
+		g.Expect(err.Error()).To(Equal("id: required\n" +
+			"items[1]: quantity: must be positive\n" +
+			"shipping: city: required\n" +
+			"extra[billing]: city: required"))
+	})
+
+	t.Run("skips unexported fields and nil pointers", func(t *testing.T) {
+		g := NewWithT(t)
+		order := valid()
+		order.Shipping = nil
+		order.note = walkItem{Quantity: -1}
+		g.Expect(validation.Walk(order, nil)).To(Succeed())
+	})
+
+	t.Run("handles cycles", func(t *testing.T) {
+		g := NewWithT(t)
+		order := valid()
+		order.Parent = order
+		g.Expect(validation.Walk(order, nil)).To(Succeed())
+	})
+
+	t.Run("calls the visitor with paths", func(t *testing.T) {
+		g := NewWithT(t)
+		var paths []string
+		err := validation.Walk(valid(), func(path string, value any) error {
+			paths = append(paths, path)
+			if s, ok := value.(string); ok && s == "b" {
+				return validation.NewValidationError("discontinued")
+			}
+			return nil
+		})
+		g.Expect(err).To(MatchError("items[1].sku: discontinued"))
+		g.Expect(paths).To(ContainElements("", "id", "items", "items[0]", "items[0].sku", "shipping.city"))
+	})
+
+	t.Run("validates slices at the root", func(t *testing.T) {
+		g := NewWithT(t)
+		err := validation.Walk([]walkItem{{Quantity: 1}, {Quantity: 0}}, nil)
+		g.Expect(err).To(MatchError("[1]: quantity: must be positive"))
+	})
+
+	t.Run("skips promoted fields shadowed by other fields", func(t *testing.T) {
+		g := NewWithT(t)
+		type Audit struct {
+			ID    string
+			Notes string
+		}
+		type Review struct {
+			Notes string
+		}
+		type Tagged struct {
+			Notes string `json:"Notes,omitempty"`
+		}
+		visit := func(value any) []string {
+			var fields []string
+			g.Expect(validation.Walk(value, func(path string, value any) error {
+				if s, ok := value.(string); ok {
+					fields = append(fields, path+"="+s)
+				}
+				return nil
+			})).To(Succeed())
+			return fields
+		}
+
+		type shadowing struct {
+			*Audit
+			ID string
+		}
+		g.Expect(visit(shadowing{Audit: &Audit{ID: "inner", Notes: "audit"}, ID: "outer"})).To(Equal([]string{"Notes=audit", "ID=outer"}))
+		g.Expect(visit(shadowing{ID: "outer"})).To(Equal([]string{"ID=outer"}))
+
+		type ambiguous struct {
+			Audit
+			Review
+		}
+		g.Expect(visit(ambiguous{Audit{ID: "a", Notes: "audit"}, Review{Notes: "review"}})).To(Equal([]string{"ID=a"}))
+
+		type taggedWins struct {
+			Review
+			Tagged
+		}
+		g.Expect(visit(taggedWins{Review{Notes: "review"}, Tagged{Notes: "tagged"}})).To(Equal([]string{"Notes=tagged"}))
+	})
+}
+
+func TestValidateNestedDeep(t *testing.T) {
+	t.Run("validates slice elements", func(t *testing.T) {
+		g := NewWithT(t)
+		err := validation.ValidateNestedDeep([]walkItem{{Quantity: 1}, {Quantity: 0}})
+		g.Expect(err).To(MatchError("[1]: quantity: must be positive"))
+	})
+
+	t.Run("validates pointers and map values", func(t *testing.T) {
+		g := NewWithT(t)
+		err := validation.ValidateNestedDeep(map[string][]*walkAddress{
+			"eu": {{City: "Paris"}, {}},
+			"us": {nil},
+		})
+		g.Expect(err).To(MatchError("[eu][1]: city: required"))
+	})
+
+	t.Run("validates a single value like ValidateNested", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(validation.ValidateNestedDeep(walkItem{Quantity: 0})).To(MatchError("quantity: must be positive"))
+		g.Expect(validation.ValidateNestedDeep("not validatable")).To(Succeed())
+	})
+
+	t.Run("does not descend into struct fields", func(t *testing.T) {
+		g := NewWithT(t)
+		type wrapper struct {
+			Item walkItem
+		}
+		g.Expect(validation.ValidateNestedDeep([]wrapper{{Item: walkItem{Quantity: 0}}})).To(Succeed())
+	})
+
+	t.Run("stops at Validatable elements", func(t *testing.T) {
+		g := NewWithT(t)
+		order := walkOrder{ID: "o-1", Items: []walkItem{{Quantity: 0}}}
+		g.Expect(validation.ValidateNestedDeep([]walkOrder{order})).To(Succeed())
+	})
+}